@@ -0,0 +1,242 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+
+	"github.com/ajm188/go-ff/feature"
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	diffCmd = &cobra.Command{
+		Use:          "diff -f file [--prune] [-j|--json] [--exit-code]",
+		Short:        "show what apply would change on the server",
+		Args:         cobra.NoArgs,
+		RunE:         diffFeatures,
+		SilenceUsage: true,
+	}
+	applyCmd = &cobra.Command{
+		Use:   "apply -f file [--prune] [-j|--json]",
+		Short: "create and update features on the server to match a config file",
+		Long: `Apply creates and updates features on the server to match the given config
+file, which is in the same format as the server's --config file. With --prune,
+features on the server that are not in the file are deleted.
+
+All of the changes are applied atomically, and only if no feature was changed
+by someone else since apply read it.`,
+		Args:         cobra.NoArgs,
+		RunE:         applyFeatures,
+		SilenceUsage: true,
+	}
+)
+
+var applyOptions = struct {
+	File     string
+	Prune    bool
+	UseJSON  bool
+	ExitCode bool
+}{}
+
+// errDiff is returned by diff --exit-code when there are changes, so that the
+// client exits non-zero.
+var errDiff = errors.New("features differ")
+
+func diffFeatures(cmd *cobra.Command, args []string) error {
+	resp, err := reconcile(true)
+	if err != nil {
+		return err
+	}
+
+	if err := printChanges(resp, "to create", "to update", "to delete"); err != nil {
+		return err
+	}
+
+	if applyOptions.ExitCode && len(resp.Changes) > 0 {
+		return errDiff
+	}
+
+	return nil
+}
+
+func applyFeatures(cmd *cobra.Command, args []string) error {
+	resp, err := reconcile(false)
+	if err != nil {
+		return err
+	}
+
+	if resp.Pending != nil && !applyOptions.UseJSON {
+		if err := printChanges(resp, "to create", "to update", "to delete"); err != nil {
+			return err
+		}
+
+		printPending(resp.Pending)
+		return nil
+	}
+
+	return printChanges(resp, "created", "updated", "deleted")
+}
+
+// reconcile sends the operations to make the server match the config file, as
+// a single ApplyFeatures request. Each operation expects the feature to be at
+// the version we read (or, for new features, to still not exist), so that we
+// don't clobber concurrent edits.
+func reconcile(dryRun bool) (*featurepb.ApplyFeaturesResponse, error) {
+	desired, err := readFeatureFile(applyOptions.File)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetFeatures(ctx, &featurepb.GetFeaturesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]*featurepb.Feature, len(resp.Features))
+	for _, f := range resp.Features {
+		current[f.Name] = f
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	ops := make([]*featurepb.FeatureOperation, 0, len(names))
+	for _, name := range names {
+		op := &featurepb.FeatureOperation{
+			Op: &featurepb.FeatureOperation_Set{Set: desired[name]},
+		}
+
+		if f, ok := current[name]; ok {
+			op.ExpectedVersion = f.Version
+		} else {
+			op.ExpectAbsent = true
+		}
+
+		ops = append(ops, op)
+	}
+
+	if applyOptions.Prune {
+		var stale []*featurepb.Feature
+		for name, f := range current {
+			if _, ok := desired[name]; !ok {
+				stale = append(stale, f)
+			}
+		}
+
+		sort.Slice(stale, func(i, j int) bool { return stale[i].Name < stale[j].Name })
+
+		for _, f := range stale {
+			ops = append(ops, &featurepb.FeatureOperation{
+				Op:              &featurepb.FeatureOperation_Delete{Delete: f.Name},
+				ExpectedVersion: f.Version,
+			})
+		}
+	}
+
+	return client.ApplyFeatures(ctx, &featurepb.ApplyFeaturesRequest{
+		Operations: ops,
+		DryRun:     dryRun,
+	})
+}
+
+// readFeatureFile reads features from a file in the server's config format,
+// keyed by name. As with the server, the key takes precedence over any name
+// in the feature itself, and versions are ignored.
+func readFeatureFile(path string) (map[string]*featurepb.Feature, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := feature.UnmarshalConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := feature.ValidateFeatures(m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	features := make(map[string]*featurepb.Feature, len(m))
+	for name, f := range m {
+		f.Version = 0
+		features[name] = f.Feature
+	}
+
+	return features, nil
+}
+
+// printChanges prints the changes in resp, followed by a summary using the
+// given verbs, or, with --json, the response as JSON.
+func printChanges(resp *featurepb.ApplyFeaturesResponse, created, updated, deleted string) error {
+	m := jsonpb.Marshaler{}
+
+	if applyOptions.UseJSON {
+		data, err := m.MarshalToString(resp)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(data)
+		return nil
+	}
+
+	var ncreated, nupdated, ndeleted int
+
+	for _, c := range resp.Changes {
+		switch {
+		case c.Before == nil:
+			ncreated++
+			fmt.Printf("+ %s\n", c.Name)
+		case c.After == nil:
+			ndeleted++
+			fmt.Printf("- %s\n", c.Name)
+		default:
+			nupdated++
+			fmt.Printf("~ %s\n", c.Name)
+		}
+
+		for _, f := range []struct {
+			prefix string
+			feat   *featurepb.Feature
+		}{
+			{"-", c.Before},
+			{"+", c.After},
+		} {
+			if f.feat == nil {
+				continue
+			}
+
+			data, err := m.MarshalToString(withoutVersion(f.feat))
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  %s %s\n", f.prefix, data)
+		}
+	}
+
+	fmt.Printf("%d %s, %d %s, %d %s\n", ncreated, created, nupdated, updated, ndeleted, deleted)
+	return nil
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{diffCmd, applyCmd} {
+		cmd.Flags().StringVarP(&applyOptions.File, "file", "f", "", "config file with the desired features")
+		cmd.Flags().BoolVar(&applyOptions.Prune, "prune", false, "delete features that are not in the config file")
+		cmd.Flags().BoolVarP(&applyOptions.UseJSON, "json", "j", false, "output the changes as JSON")
+		cmd.MarkFlagRequired("file")
+		rootCmd.AddCommand(cmd)
+	}
+
+	diffCmd.Flags().BoolVar(&applyOptions.ExitCode, "exit-code", false, "exit with status 1 if there are changes")
+}
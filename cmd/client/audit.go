@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+
+	"github.com/ajm188/go-ff/feature"
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	auditCmd = &cobra.Command{
+		Use:   "audit [--feature name] [--user actor] [--since time] [--until time] [--limit n] [-j|--json]",
+		Short: "query the server's audit log",
+		Long: `Audit lists the changes recorded in the server's audit log (see the server's
+--audit-log flag), oldest first.
+
+--since and --until take an RFC 3339 time (2006-01-02T15:04:05Z), a date
+(2006-01-02, in UTC), or a duration, meaning that long ago (e.g. 24h).`,
+		Args:         cobra.NoArgs,
+		RunE:         listAuditEntries,
+		SilenceUsage: true,
+	}
+	auditVerifyCmd = &cobra.Command{
+		Use:   "verify file...",
+		Short: "check the hash chain of audit log files",
+		Long: `Verify checks that the hash chain of each audit log file (see the server's
+--audit-hash-chain flag) is intact, and prints the hash of its last entry.
+
+Removing entries from the end of a log leaves the chain intact, so keep the
+printed hash somewhere else, and check that later verifications include it.
+Verify does not contact a server.`,
+		Args:         cobra.MinimumNArgs(1),
+		RunE:         verifyAuditLogs,
+		SilenceUsage: true,
+		Annotations:  map[string]string{offlineAnnotation: "true"},
+	}
+)
+
+var auditOptions = struct {
+	Feature string
+	User    string
+	Since   string
+	Until   string
+	Limit   uint32
+	UseJSON bool
+}{}
+
+func listAuditEntries(cmd *cobra.Command, args []string) error {
+	req := &featurepb.ListAuditEntriesRequest{
+		Feature: auditOptions.Feature,
+		Actor:   auditOptions.User,
+		Limit:   auditOptions.Limit,
+	}
+
+	for _, t := range []struct {
+		flag  string
+		value string
+		dst   *int64
+	}{
+		{"--since", auditOptions.Since, &req.SinceUnixNano},
+		{"--until", auditOptions.Until, &req.UntilUnixNano},
+	} {
+		if t.value == "" {
+			continue
+		}
+
+		when, err := parseAuditTime(t.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", t.flag, err)
+		}
+
+		*t.dst = when.UnixNano()
+	}
+
+	resp, err := client.ListAuditEntries(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	m := jsonpb.Marshaler{}
+
+	if auditOptions.UseJSON {
+		for _, e := range resp.Entries {
+			data, err := m.MarshalToString(e)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(data)
+		}
+
+		return nil
+	}
+
+	for _, e := range resp.Entries {
+		fmt.Printf("%s %s %s %s v%d", time.Unix(0, e.TimeUnixNano).UTC().Format(time.RFC3339), e.Actor, e.Action, e.Feature, e.Version)
+
+		if e.Method != "" {
+			fmt.Printf(" via %s", e.Method)
+		}
+
+		if e.Peer != "" {
+			fmt.Printf(" from %s", e.Peer)
+		}
+
+		fmt.Println()
+
+		if e.Reason != "" {
+			fmt.Printf("  reason: %s\n", e.Reason)
+		}
+
+		for _, f := range []struct {
+			prefix string
+			feat   *featurepb.Feature
+		}{
+			{"-", e.Before},
+			{"+", e.After},
+		} {
+			if f.feat == nil {
+				continue
+			}
+
+			data, err := m.MarshalToString(withoutVersion(f.feat))
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  %s %s\n", f.prefix, data)
+		}
+	}
+
+	return nil
+}
+
+// parseAuditTime parses a time given to --since or --until.
+func parseAuditTime(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
+
+func verifyAuditLogs(cmd *cobra.Command, args []string) error {
+	failed := false
+
+	for _, path := range args {
+		n, err := feature.VerifyAuditLog(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			failed = true
+			continue
+		}
+
+		if n == 0 {
+			fmt.Printf("%s: not hash-chained\n", path)
+			continue
+		}
+
+		entries, err := feature.ReadAuditLog(path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: ok, %d chained entries, last hash %s\n", path, n, entries[len(entries)-1].Hash)
+	}
+
+	if failed {
+		return errInvalidFiles
+	}
+
+	return nil
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditOptions.Feature, "feature", "", "only list changes to this feature")
+	auditCmd.Flags().StringVar(&auditOptions.User, "user", "", "only list changes made by this actor")
+	auditCmd.Flags().StringVar(&auditOptions.Since, "since", "", "only list changes made at or after this time")
+	auditCmd.Flags().StringVar(&auditOptions.Until, "until", "", "only list changes made before this time")
+	auditCmd.Flags().Uint32Var(&auditOptions.Limit, "limit", 0, "only list the most recent n matching changes")
+	auditCmd.Flags().BoolVarP(&auditOptions.UseJSON, "json", "j", false, "output the entries as JSON lines")
+	auditCmd.AddCommand(auditVerifyCmd)
+	rootCmd.AddCommand(auditCmd)
+}
@@ -9,23 +9,30 @@ import (
 )
 
 var deleteFeatureCmd = &cobra.Command{
-	Use:          "delete feature",
+	Use:          "delete feature [--expected-version N]",
 	Aliases:      []string{"remove"},
 	Args:         cobra.ExactArgs(1),
 	RunE:         deleteFeature,
 	SilenceUsage: true,
 }
 
+var deleteFeatureOptions = struct {
+	ExpectedVersion uint64
+}{}
+
 func deleteFeature(cmd *cobra.Command, args []string) error {
 	resp, err := client.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{
-		Name: cmd.Flags().Arg(0),
+		Name:            cmd.Flags().Arg(0),
+		ExpectedVersion: deleteFeatureOptions.ExpectedVersion,
 	})
 	if err != nil {
 		return err
 	}
 
-	switch resp.Feature {
-	case nil:
+	switch {
+	case resp.Pending != nil:
+		printPending(resp.Pending)
+	case resp.Feature == nil:
 		fmt.Printf("no such feature %s\n", cmd.Flags().Arg(0))
 	default:
 		fmt.Printf("deleted feature %s:%v\n", resp.Feature.Name, resp.Feature.Enabled)
@@ -35,5 +42,6 @@ func deleteFeature(cmd *cobra.Command, args []string) error {
 }
 
 func init() {
+	deleteFeatureCmd.Flags().Uint64Var(&deleteFeatureOptions.ExpectedVersion, "expected-version", 0, "only delete the feature if it is at this version")
 	rootCmd.AddCommand(deleteFeatureCmd)
 }
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var evalFeatureCmd = &cobra.Command{
+	Use:          "eval {--all | feature [feature ...]} [-p key=value ...] [--params JSON] [-j|--json]",
+	Aliases:      []string{"evaluate"},
+	Short:        "evaluate features on the server, and explain the results",
+	RunE:         evalFeature,
+	SilenceUsage: true,
+}
+
+var evalFeatureOptions = struct {
+	All        bool
+	Params     []string
+	ParamsJSON string
+	UseJSON    bool
+}{}
+
+func evalFeature(cmd *cobra.Command, args []string) error {
+	switch {
+	case evalFeatureOptions.All && len(args) > 0:
+		return fmt.Errorf("cannot specify feature names with --all")
+	case !evalFeatureOptions.All && len(args) == 0:
+		return fmt.Errorf("must specify at least one feature, or --all")
+	}
+
+	params, err := parseParameters(evalFeatureOptions.ParamsJSON, evalFeatureOptions.Params)
+	if err != nil {
+		return err
+	}
+
+	var details []*featurepb.EvaluationDetail
+
+	switch len(args) {
+	case 1:
+		resp, err := client.EvaluateFeature(ctx, &featurepb.EvaluateFeatureRequest{
+			Name:       args[0],
+			Parameters: params,
+		})
+		if err != nil {
+			return err
+		}
+
+		details = []*featurepb.EvaluationDetail{resp.Detail}
+	default:
+		resp, err := client.EvaluateFeatures(ctx, &featurepb.EvaluateFeaturesRequest{
+			Names:      args,
+			Parameters: params,
+		})
+		if err != nil {
+			return err
+		}
+
+		details = resp.Details
+	}
+
+	if evalFeatureOptions.UseJSON {
+		m := jsonpb.Marshaler{Indent: "  "}
+		for _, d := range details {
+			data, err := m.MarshalToString(d)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(data)
+		}
+
+		return nil
+	}
+
+	for _, d := range details {
+		fmt.Println(formatDetail(d))
+	}
+
+	return nil
+}
+
+// formatDetail returns a human-readable, single-line description of an
+// evaluation.
+func formatDetail(d *featurepb.EvaluationDetail) string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "%s:%v reason=%s", d.Name, d.Enabled, d.Reason)
+
+	if d.Variant != "" {
+		fmt.Fprintf(buf, " variant=%s", d.Variant)
+	}
+
+	if d.Value != nil {
+		m := jsonpb.Marshaler{}
+		if data, err := m.MarshalToString(d.Value); err == nil {
+			fmt.Fprintf(buf, " value=%s", data)
+		}
+	}
+
+	if d.RuleIndex >= 0 {
+		fmt.Fprintf(buf, " rule=%d", d.RuleIndex)
+	}
+
+	if d.Bucket >= 0 {
+		fmt.Fprintf(buf, " bucket=%d", d.Bucket)
+	}
+
+	if d.Version > 0 {
+		fmt.Fprintf(buf, " version=%d", d.Version)
+	}
+
+	if d.Error != "" {
+		fmt.Fprintf(buf, " error=%q", d.Error)
+	}
+
+	return buf.String()
+}
+
+// parseParameters builds a Struct from a JSON object and/or a list of
+// key=value pairs. Values that are valid JSON (numbers, bools, etc) are
+// decoded; anything else is treated as a string. Pairs take precedence over
+// keys in the JSON object.
+func parseParameters(paramsJSON string, pairs []string) (*structpb.Struct, error) {
+	m := map[string]interface{}{}
+
+	if paramsJSON != "" {
+		if err := json.Unmarshal([]byte(paramsJSON), &m); err != nil {
+			return nil, fmt.Errorf("--params must be a JSON object: %w", err)
+		}
+	}
+
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid parameter %q, must be of the form key=value", pair)
+		}
+
+		var v interface{}
+		if err := json.Unmarshal([]byte(parts[1]), &v); err != nil {
+			v = parts[1]
+		}
+
+		m[parts[0]] = v
+	}
+
+	return structpb.NewStruct(m)
+}
+
+func init() {
+	evalFeatureCmd.Flags().BoolVarP(&evalFeatureOptions.All, "all", "a", false, "evaluate all features")
+	evalFeatureCmd.Flags().StringArrayVarP(&evalFeatureOptions.Params, "param", "p", nil, "parameter in the form key=value; may be repeated")
+	evalFeatureCmd.Flags().StringVar(&evalFeatureOptions.ParamsJSON, "params", "", "parameters as a JSON object")
+	evalFeatureCmd.Flags().BoolVarP(&evalFeatureOptions.UseJSON, "json", "j", false, "output evaluation details as JSON")
+	rootCmd.AddCommand(evalFeatureCmd)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	historyCmd = &cobra.Command{
+		Use:          "history feature [-j|--json]",
+		Short:        "list every revision of a feature",
+		Args:         cobra.ExactArgs(1),
+		RunE:         listFeatureHistory,
+		SilenceUsage: true,
+	}
+	rollbackCmd = &cobra.Command{
+		Use:          "rollback feature version",
+		Short:        "restore a feature to its state as of a previous revision",
+		Args:         cobra.ExactArgs(2),
+		RunE:         rollbackFeature,
+		SilenceUsage: true,
+	}
+)
+
+var historyOptions = struct {
+	UseJSON bool
+}{}
+
+func listFeatureHistory(cmd *cobra.Command, args []string) error {
+	resp, err := client.ListFeatureHistory(ctx, &featurepb.ListFeatureHistoryRequest{
+		Name: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	m := jsonpb.Marshaler{}
+
+	if historyOptions.UseJSON {
+		data, err := m.MarshalToString(resp)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(data)
+		return nil
+	}
+
+	for _, rev := range resp.Revisions {
+		action := "update"
+		switch {
+		case rev.Before == nil:
+			action = "create"
+		case rev.After == nil:
+			action = "delete"
+		}
+
+		fmt.Printf("v%d %s %s %s\n", rev.Version, time.Unix(0, rev.TimeUnixNano).UTC().Format(time.RFC3339), rev.Actor, action)
+
+		for _, f := range []struct {
+			prefix string
+			feat   *featurepb.Feature
+		}{
+			{"-", rev.Before},
+			{"+", rev.After},
+		} {
+			if f.feat == nil {
+				continue
+			}
+
+			data, err := m.MarshalToString(withoutVersion(f.feat))
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  %s %s\n", f.prefix, data)
+		}
+	}
+
+	return nil
+}
+
+// withoutVersion returns a copy of the feature without its version, which is
+// redundant when shown alongside the revision.
+func withoutVersion(f *featurepb.Feature) *featurepb.Feature {
+	c := proto.Clone(f).(*featurepb.Feature)
+	c.Version = 0
+	return c
+}
+
+func rollbackFeature(cmd *cobra.Command, args []string) error {
+	name := cmd.Flags().Arg(0)
+
+	version, err := strconv.ParseUint(cmd.Flags().Arg(1), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid version %s: %w", cmd.Flags().Arg(1), err)
+	}
+
+	resp, err := client.RollbackFeature(ctx, &featurepb.RollbackFeatureRequest{
+		Name:    name,
+		Version: version,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.Pending != nil:
+		printPending(resp.Pending)
+	case resp.After == nil:
+		fmt.Printf("rolled back %s to v%d (deleted)\n", name, version)
+	default:
+		fmt.Printf("rolled back %s to v%d (now v%d) %s:%v\n", name, version, resp.After.Version, resp.After.Name, resp.After.Enabled)
+	}
+
+	return nil
+}
+
+func init() {
+	historyCmd.Flags().BoolVarP(&historyOptions.UseJSON, "json", "j", false, "output the history as JSON")
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(rollbackCmd)
+}
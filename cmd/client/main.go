@@ -2,45 +2,199 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
+	"os/user"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
+	"github.com/ajm188/go-ff/feature"
 	featurepb "github.com/ajm188/go-ff/proto/feature"
 )
 
+// offlineAnnotation marks commands that work without a server, so that the
+// client doesn't connect to one.
+const offlineAnnotation = "offline"
+
+// tokenEnv is the environment variable to read the bearer token from, if
+// neither --token nor --token-file is given.
+const tokenEnv = "FF_TOKEN"
+
 var (
 	ctx = context.Background()
 
-	addr   string
-	cc     *grpc.ClientConn
-	client featurepb.FeaturesClient
+	addr      string
+	actor     string
+	reason    string
+	token     string
+	tokenFile string
+	cc        *grpc.ClientConn
+	client    featurepb.FeaturesClient
+
+	useTLS        bool
+	tlsCA         string
+	tlsCert       string
+	tlsKey        string
+	tlsServerName string
 
 	rootCmd = &cobra.Command{
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			var err error
-			cc, err = grpc.Dial(addr, grpc.WithInsecure())
+			if cmd.Annotations[offlineAnnotation] != "" {
+				return nil
+			}
+
+			opts, err := dialOptions()
+			if err != nil {
+				return err
+			}
+
+			tok, err := bearerToken()
+			if err != nil {
+				return err
+			}
+
+			if tok != "" {
+				opts = append(opts, grpc.WithPerRPCCredentials(feature.BearerToken(tok)))
+			}
+
+			cc, err = grpc.Dial(addr, opts...)
 			if err != nil {
 				return err
 			}
 
 			client = featurepb.NewFeaturesClient(cc)
+
+			if actor != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, feature.ActorMetadataKey, actor)
+			}
+
+			if reason != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, feature.ReasonMetadataKey, reason)
+			}
+
 			return nil
 		},
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			if cc == nil {
+				return nil
+			}
+
 			return cc.Close()
 		},
 	}
 )
 
+// defaultActor returns the name of the current user, to attribute changes to
+// in the feature history.
+func defaultActor() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+
+	return os.Getenv("USER")
+}
+
+// dialOptions returns the options to connect to the server with: over TLS if
+// --tls or any of the other TLS flags are given, and in plaintext otherwise.
+func dialOptions() ([]grpc.DialOption, error) {
+	if !useTLS && tlsCA == "" && tlsCert == "" && tlsKey == "" && tlsServerName == "" {
+		return []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+
+	config, err := feature.ClientTLSConfig(tlsCA, tlsCert, tlsKey, tlsServerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}, nil
+}
+
+// bearerToken returns the token to authenticate to the server with, from
+// --token, --token-file, or $FF_TOKEN, in that order.
+func bearerToken() (string, error) {
+	switch {
+	case token != "" && tokenFile != "":
+		return "", fmt.Errorf("--token and --token-file are mutually exclusive")
+	case token != "":
+		return token, nil
+	case tokenFile != "":
+		data, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	return os.Getenv(tokenEnv), nil
+}
+
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&addr, "server", "s", ":15000", "server address to make requests against")
+	rootCmd.PersistentFlags().StringVar(&actor, "actor", defaultActor(), "who to attribute changes to in the feature history. servers that require a token record the token's name instead")
+	rootCmd.PersistentFlags().StringVar(&reason, "reason", "", "why the change is being made, for the audit log")
+	rootCmd.PersistentFlags().StringVar(&token, "token", "", "bearer token to authenticate to the server with (default $"+tokenEnv+")")
+	rootCmd.PersistentFlags().BoolVar(&useTLS, "tls", false, "connect to the server over TLS, verifying its certificate against the system CAs. implied by the other --tls-* flags")
+	rootCmd.PersistentFlags().StringVar(&tlsCA, "tls-ca", "", "path to PEM CA certificates to verify the server's certificate against, instead of the system CAs")
+	rootCmd.PersistentFlags().StringVar(&tlsCert, "tls-cert", "", "path to a PEM client certificate, for servers that require one")
+	rootCmd.PersistentFlags().StringVar(&tlsKey, "tls-key", "", "path to the PEM private key for --tls-cert")
+	rootCmd.PersistentFlags().StringVar(&tlsServerName, "tls-server-name", "", "name to verify the server's certificate against, if not the host in --server")
+	rootCmd.PersistentFlags().StringVar(&tokenFile, "token-file", "", "path to a file containing the bearer token to authenticate to the server with")
+}
+
+// formatError formats an error for the user. Validation problems, whether
+// found locally or by the server, are listed one per line.
+func formatError(err error) string {
+	switch st := status.Convert(err); st.Code() {
+	case codes.Unauthenticated:
+		return fmt.Sprintf("%s (pass --token or --token-file, or set $%s)", st.Message(), tokenEnv)
+	case codes.PermissionDenied:
+		return st.Message()
+	}
+
+	var (
+		problems []string
+		prefix   string
+	)
+
+	var verr *feature.ValidationError
+	if errors.As(err, &verr) {
+		// Keep any context the error was wrapped with, e.g. the file name.
+		prefix = strings.TrimSuffix(err.Error(), verr.Error())
+
+		for _, fe := range verr.Errors {
+			problems = append(problems, fe.Error())
+		}
+	}
+
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				problems = append(problems, v.Field+": "+v.Description)
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return err.Error()
+	}
+
+	return fmt.Sprintf("%s%v:\n  %s", prefix, feature.ErrInvalidFeature, strings.Join(problems, "\n  "))
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		log.Fatal(err)
+		log.Fatal(formatError(err))
 	}
 }
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	pendingCmd = &cobra.Command{
+		Use:   "pending [-j|--json]",
+		Short: "list changes to protected features awaiting approval",
+		Long: `Pending lists the changes to protected features that are awaiting approval,
+oldest first. Changes to protected features only take effect once someone
+other than their requester approves them, and expire if not approved in time.`,
+		Args:         cobra.NoArgs,
+		RunE:         listPendingChanges,
+		SilenceUsage: true,
+	}
+	approveCmd = &cobra.Command{
+		Use:          "approve id",
+		Short:        "approve a pending change, making it take effect",
+		Args:         cobra.ExactArgs(1),
+		RunE:         approveChange,
+		SilenceUsage: true,
+	}
+	rejectCmd = &cobra.Command{
+		Use:          "reject id",
+		Short:        "reject a pending change, or withdraw one of your own",
+		Args:         cobra.ExactArgs(1),
+		RunE:         rejectChange,
+		SilenceUsage: true,
+	}
+)
+
+var pendingOptions = struct {
+	UseJSON bool
+}{}
+
+func listPendingChanges(cmd *cobra.Command, args []string) error {
+	resp, err := client.ListPendingChanges(ctx, &featurepb.ListPendingChangesRequest{})
+	if err != nil {
+		return err
+	}
+
+	if pendingOptions.UseJSON {
+		m := jsonpb.Marshaler{}
+
+		for _, pc := range resp.Pending {
+			data, err := m.MarshalToString(pc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(data)
+		}
+
+		return nil
+	}
+
+	for _, pc := range resp.Pending {
+		if err := printPendingChange(pc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printPendingChange prints who requested pc and when, and the changes it
+// would make.
+func printPendingChange(pc *featurepb.PendingChange) error {
+	fmt.Printf("%s %s by %s, expires %s: %s\n",
+		pc.Id,
+		time.Unix(0, pc.CreateTimeUnixNano).UTC().Format(time.RFC3339),
+		pc.RequestedBy,
+		time.Unix(0, pc.ExpireTimeUnixNano).UTC().Format(time.RFC3339),
+		strings.Join(pendingFeatureNames(pc), ", "),
+	)
+
+	if pc.Reason != "" {
+		fmt.Printf("  reason: %s\n", pc.Reason)
+	}
+
+	m := jsonpb.Marshaler{}
+
+	for _, c := range pc.Changes {
+		for _, f := range []struct {
+			prefix string
+			feat   *featurepb.Feature
+		}{
+			{"-", c.Before},
+			{"+", c.After},
+		} {
+			if f.feat == nil {
+				continue
+			}
+
+			data, err := m.MarshalToString(withoutVersion(f.feat))
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  %s %s\n", f.prefix, data)
+		}
+	}
+
+	return nil
+}
+
+// printPending tells the user that their change to a protected feature has
+// not been made yet, and how to get it approved.
+func printPending(pc *featurepb.PendingChange) {
+	fmt.Printf("%s is protected; change %s is awaiting approval by someone else (client approve %s), until %s\n",
+		strings.Join(pendingFeatureNames(pc), ", "),
+		pc.Id,
+		pc.Id,
+		time.Unix(0, pc.ExpireTimeUnixNano).UTC().Format(time.RFC3339),
+	)
+}
+
+// pendingFeatureNames returns the names of the features pc changes.
+func pendingFeatureNames(pc *featurepb.PendingChange) []string {
+	names := make([]string, 0, len(pc.Changes))
+	for _, c := range pc.Changes {
+		names = append(names, c.Name)
+	}
+
+	return names
+}
+
+func approveChange(cmd *cobra.Command, args []string) error {
+	resp, err := client.ApproveChange(ctx, &featurepb.ApproveChangeRequest{
+		Id: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range resp.Changes {
+		switch c.After {
+		case nil:
+			fmt.Printf("deleted feature %s\n", c.Name)
+		default:
+			fmt.Printf("set feature %s:%v (now v%d)\n", c.After.Name, c.After.Enabled, c.After.Version)
+		}
+	}
+
+	return nil
+}
+
+func rejectChange(cmd *cobra.Command, args []string) error {
+	resp, err := client.RejectChange(ctx, &featurepb.RejectChangeRequest{
+		Id: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("rejected change %s to %s by %s\n", resp.Pending.Id, strings.Join(pendingFeatureNames(resp.Pending), ", "), resp.Pending.RequestedBy)
+	return nil
+}
+
+func init() {
+	pendingCmd.Flags().BoolVarP(&pendingOptions.UseJSON, "json", "j", false, "output the pending changes as JSON lines")
+	rootCmd.AddCommand(pendingCmd)
+	rootCmd.AddCommand(approveCmd)
+	rootCmd.AddCommand(rejectCmd)
+}
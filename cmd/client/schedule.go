@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	scheduleCmd = &cobra.Command{
+		Use:   "schedule",
+		Short: "schedule changes to features for the server to make later",
+		Long: `Schedule changes to a feature that the server makes on its own at given
+times, e.g. to launch a feature, or ramp it up over a day.
+
+Times are an RFC 3339 time (2006-01-02T15:04:05Z), or a duration, meaning
+that long from now (e.g. 2h30m).`,
+	}
+	scheduleSetCmd = &cobra.Command{
+		Use:   "set feature-name time -f file",
+		Short: "schedule replacing a feature with one from a config file",
+		Long: `Set schedules replacing the named feature (or creating it) with the feature
+of that name in the given file, which is in the same format as the server's
+--config file.`,
+		Args:         cobra.ExactArgs(2),
+		RunE:         scheduleSet,
+		SilenceUsage: true,
+	}
+	scheduleEnableCmd = &cobra.Command{
+		Use:          "enable feature-name time",
+		Short:        "schedule enabling a CONSTANT feature",
+		Args:         cobra.ExactArgs(2),
+		RunE:         scheduleEnabled(true),
+		SilenceUsage: true,
+	}
+	scheduleDisableCmd = &cobra.Command{
+		Use:          "disable feature-name time",
+		Short:        "schedule disabling a CONSTANT feature",
+		Args:         cobra.ExactArgs(2),
+		RunE:         scheduleEnabled(false),
+		SilenceUsage: true,
+	}
+	scheduleRampCmd = &cobra.Command{
+		Use:   "ramp feature-name percentage@time...",
+		Short: "schedule changing the percentage of a PERCENTAGE_BASED feature in steps",
+		Long: `Ramp schedules changing the percentage of a PERCENTAGE_BASED feature at each
+of the given times, in order, leaving the rest of the feature as it is when
+each step is made. For example, to go to 1% in an hour, and then to 10%, 50%
+and 100% at two-hour intervals:
+
+    client schedule ramp new_search 1%@1h 10%@3h 50%@5h 100%@7h`,
+		Args:         cobra.MinimumNArgs(2),
+		RunE:         scheduleRamp,
+		SilenceUsage: true,
+	}
+	scheduleListCmd = &cobra.Command{
+		Use:          "list [feature-name] [-j|--json]",
+		Short:        "list scheduled changes, ordered by when their next step is made",
+		Args:         cobra.MaximumNArgs(1),
+		RunE:         listScheduledChanges,
+		SilenceUsage: true,
+	}
+	scheduleCancelCmd = &cobra.Command{
+		Use:          "cancel id",
+		Short:        "cancel the remaining steps of a scheduled change",
+		Args:         cobra.ExactArgs(1),
+		RunE:         cancelScheduledChange,
+		SilenceUsage: true,
+	}
+)
+
+var scheduleOptions = struct {
+	File    string
+	UseJSON bool
+}{}
+
+func scheduleSet(cmd *cobra.Command, args []string) error {
+	name := cmd.Flags().Arg(0)
+
+	at, err := parseScheduleTime(cmd.Flags().Arg(1))
+	if err != nil {
+		return fmt.Errorf("invalid time: %w", err)
+	}
+
+	features, err := readFeatureFile(scheduleOptions.File)
+	if err != nil {
+		return err
+	}
+
+	f, ok := features[name]
+	if !ok {
+		return fmt.Errorf("%s has no feature named %s", scheduleOptions.File, name)
+	}
+
+	return scheduleFeature(name, &featurepb.ScheduledStep{
+		TimeUnixNano: at.UnixNano(),
+		Change:       &featurepb.ScheduledStep_Feature{Feature: f},
+	})
+}
+
+func scheduleEnabled(enabled bool) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		at, err := parseScheduleTime(cmd.Flags().Arg(1))
+		if err != nil {
+			return fmt.Errorf("invalid time: %w", err)
+		}
+
+		return scheduleFeature(cmd.Flags().Arg(0), &featurepb.ScheduledStep{
+			TimeUnixNano: at.UnixNano(),
+			Change:       &featurepb.ScheduledStep_Enabled{Enabled: enabled},
+		})
+	}
+}
+
+func scheduleRamp(cmd *cobra.Command, args []string) error {
+	steps := make([]*featurepb.ScheduledStep, 0, len(args)-1)
+
+	for _, spec := range args[1:] {
+		parts := strings.SplitN(spec, "@", 2)
+		if len(parts) != 2 || !strings.HasSuffix(parts[0], "%") {
+			return fmt.Errorf("invalid step %q, must be of the form N%%@time", spec)
+		}
+
+		p, err := strconv.ParseUint(strings.TrimSuffix(parts[0], "%"), 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid percentage in step %s: %w", spec, err)
+		}
+
+		at, err := parseScheduleTime(parts[1])
+		if err != nil {
+			return fmt.Errorf("invalid time in step %s: %w", spec, err)
+		}
+
+		steps = append(steps, &featurepb.ScheduledStep{
+			TimeUnixNano: at.UnixNano(),
+			Change:       &featurepb.ScheduledStep_Percentage{Percentage: uint32(p)},
+		})
+	}
+
+	return scheduleFeature(cmd.Flags().Arg(0), steps...)
+}
+
+func scheduleFeature(name string, steps ...*featurepb.ScheduledStep) error {
+	resp, err := client.ScheduleFeature(ctx, &featurepb.ScheduleFeatureRequest{
+		Name:  name,
+		Steps: steps,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("scheduled %s (client schedule cancel %s)\n", resp.Schedule.Id, resp.Schedule.Id)
+	return printSchedule(resp.Schedule)
+}
+
+// parseScheduleTime parses the time of a scheduled step.
+func parseScheduleTime(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(d), nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
+
+func listScheduledChanges(cmd *cobra.Command, args []string) error {
+	resp, err := client.ListScheduledChanges(ctx, &featurepb.ListScheduledChangesRequest{
+		Name: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	if scheduleOptions.UseJSON {
+		m := jsonpb.Marshaler{}
+
+		for _, sc := range resp.Schedules {
+			data, err := m.MarshalToString(sc)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(data)
+		}
+
+		return nil
+	}
+
+	for _, sc := range resp.Schedules {
+		fmt.Printf("%s %s by %s\n", sc.Id, sc.Name, sc.RequestedBy)
+
+		if sc.Reason != "" {
+			fmt.Printf("  reason: %s\n", sc.Reason)
+		}
+
+		if err := printSchedule(sc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printSchedule prints each remaining step of sc.
+func printSchedule(sc *featurepb.ScheduledChange) error {
+	m := jsonpb.Marshaler{}
+
+	for _, step := range sc.Steps {
+		at := time.Unix(0, step.TimeUnixNano).UTC().Format(time.RFC3339)
+
+		switch change := step.GetChange().(type) {
+		case *featurepb.ScheduledStep_Feature:
+			data, err := m.MarshalToString(withoutVersion(change.Feature))
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  %s set %s\n", at, data)
+		case *featurepb.ScheduledStep_Enabled:
+			fmt.Printf("  %s enabled=%v\n", at, change.Enabled)
+		case *featurepb.ScheduledStep_Percentage:
+			fmt.Printf("  %s percentage=%d%%\n", at, change.Percentage)
+		}
+	}
+
+	return nil
+}
+
+func cancelScheduledChange(cmd *cobra.Command, args []string) error {
+	resp, err := client.CancelScheduledChange(ctx, &featurepb.CancelScheduledChangeRequest{
+		Id: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("cancelled %s to %s by %s; steps not made:\n", resp.Schedule.Id, resp.Schedule.Name, resp.Schedule.RequestedBy)
+	return printSchedule(resp.Schedule)
+}
+
+func init() {
+	scheduleSetCmd.Flags().StringVarP(&scheduleOptions.File, "file", "f", "", "config file holding the feature as it should be")
+	scheduleSetCmd.MarkFlagRequired("file")
+	scheduleListCmd.Flags().BoolVarP(&scheduleOptions.UseJSON, "json", "j", false, "output the schedules as JSON lines")
+	scheduleCmd.AddCommand(scheduleSetCmd, scheduleEnableCmd, scheduleDisableCmd, scheduleRampCmd, scheduleListCmd, scheduleCancelCmd)
+	rootCmd.AddCommand(scheduleCmd)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+
+	"github.com/ajm188/go-ff/feature"
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	segmentCmd = &cobra.Command{
+		Use:     "segment",
+		Aliases: []string{"segments"},
+		Short:   "manage reusable segments that features can reference as [segment:name]",
+	}
+	deleteSegmentCmd = &cobra.Command{
+		Use:          "delete segment",
+		Aliases:      []string{"remove"},
+		Args:         cobra.ExactArgs(1),
+		RunE:         deleteSegment,
+		SilenceUsage: true,
+	}
+	getSegmentCmd = &cobra.Command{
+		Use:          "get segment",
+		Args:         cobra.ExactArgs(1),
+		RunE:         getSegment,
+		SilenceUsage: true,
+	}
+	getSegmentsCmd = &cobra.Command{
+		Use:          "list",
+		Args:         cobra.NoArgs,
+		RunE:         getSegments,
+		SilenceUsage: true,
+	}
+	setSegmentCmd = &cobra.Command{
+		Use:          "set segment",
+		Args:         cobra.ExactArgs(1),
+		RunE:         setSegment,
+		SilenceUsage: true,
+	}
+)
+
+func deleteSegment(cmd *cobra.Command, args []string) error {
+	resp, err := client.DeleteSegment(ctx, &featurepb.DeleteSegmentRequest{
+		Name: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	switch resp.Segment {
+	case nil:
+		fmt.Printf("no such segment %s\n", cmd.Flags().Arg(0))
+	default:
+		fmt.Printf("deleted segment %s\n", resp.Segment.Name)
+	}
+
+	return nil
+}
+
+func getSegment(cmd *cobra.Command, args []string) error {
+	resp, err := client.GetSegment(ctx, &featurepb.GetSegmentRequest{
+		Name: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	m := jsonpb.Marshaler{Indent: "  "}
+	data, err := m.MarshalToString(resp.Segment)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(data)
+	return nil
+}
+
+func getSegments(cmd *cobra.Command, args []string) error {
+	resp, err := client.GetSegments(ctx, &featurepb.GetSegmentsRequest{})
+	if err != nil {
+		return err
+	}
+
+	buf := &strings.Builder{}
+	for _, seg := range resp.Segments {
+		fmt.Fprintf(buf, "%s:%s\n", seg.Name, seg.Description)
+	}
+
+	fmt.Print(buf.String())
+	return nil
+}
+
+var setSegmentOptions featurepb.Segment
+
+func setSegment(cmd *cobra.Command, args []string) error {
+	name := cmd.Flags().Arg(0)
+
+	resp, err := client.GetSegment(ctx, &featurepb.GetSegmentRequest{
+		Name: name,
+	})
+	if err != nil {
+		if !feature.IsError(err, feature.ErrNoSegment) {
+			return err
+		}
+
+		resp = &featurepb.GetSegmentResponse{
+			Segment: &featurepb.Segment{Name: name},
+		}
+	}
+
+	seg := resp.Segment
+
+	if cmd.Flags().Changed("key") {
+		seg.Key = setSegmentOptions.Key
+	}
+
+	if cmd.Flags().Changed("include") {
+		seg.Included = setSegmentOptions.Included
+	}
+
+	if cmd.Flags().Changed("exclude") {
+		seg.Excluded = setSegmentOptions.Excluded
+	}
+
+	if cmd.Flags().Changed("rule") {
+		seg.Rules = setSegmentOptions.Rules
+	}
+
+	if cmd.Flags().Changed("description") {
+		seg.Description = setSegmentOptions.Description
+	}
+
+	_, err = client.SetSegment(ctx, &featurepb.SetSegmentRequest{
+		Segment: seg,
+	})
+	return err
+}
+
+func init() {
+	setSegmentCmd.Flags().StringVarP(&setSegmentOptions.Key, "key", "k", "", "name of the parameter (e.g. user_id) matched against --include and --exclude")
+	setSegmentCmd.Flags().StringSliceVarP(&setSegmentOptions.Included, "include", "i", nil, "key values that are always in the segment")
+	setSegmentCmd.Flags().StringSliceVarP(&setSegmentOptions.Excluded, "exclude", "x", nil, "key values that are never in the segment")
+	setSegmentCmd.Flags().StringArrayVarP(&setSegmentOptions.Rules, "rule", "r", nil, "govaluate expression; entities matching any rule are in the segment. may be repeated")
+	setSegmentCmd.Flags().StringVarP(&setSegmentOptions.Description, "description", "d", "", "description of the segment")
+
+	segmentCmd.AddCommand(deleteSegmentCmd)
+	segmentCmd.AddCommand(getSegmentCmd)
+	segmentCmd.AddCommand(getSegmentsCmd)
+	segmentCmd.AddCommand(setSegmentCmd)
+	rootCmd.AddCommand(segmentCmd)
+}
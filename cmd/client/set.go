@@ -1,11 +1,15 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
 
 	"github.com/ajm188/go-ff/feature"
 	featurepb "github.com/ajm188/go-ff/proto/feature"
@@ -18,7 +22,18 @@ var setFeatureCmd = &cobra.Command{
 	SilenceUsage: false,
 }
 
-var setFeatureOptions featurepb.Feature
+var (
+	setFeatureOptions     featurepb.Feature
+	setFeatureVariants    []string
+	setFeatureRules       []string
+	setFeatureFallthrough string
+	setFeatureValue       = struct {
+		String string
+		Int    int64
+		Float  float64
+		JSON   string
+	}{}
+)
 
 func setFeature(cmd *cobra.Command, args []string) error {
 	var (
@@ -36,17 +51,72 @@ func setFeature(cmd *cobra.Command, args []string) error {
 		t = &_t
 	}
 
+	if cmd.Flags().Changed("variant") {
+		variants, err := parseVariants(setFeatureVariants)
+		if err != nil {
+			return err
+		}
+
+		setFeatureOptions.Variants = variants
+	}
+
+	if cmd.Flags().Changed("rule") {
+		rules := make([]*featurepb.Rule, 0, len(setFeatureRules))
+		for _, spec := range setFeatureRules {
+			rule, err := parseRule(spec)
+			if err != nil {
+				return err
+			}
+
+			rules = append(rules, rule)
+		}
+
+		setFeatureOptions.Rules = rules
+	}
+
+	if cmd.Flags().Changed("fallthrough") {
+		o, err := parseOutcome(setFeatureFallthrough)
+		if err != nil {
+			return fmt.Errorf("invalid --fallthrough: %w", err)
+		}
+
+		setFeatureOptions.Fallthrough = o
+	}
+
+	value, err := parseValue(cmd)
+	if err != nil {
+		return err
+	}
+
+	if value != nil {
+		setFeatureOptions.Value = value
+	}
+
 	cmd.SilenceUsage = true
 	name := cmd.Flags().Arg(0)
 
+	for {
+		err := trySetFeature(cmd, name, t, typeName, value)
+		if !feature.IsError(err, feature.ErrVersionConflict) {
+			return err
+		}
+
+		// Someone else changed the feature between our read and write.
+		fmt.Fprintf(os.Stderr, "conflict: %s\n", status.Convert(err).Message())
+		if !confirm("retry against the latest version?") {
+			return err
+		}
+	}
+}
+
+// trySetFeature reads the named feature, applies the flags to it, and writes
+// it back, conditional on the feature not having changed in between.
+func trySetFeature(cmd *cobra.Command, name string, t *featurepb.Feature_Type, typeName string, value *featurepb.Value) error {
 	resp, err := client.GetFeature(ctx, &featurepb.GetFeatureRequest{
 		Name: name,
 	})
 	if err != nil {
-		// Note: we cannot use errors.Is here because we're getting back a gRPC
-		// error, which does not wrap the error returned by our server
-		// implementation.
-		if strings.Contains(err.Error(), feature.ErrNoFeature.Error()) {
+		if feature.IsError(err, feature.ErrNoFeature) {
 			if t == nil {
 				cmd.SilenceUsage = false
 				return fmt.Errorf("no feature named %s, must specify a type", name)
@@ -56,7 +126,19 @@ func setFeature(cmd *cobra.Command, args []string) error {
 			setFeatureOptions.Name = name
 			setFeatureOptions.Type = *t
 
-			_, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{Feature: &setFeatureOptions})
+			if err := validateFeature(&setFeatureOptions); err != nil {
+				return err
+			}
+
+			// Fail, rather than overwrite, if someone else creates it first.
+			resp, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+				Feature:      &setFeatureOptions,
+				ExpectAbsent: true,
+			})
+			if err == nil && resp.Pending != nil {
+				printPending(resp.Pending)
+			}
+
 			return err
 		}
 
@@ -81,9 +163,47 @@ func setFeature(cmd *cobra.Command, args []string) error {
 		feat.Expression = setFeatureOptions.Expression
 	}
 
+	if cmd.Flags().Changed("bucketing-key") {
+		feat.BucketingKey = setFeatureOptions.BucketingKey
+	}
+
+	if cmd.Flags().Changed("salt") {
+		feat.Salt = setFeatureOptions.Salt
+	}
+
+	if cmd.Flags().Changed("variant") {
+		feat.Variants = setFeatureOptions.Variants
+	}
+
+	if value != nil {
+		feat.Value = value
+	}
+
+	if cmd.Flags().Changed("rule") {
+		feat.Rules = setFeatureOptions.Rules
+	}
+
+	if cmd.Flags().Changed("fallthrough") {
+		feat.Fallthrough = setFeatureOptions.Fallthrough
+	}
+
+	if cmd.Flags().Changed("protected") {
+		feat.Protected = setFeatureOptions.Protected
+	}
+
 	if t != nil {
 		cmd.SilenceUsage = false
 
+		if *t != featurepb.Feature_RULES {
+			if cmd.Flags().Changed("rule") {
+				return fmt.Errorf("--rule is incompatible with feature type %s", typeName)
+			}
+
+			if cmd.Flags().Changed("fallthrough") {
+				return fmt.Errorf("--fallthrough is incompatible with feature type %s", typeName)
+			}
+		}
+
 		switch *t {
 		case featurepb.Feature_CONSTANT:
 			if cmd.Flags().Changed("percentage") {
@@ -93,6 +213,14 @@ func setFeature(cmd *cobra.Command, args []string) error {
 			if cmd.Flags().Changed("expression") {
 				return fmt.Errorf("--expression is incompatible with feature type %s", typeName)
 			}
+
+			if cmd.Flags().Changed("bucketing-key") {
+				return fmt.Errorf("--bucketing-key is incompatible with feature type %s", typeName)
+			}
+
+			if cmd.Flags().Changed("variant") {
+				return fmt.Errorf("--variant is incompatible with feature type %s", typeName)
+			}
 		case featurepb.Feature_PERCENTAGE_BASED:
 			if cmd.Flags().Changed("enabled") {
 				return fmt.Errorf("--enabled is incompatible with feature type %s", typeName)
@@ -101,6 +229,10 @@ func setFeature(cmd *cobra.Command, args []string) error {
 			if cmd.Flags().Changed("expression") {
 				return fmt.Errorf("--expression is incompatible with feature type %s", typeName)
 			}
+
+			if cmd.Flags().Changed("variant") {
+				return fmt.Errorf("--variant is incompatible with feature type %s", typeName)
+			}
 		case featurepb.Feature_EXPRESSION:
 			if cmd.Flags().Changed("enabled") {
 				return fmt.Errorf("--enabled is incompatible with feature type %s", typeName)
@@ -109,21 +241,184 @@ func setFeature(cmd *cobra.Command, args []string) error {
 			if cmd.Flags().Changed("percentage") {
 				return fmt.Errorf("--percentage is incompatible with feature type %s", typeName)
 			}
+
+			if cmd.Flags().Changed("bucketing-key") {
+				return fmt.Errorf("--bucketing-key is incompatible with feature type %s", typeName)
+			}
+
+			if cmd.Flags().Changed("variant") {
+				return fmt.Errorf("--variant is incompatible with feature type %s", typeName)
+			}
+		case featurepb.Feature_VARIANT:
+			if cmd.Flags().Changed("enabled") {
+				return fmt.Errorf("--enabled is incompatible with feature type %s", typeName)
+			}
+
+			if cmd.Flags().Changed("percentage") {
+				return fmt.Errorf("--percentage is incompatible with feature type %s", typeName)
+			}
+
+			if cmd.Flags().Changed("expression") {
+				return fmt.Errorf("--expression is incompatible with feature type %s", typeName)
+			}
+		case featurepb.Feature_RULES:
+			if cmd.Flags().Changed("enabled") {
+				return fmt.Errorf("--enabled is incompatible with feature type %s", typeName)
+			}
+
+			if cmd.Flags().Changed("percentage") {
+				return fmt.Errorf("--percentage is incompatible with feature type %s", typeName)
+			}
+
+			if cmd.Flags().Changed("expression") {
+				return fmt.Errorf("--expression is incompatible with feature type %s", typeName)
+			}
 		}
 
 		cmd.SilenceUsage = true
 	}
 
-	_, err = client.SetFeature(ctx, &featurepb.SetFeatureRequest{
-		Feature: feat,
+	if err := validateFeature(feat); err != nil {
+		return err
+	}
+
+	setResp, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature:         feat,
+		ExpectedVersion: feat.Version,
 	})
+	if err != nil {
+		return err
+	}
+
+	if setResp.Pending != nil {
+		printPending(setResp.Pending)
+	}
+
+	return nil
+}
+
+// validateFeature checks the feature before sending it, so that every problem
+// with it is reported at once. The server checks it again, including that any
+// segments it references exist.
+func validateFeature(f *featurepb.Feature) error {
+	_, err := (&feature.Feature{Feature: f}).Validate()
 	return err
 }
 
+// parseVariants parses a list of name=weight strings into Variant messages.
+func parseVariants(specs []string) ([]*featurepb.Feature_Variant, error) {
+	variants := make([]*featurepb.Feature_Variant, 0, len(specs))
+
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid variant %q, must be of the form name=weight", spec)
+		}
+
+		weight, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight for variant %s: %w", parts[0], err)
+		}
+
+		variants = append(variants, &featurepb.Feature_Variant{
+			Name:   parts[0],
+			Weight: uint32(weight),
+		})
+	}
+
+	return variants, nil
+}
+
+// parseRule parses a rule of the form "expression => outcome", where outcome
+// is parsed by parseOutcome.
+func parseRule(spec string) (*featurepb.Rule, error) {
+	i := strings.LastIndex(spec, "=>")
+	if i == -1 {
+		return nil, fmt.Errorf("invalid rule %q, must be of the form 'expression => outcome'", spec)
+	}
+
+	o, err := parseOutcome(spec[i+2:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule %q: %w", spec, err)
+	}
+
+	return &featurepb.Rule{
+		Expression: strings.TrimSpace(spec[:i]),
+		Outcome:    o,
+	}, nil
+}
+
+// parseOutcome parses an outcome, which is one of "on", "off", a percentage
+// such as "10%", or "variant:name".
+func parseOutcome(s string) (*featurepb.Outcome, error) {
+	s = strings.TrimSpace(s)
+
+	switch {
+	case s == "on" || s == "true":
+		return &featurepb.Outcome{Kind: &featurepb.Outcome_Enabled{Enabled: true}}, nil
+	case s == "off" || s == "false":
+		return &featurepb.Outcome{Kind: &featurepb.Outcome_Enabled{Enabled: false}}, nil
+	case strings.HasSuffix(s, "%"):
+		p, err := strconv.ParseUint(strings.TrimSuffix(s, "%"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid percentage %s: %w", s, err)
+		}
+
+		return &featurepb.Outcome{Kind: &featurepb.Outcome_Percentage{Percentage: uint32(p)}}, nil
+	case strings.HasPrefix(s, "variant:"):
+		return &featurepb.Outcome{Kind: &featurepb.Outcome_Variant{Variant: strings.TrimPrefix(s, "variant:")}}, nil
+	}
+
+	return nil, fmt.Errorf("unknown outcome %q, must be one of on, off, N%%, or variant:name", s)
+}
+
+// parseValue returns the Value specified by the --*-value flags, or nil if
+// none were given. At most one value flag may be specified.
+func parseValue(cmd *cobra.Command) (*featurepb.Value, error) {
+	var value *featurepb.Value
+
+	for _, flag := range []string{"string-value", "int-value", "float-value", "json-value"} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		if value != nil {
+			return nil, fmt.Errorf("only one of --string-value, --int-value, --float-value or --json-value may be specified")
+		}
+
+		switch flag {
+		case "string-value":
+			value = &featurepb.Value{Kind: &featurepb.Value_StringValue{StringValue: setFeatureValue.String}}
+		case "int-value":
+			value = &featurepb.Value{Kind: &featurepb.Value_IntValue{IntValue: setFeatureValue.Int}}
+		case "float-value":
+			value = &featurepb.Value{Kind: &featurepb.Value_FloatValue{FloatValue: setFeatureValue.Float}}
+		case "json-value":
+			if !json.Valid([]byte(setFeatureValue.JSON)) {
+				return nil, fmt.Errorf("--json-value is not valid JSON: %s", setFeatureValue.JSON)
+			}
+
+			value = &featurepb.Value{Kind: &featurepb.Value_JsonValue{JsonValue: setFeatureValue.JSON}}
+		}
+	}
+
+	return value, nil
+}
+
 func init() {
 	setFeatureCmd.Flags().StringVarP(&setFeatureOptions.Description, "description", "d", "", "description of the feature")
 	setFeatureCmd.Flags().BoolVar(&setFeatureOptions.Enabled, "enabled", false, "enable this feature. only used for type=CONSTANT")
 	setFeatureCmd.Flags().Uint32VarP(&setFeatureOptions.Percentage, "percentage", "p", 0, "percentage [0, 100] of requests for which the feature should be enabled. only used for type=PERCENTAGE_BASED")
 	setFeatureCmd.Flags().StringVarP(&setFeatureOptions.Expression, "expression", "e", "", "govaluate expression string. only used for type=EXPRESSION")
+	setFeatureCmd.Flags().StringVarP(&setFeatureOptions.BucketingKey, "bucketing-key", "b", "", "name of the parameter (e.g. user_id) to deterministically bucket on. only used for type=PERCENTAGE_BASED or VARIANT")
+	setFeatureCmd.Flags().StringVar(&setFeatureOptions.Salt, "salt", "", "salt to mix into the bucketing hash. defaults to the feature name. only used for type=PERCENTAGE_BASED or VARIANT")
+	setFeatureCmd.Flags().StringArrayVar(&setFeatureVariants, "variant", nil, "variant in the form name=weight; may be repeated, and weights must sum to 100. only used for type=VARIANT")
+	setFeatureCmd.Flags().StringVar(&setFeatureValue.String, "string-value", "", "string value returned when the feature is enabled")
+	setFeatureCmd.Flags().Int64Var(&setFeatureValue.Int, "int-value", 0, "integer value returned when the feature is enabled")
+	setFeatureCmd.Flags().Float64Var(&setFeatureValue.Float, "float-value", 0, "float value returned when the feature is enabled")
+	setFeatureCmd.Flags().StringVar(&setFeatureValue.JSON, "json-value", "", "JSON value returned when the feature is enabled")
+	setFeatureCmd.Flags().StringArrayVar(&setFeatureRules, "rule", nil, "targeting rule in the form 'expression => outcome', where outcome is one of on, off, N%, or variant:name; may be repeated, and the first matching rule wins. only used for type=RULES")
+	setFeatureCmd.Flags().StringVar(&setFeatureFallthrough, "fallthrough", "", "outcome used when no rule matches (on, off, N%, or variant:name). only used for type=RULES")
+	setFeatureCmd.Flags().BoolVar(&setFeatureOptions.Protected, "protected", false, "require changes to this feature (including unprotecting it) to be approved by someone other than their requester")
 	rootCmd.AddCommand(setFeatureCmd)
 }
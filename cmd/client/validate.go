@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajm188/go-ff/feature"
+)
+
+var (
+	validateCmd = &cobra.Command{
+		Use:   "validate file...",
+		Short: "check config files without loading them into a server",
+		Long: `Validate checks config files exactly as the server does when loading them
+with --config, without contacting a server.`,
+		Args:         cobra.MinimumNArgs(1),
+		RunE:         validateFiles,
+		SilenceUsage: true,
+		Annotations:  map[string]string{offlineAnnotation: "true"},
+	}
+	lintCmd = &cobra.Command{
+		Use:   "lint file... [--params p1,p2,...] [-j|--json]",
+		Short: "check config files for likely mistakes",
+		Long: `Lint checks config files for features that are valid, but probably mistakes:
+
+  name-mismatch         the feature's name field differs from its key
+  missing-description   the feature has no description
+  constant-percentage   a PERCENTAGE_BASED feature at 0% or 100%, which should
+                        be a CONSTANT feature
+  unknown-parameter     the feature uses a parameter not listed in --params
+                        (only checked if --params is given)
+
+Files must be valid (see validate) to be linted. Lint exits non-zero if there
+are any issues. It does not contact a server.`,
+		Args:         cobra.MinimumNArgs(1),
+		RunE:         lintFiles,
+		SilenceUsage: true,
+		Annotations:  map[string]string{offlineAnnotation: "true"},
+	}
+)
+
+// errInvalidFiles is returned by validate and lint when any file has problems,
+// which have already been printed, so that the client exits non-zero.
+var errInvalidFiles = errors.New("problems found")
+
+func validateFiles(cmd *cobra.Command, args []string) error {
+	failed := false
+
+	for _, path := range args {
+		if _, err := feature.ReadConfigFile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", formatError(fmt.Errorf("%s: %w", path, err)))
+			failed = true
+			continue
+		}
+
+		fmt.Printf("%s: ok\n", path)
+	}
+
+	if failed {
+		return errInvalidFiles
+	}
+
+	return nil
+}
+
+var lintOptions = struct {
+	Params  []string
+	UseJSON bool
+}{}
+
+// fileLintIssue is a LintIssue with the file it was found in, for JSON output.
+type fileLintIssue struct {
+	File string `json:"file"`
+	*feature.LintIssue
+}
+
+func lintFiles(cmd *cobra.Command, args []string) error {
+	var params []string
+	if cmd.Flags().Changed("params") {
+		params = lintOptions.Params
+		if params == nil {
+			params = []string{}
+		}
+	}
+
+	var (
+		failed bool
+		issues = []*fileLintIssue{}
+	)
+
+	for _, path := range args {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		fileIssues, err := feature.LintConfig(data, params)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", formatError(fmt.Errorf("%s: %w", path, err)))
+			failed = true
+			continue
+		}
+
+		for _, issue := range fileIssues {
+			issues = append(issues, &fileLintIssue{File: path, LintIssue: issue})
+		}
+	}
+
+	if lintOptions.UseJSON {
+		data, err := json.Marshal(issues)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s\n", data)
+	} else {
+		for _, issue := range issues {
+			fmt.Printf("%s: %s\n", issue.File, issue.LintIssue)
+		}
+	}
+
+	if failed || len(issues) > 0 {
+		return errInvalidFiles
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	lintCmd.Flags().StringSliceVar(&lintOptions.Params, "params", nil, "parameters that features may use; if set, features using any other parameter are flagged")
+	lintCmd.Flags().BoolVarP(&lintOptions.UseJSON, "json", "j", false, "output the issues as JSON")
+	rootCmd.AddCommand(lintCmd)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var watchFeaturesCmd = &cobra.Command{
+	Use:          "watch [--from-revision N --epoch E] [-j|--json]",
+	Short:        "stream feature changes from the server",
+	Args:         cobra.NoArgs,
+	RunE:         watchFeatures,
+	SilenceUsage: true,
+}
+
+var watchFeaturesOptions = struct {
+	FromRevision uint64
+	Epoch        string
+	UseJSON      bool
+}{}
+
+func watchFeatures(cmd *cobra.Command, args []string) error {
+	stream, err := client.WatchFeatures(ctx, &featurepb.WatchFeaturesRequest{
+		FromRevision: watchFeaturesOptions.FromRevision,
+		Epoch:        watchFeaturesOptions.Epoch,
+	})
+	if err != nil {
+		return err
+	}
+
+	m := jsonpb.Marshaler{}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if watchFeaturesOptions.UseJSON {
+			data, err := m.MarshalToString(resp)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(data)
+			continue
+		}
+
+		switch {
+		case resp.Snapshot != nil:
+			fmt.Printf("@%d snapshot (%d features, epoch %s)\n", resp.Snapshot.Revision, len(resp.Snapshot.Features), resp.Snapshot.Epoch)
+			for _, feat := range resp.Snapshot.Features {
+				fmt.Printf("  %s:%v\n", feat.Name, feat.Enabled)
+			}
+		case resp.Event != nil:
+			switch resp.Event.Type {
+			case featurepb.FeatureEvent_DELETE:
+				fmt.Printf("@%d delete %s\n", resp.Event.Revision, resp.Event.Feature.Name)
+			default:
+				fmt.Printf("@%d set %s:%v\n", resp.Event.Revision, resp.Event.Feature.Name, resp.Event.Feature.Enabled)
+			}
+		}
+	}
+}
+
+func init() {
+	watchFeaturesCmd.Flags().Uint64Var(&watchFeaturesOptions.FromRevision, "from-revision", 0, "resume from the given revision, instead of starting with a snapshot")
+	watchFeaturesCmd.Flags().StringVar(&watchFeaturesOptions.Epoch, "epoch", "", "epoch of the snapshot --from-revision follows; if the server's has changed, the stream starts with a snapshot")
+	watchFeaturesCmd.Flags().BoolVarP(&watchFeaturesOptions.UseJSON, "json", "j", false, "output each message as a line of JSON")
+	rootCmd.AddCommand(watchFeaturesCmd)
+}
@@ -7,9 +7,12 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/ajm188/go-ff/feature"
 )
@@ -17,6 +20,18 @@ import (
 var (
 	addr       string
 	configPath string
+	credsPath  string
+	tlsCert    string
+	tlsKey     string
+	tlsCA      string
+	auditPath  string
+	auditChain bool
+	statePath  string
+	dataDir    string
+	writeBack  bool
+
+	approvalTimeout  time.Duration
+	scheduleInterval time.Duration
 
 	rootCmd = &cobra.Command{
 		RunE:          serve,
@@ -25,21 +40,76 @@ var (
 )
 
 func serve(cmd *cobra.Command, args []string) error {
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	store.SetApprovalTimeout(approvalTimeout)
+
+	if auditPath != "" {
+		audit, err := feature.OpenAuditLog(auditPath, auditChain)
+		if err != nil {
+			return err
+		}
+		defer audit.Close()
+
+		store.SetAuditLog(audit)
+	} else if auditChain {
+		return fmt.Errorf("--audit-hash-chain requires --audit-log")
+	}
+
 	if configPath != "" {
-		if err := feature.InitFromFile(configPath); err != nil {
+		if err := store.InitFromFile(configPath); err != nil {
 			log.Fatal(err)
 		}
 
 		watchCtx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		if err := feature.Watch(watchCtx, configPath); err != nil {
+		if err := store.Watch(watchCtx, configPath); err != nil {
 			return err
 		}
 	}
 
-	s := grpc.NewServer()
-	feature.RegisterServer(s)
+	scheduleCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.RunSchedules(scheduleCtx, scheduleInterval)
+
+	opts, err := tlsOptions()
+	if err != nil {
+		return err
+	}
+
+	if credsPath != "" {
+		auth, err := feature.LoadAuthenticator(credsPath)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, auth.ServerOptions()...)
+
+		// Reload the credentials on SIGHUP, e.g. to revoke a token.
+		hupch := make(chan os.Signal, 1)
+		signal.Notify(hupch, syscall.SIGHUP)
+		defer signal.Stop(hupch)
+
+		go func() {
+			for range hupch {
+				if err := auth.LoadFile(credsPath); err != nil {
+					log.Printf("failed to reload credentials: %v", err)
+					continue
+				}
+
+				log.Printf("reloaded credentials from %s", credsPath)
+			}
+		}()
+	}
+
+	s := grpc.NewServer(opts...)
+	store.Register(s)
 
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -67,9 +137,76 @@ func serve(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// tlsOptions returns the options to serve TLS with, if --tls-cert and
+// --tls-key are given.
+func tlsOptions() ([]grpc.ServerOption, error) {
+	if tlsCert == "" && tlsKey == "" {
+		if tlsCA != "" {
+			return nil, fmt.Errorf("--tls-ca requires --tls-cert and --tls-key")
+		}
+
+		return nil, nil
+	}
+
+	config, err := feature.ServerTLSConfig(tlsCert, tlsKey, tlsCA)
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(config))}, nil
+}
+
+// openStore returns the store to serve features from, persisting to the
+// --data-dir, --state-file or --config file, if requested.
+func openStore() (*feature.Store, error) {
+	var (
+		backend feature.Backend
+		err     error
+	)
+
+	n := 0
+	for _, set := range []bool{dataDir != "", statePath != "", writeBack} {
+		if set {
+			n++
+		}
+	}
+
+	switch {
+	case n > 1:
+		return nil, fmt.Errorf("--data-dir, --state-file and --write-config are mutually exclusive")
+	case writeBack && configPath == "":
+		return nil, fmt.Errorf("--write-config requires --config")
+	case dataDir != "":
+		backend, err = feature.NewLogBackend(dataDir)
+	case writeBack:
+		backend, err = feature.NewConfigFileBackend(configPath)
+	case statePath != "":
+		backend, err = feature.NewFileBackend(statePath)
+	default:
+		return feature.NewStore(), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return feature.OpenStore(backend)
+}
+
 func init() {
 	rootCmd.Flags().StringVar(&addr, "addr", ":15000", "address to listen on")
 	rootCmd.Flags().StringVarP(&configPath, "config", "c", "", "path to feature flag config file")
+	rootCmd.Flags().StringVar(&dataDir, "data-dir", "", "directory to durably store features and segments in, using an embedded write-ahead log. if --config is also given, its features replace the persisted ones on startup")
+	rootCmd.Flags().StringVar(&statePath, "state-file", "", "path to a file to persist features and segments to across restarts. if --config is also given, its features replace the persisted ones on startup")
+	rootCmd.Flags().StringVar(&credsPath, "credentials", "", "path to a credentials file of bearer tokens and their roles. if set, every request must present one of the tokens. the file is reloaded on SIGHUP")
+	rootCmd.Flags().StringVar(&tlsCert, "tls-cert", "", "path to a PEM certificate to serve TLS with. it is reloaded when it changes")
+	rootCmd.Flags().StringVar(&tlsKey, "tls-key", "", "path to the PEM private key for --tls-cert. it is reloaded when it changes")
+	rootCmd.Flags().StringVar(&tlsCA, "tls-ca", "", "path to PEM CA certificates. if set, clients must present a certificate signed by one of them")
+	rootCmd.Flags().StringVar(&auditPath, "audit-log", "", "path to an append-only audit log to record every change to features in, as JSON lines")
+	rootCmd.Flags().BoolVar(&auditChain, "audit-hash-chain", false, "hash-chain the entries in --audit-log, so that tampering with them can be detected")
+	rootCmd.Flags().DurationVar(&approvalTimeout, "approval-timeout", feature.DefaultApprovalTimeout, "how long changes to protected features may be approved for before they expire")
+	rootCmd.Flags().DurationVar(&scheduleInterval, "schedule-interval", time.Second, "how often to check for scheduled changes that are due")
+	rootCmd.Flags().BoolVar(&writeBack, "write-config", false, "write changes made through the API back to the --config file")
 }
 
 func main() {
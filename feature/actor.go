@@ -0,0 +1,76 @@
+package feature
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ActorMetadataKey is the gRPC metadata key that clients use to say who is
+// making a change, for the feature history.
+const ActorMetadataKey = "ff-actor"
+
+// ReasonMetadataKey is the gRPC metadata key that clients use to say why they
+// are making a change, for the audit log.
+const ReasonMetadataKey = "ff-reason"
+
+// caller describes who is making a change, and how, for the feature history
+// and the audit log.
+type caller struct {
+	actor string
+	// authenticated is whether actor was authenticated by an Authenticator,
+	// rather than claimed by the client.
+	authenticated bool
+	// peer is the address of the client, for changes made through the API.
+	peer   string
+	method string
+	reason string
+	// approvedBy is who approved the change, for changes to protected
+	// features.
+	approvedBy string
+}
+
+// callerFromContext returns the caller making a request in ctx to the named
+// RPC.
+func callerFromContext(ctx context.Context, method string) *caller {
+	c := &caller{
+		actor:  actorFromContext(ctx),
+		method: method,
+	}
+
+	_, c.authenticated = IdentityFromContext(ctx)
+
+	if p, ok := peer.FromContext(ctx); ok {
+		c.peer = p.Addr.String()
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(ReasonMetadataKey); len(vals) > 0 {
+			c.reason = vals[0]
+		}
+	}
+
+	return c
+}
+
+// actorFromContext returns who is making the request in ctx: the caller
+// authenticated by an Authenticator, the actor given in the request metadata,
+// or else the address of the peer.
+func actorFromContext(ctx context.Context) string {
+	if id, ok := IdentityFromContext(ctx); ok {
+		return id.Name
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(ActorMetadataKey); len(vals) > 0 && vals[0] != "" {
+			return vals[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+
+	return "unknown"
+}
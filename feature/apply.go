@@ -0,0 +1,151 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// ErrInvalidOperation is returned by ApplyFeatures for a malformed batch, e.g.
+// one that operates on the same feature twice.
+var ErrInvalidOperation = errors.New("invalid operation")
+
+// ApplyFeatures is part of the featurepb.FeaturesServer interface. It applies
+// a batch of sets and deletes atomically: every operation is validated (and
+// its expected version checked) before any of them are applied, and they are
+// persisted with a single write to the backend. If any operation is invalid,
+// none of them are applied.
+//
+// With DryRun set, the operations are validated and the changes they would
+// make are returned, but nothing is applied.
+func (s *Store) ApplyFeatures(ctx context.Context, req *featurepb.ApplyFeaturesRequest) (*featurepb.ApplyFeaturesResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var (
+		planned = make([]*featureChange, 0, len(req.Operations))
+		seen    = make(map[string]bool, len(req.Operations))
+		v       = s.validationLocked()
+	)
+
+	for i, op := range req.Operations {
+		c, err := s.planOperationLocked(v, op)
+		if err != nil {
+			return nil, rpcError(fmt.Errorf("operation %d: %w", i, err))
+		}
+
+		if c.name == "" {
+			// An unnamed feature, which v has already recorded.
+			continue
+		}
+
+		if seen[c.name] {
+			return nil, rpcError(fmt.Errorf("operation %d: %w: %s appears in more than one operation", i, ErrInvalidOperation, c.name))
+		}
+
+		seen[c.name] = true
+		planned = append(planned, c)
+	}
+
+	// Report every invalid feature in the batch at once.
+	if err := v.err(); err != nil {
+		return nil, rpcError(err)
+	}
+
+	changes := make([]*featureChange, 0, len(planned))
+	for _, c := range planned {
+		if c.before == nil && c.after == nil {
+			// Deleting a feature that doesn't exist.
+			continue
+		}
+
+		if c.before != nil && c.after != nil {
+			// Versions are assigned by the store, so ignore them when checking
+			// whether the feature would change.
+			c.after.Version = c.before.Version
+			if proto.Equal(c.before, c.after.Feature) {
+				continue
+			}
+		}
+
+		changes = append(changes, c)
+	}
+
+	var pending *featurepb.PendingChange
+
+	if req.DryRun {
+		for _, c := range changes {
+			if c.after != nil {
+				c.after.Version = s.nextVersionLocked(c.name)
+			}
+		}
+	} else if len(changes) > 0 {
+		var err error
+		if pending, err = s.commitLocked(callerFromContext(ctx, "ApplyFeatures"), changes); err != nil {
+			return nil, rpcError(err)
+		}
+	}
+
+	resp := &featurepb.ApplyFeaturesResponse{
+		Changes: make([]*featurepb.FeatureChange, 0, len(changes)),
+		Pending: pending,
+	}
+
+	for _, c := range changes {
+		fc := &featurepb.FeatureChange{
+			Name:   c.name,
+			Before: c.before,
+		}
+
+		if c.after != nil {
+			fc.After = c.after.Feature
+		}
+
+		resp.Changes = append(resp.Changes, fc)
+	}
+
+	return resp, nil
+}
+
+// planOperationLocked returns the change that a single operation from an
+// ApplyFeatures batch would make, recording any problems with the feature it
+// sets in v. Callers must hold s.m.
+func (s *Store) planOperationLocked(v *validation, op *featurepb.FeatureOperation) (*featureChange, error) {
+	var c featureChange
+
+	switch o := op.GetOp().(type) {
+	case *featurepb.FeatureOperation_Set:
+		if o.Set == nil {
+			return nil, fmt.Errorf("%w: feature to set cannot be empty", ErrInvalidOperation)
+		}
+
+		c.name = o.Set.Name
+		c.after = s.newFeatureLocked(v, o.Set)
+
+		if c.name == "" {
+			return &c, nil
+		}
+	case *featurepb.FeatureOperation_Delete:
+		if o.Delete == "" {
+			return nil, fmt.Errorf("%w: name of feature to delete cannot be empty", ErrInvalidOperation)
+		}
+
+		c.name = o.Delete
+	default:
+		return nil, fmt.Errorf("%w: must set or delete a feature", ErrInvalidOperation)
+	}
+
+	if err := s.checkVersionLocked(c.name, op.ExpectedVersion, op.ExpectAbsent); err != nil {
+		return nil, err
+	}
+
+	if feat, ok := s.features[c.name]; ok {
+		c.before = feat.Feature
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,243 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func setOp(name string, enabled bool) *featurepb.FeatureOperation {
+	return &featurepb.FeatureOperation{
+		Op: &featurepb.FeatureOperation_Set{
+			Set: &featurepb.Feature{Name: name, Type: featurepb.Feature_CONSTANT, Enabled: enabled},
+		},
+	}
+}
+
+func deleteOp(name string) *featurepb.FeatureOperation {
+	return &featurepb.FeatureOperation{
+		Op: &featurepb.FeatureOperation_Delete{Delete: name},
+	}
+}
+
+func TestApplyFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	newStore := func(t *testing.T) *Store {
+		t.Helper()
+
+		s := NewStore()
+		s.Init(map[string]*Feature{
+			"a": {Feature: &featurepb.Feature{Type: featurepb.Feature_CONSTANT, Enabled: true}},
+			"b": {Feature: &featurepb.Feature{Type: featurepb.Feature_CONSTANT}},
+		})
+
+		return s
+	}
+
+	badExpr := &featurepb.FeatureOperation{
+		Op: &featurepb.FeatureOperation_Set{
+			Set: &featurepb.Feature{Name: "e", Type: featurepb.Feature_EXPRESSION, Expression: "x >"},
+		},
+	}
+
+	conflict := setOp("a", false)
+	conflict.ExpectedVersion = 5
+
+	create := setOp("c", true)
+	create.ExpectAbsent = true
+
+	createExisting := setOp("a", false)
+	createExisting.ExpectAbsent = true
+
+	tests := []struct {
+		name     string
+		ops      []*featurepb.FeatureOperation
+		wantErr  bool
+		wantIs   error
+		wantCode codes.Code
+		// wantChanges are the names of the features changed, in order.
+		wantChanges []string
+	}{
+		{
+			name:        "set and delete",
+			ops:         []*featurepb.FeatureOperation{setOp("a", false), setOp("c", true), deleteOp("b")},
+			wantChanges: []string{"a", "c", "b"},
+		},
+		{
+			name:        "no-ops are omitted",
+			ops:         []*featurepb.FeatureOperation{setOp("a", true), deleteOp("missing"), setOp("b", true)},
+			wantChanges: []string{"b"},
+		},
+		{
+			name:    "invalid expression",
+			ops:     []*featurepb.FeatureOperation{setOp("a", false), badExpr},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate feature",
+			ops:     []*featurepb.FeatureOperation{setOp("a", false), deleteOp("a")},
+			wantErr: true,
+			wantIs:  ErrInvalidOperation,
+		},
+		{
+			name:    "empty operation",
+			ops:     []*featurepb.FeatureOperation{setOp("a", false), {}},
+			wantErr: true,
+			wantIs:  ErrInvalidOperation,
+		},
+		{
+			name:     "version conflict",
+			ops:      []*featurepb.FeatureOperation{setOp("c", true), conflict},
+			wantErr:  true,
+			wantIs:   ErrVersionConflict,
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:        "create expecting absent",
+			ops:         []*featurepb.FeatureOperation{create},
+			wantChanges: []string{"c"},
+		},
+		{
+			name:     "create of existing feature",
+			ops:      []*featurepb.FeatureOperation{create, createExisting},
+			wantErr:  true,
+			wantIs:   ErrVersionConflict,
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, dryRun := range []bool{true, false} {
+				s := newStore(t)
+
+				resp, err := s.ApplyFeatures(ctx, &featurepb.ApplyFeaturesRequest{
+					Operations: tt.ops,
+					DryRun:     dryRun,
+				})
+
+				if tt.wantErr {
+					if err == nil {
+						t.Fatalf("ApplyFeatures(dry_run=%v) succeeded, want error", dryRun)
+					}
+
+					if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+						t.Errorf("ApplyFeatures(dry_run=%v) error = %v, want %v", dryRun, err, tt.wantIs)
+					}
+
+					if tt.wantCode != codes.OK {
+						if code := status.Code(err); code != tt.wantCode {
+							t.Errorf("ApplyFeatures(dry_run=%v) code = %v, want %v", dryRun, code, tt.wantCode)
+						}
+					}
+				} else if err != nil {
+					t.Fatalf("ApplyFeatures(dry_run=%v) error = %v", dryRun, err)
+				} else {
+					var names []string
+					for _, c := range resp.Changes {
+						names = append(names, c.Name)
+					}
+
+					if len(names) != len(tt.wantChanges) {
+						t.Fatalf("ApplyFeatures(dry_run=%v) changed %v, want %v", dryRun, names, tt.wantChanges)
+					}
+
+					for i := range names {
+						if names[i] != tt.wantChanges[i] {
+							t.Errorf("ApplyFeatures(dry_run=%v) changed %v, want %v", dryRun, names, tt.wantChanges)
+							break
+						}
+					}
+				}
+
+				// A dry run, or a failed batch, leaves the store alone.
+				if dryRun || tt.wantErr {
+					if on, err := s.Get("a", nil); err != nil || !on {
+						t.Errorf("Get(a) after ApplyFeatures(dry_run=%v) = %v, %v; want true", dryRun, on, err)
+					}
+
+					if _, err := s.Get("c", nil); !errors.Is(err, ErrNoFeature) {
+						t.Errorf("Get(c) after ApplyFeatures(dry_run=%v) error = %v, want ErrNoFeature", dryRun, err)
+					}
+
+					if n := len(s.history["a"]); n != 1 {
+						t.Errorf("history of a after ApplyFeatures(dry_run=%v) has %d revisions, want 1", dryRun, n)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestApplyFeaturesCommit(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	resp, err := s.ApplyFeatures(ctx, &featurepb.ApplyFeaturesRequest{
+		Operations: []*featurepb.FeatureOperation{setOp("a", true), setOp("b", true)},
+		DryRun:     true,
+	})
+	if err != nil {
+		t.Fatalf("ApplyFeatures(dry_run) error = %v", err)
+	}
+
+	// A dry run reports the versions the features would have.
+	for _, c := range resp.Changes {
+		if c.Before != nil || c.After.Version != 1 {
+			t.Errorf("dry run change = %v, want creation at version 1", c)
+		}
+	}
+
+	resp, err = s.ApplyFeatures(ctx, &featurepb.ApplyFeaturesRequest{
+		Operations: []*featurepb.FeatureOperation{setOp("a", false), deleteOp("b")},
+	})
+	if err != nil {
+		t.Fatalf("ApplyFeatures error = %v", err)
+	}
+
+	// The dry run applied nothing, so deleting b is a no-op.
+	if len(resp.Changes) != 1 || resp.Changes[0].After.Version != 1 {
+		t.Errorf("ApplyFeatures changes = %v, want creation of a at version 1", resp.Changes)
+	}
+
+	op := setOp("a", true)
+	op.ExpectedVersion = 1
+
+	resp, err = s.ApplyFeatures(ctx, &featurepb.ApplyFeaturesRequest{
+		Operations: []*featurepb.FeatureOperation{op, setOp("b", false)},
+	})
+	if err != nil {
+		t.Fatalf("ApplyFeatures error = %v", err)
+	}
+
+	if len(resp.Changes) != 2 || resp.Changes[0].Before.Enabled || !resp.Changes[0].After.Enabled || resp.Changes[0].After.Version != 2 {
+		t.Errorf("ApplyFeatures changes = %v, want a enabled at version 2 and b created", resp.Changes)
+	}
+
+	if on, err := s.Get("a", nil); err != nil || !on {
+		t.Errorf("Get(a) = %v, %v; want true", on, err)
+	}
+}
+
+func TestApplyFeaturesBackendError(t *testing.T) {
+	s, err := OpenStore(failingBackend{NewMemoryBackend()})
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+
+	if _, err := s.ApplyFeatures(context.Background(), &featurepb.ApplyFeaturesRequest{
+		Operations: []*featurepb.FeatureOperation{setOp("a", true), setOp("b", true)},
+	}); err == nil {
+		t.Fatal("ApplyFeatures succeeded with a failing backend")
+	}
+
+	if resp, _ := s.GetFeatures(context.Background(), &featurepb.GetFeaturesRequest{}); len(resp.Features) != 0 {
+		t.Errorf("features after failed ApplyFeatures = %v, want none", resp.Features)
+	}
+}
@@ -0,0 +1,323 @@
+package feature
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// DefaultApprovalTimeout is how long changes to protected features may be
+// approved for, unless changed with SetApprovalTimeout.
+const DefaultApprovalTimeout = 24 * time.Hour
+
+var (
+	// ErrNoPendingChange is returned when approving or rejecting a pending
+	// change that does not exist, or has expired.
+	ErrNoPendingChange = errors.New("no such pending change")
+	// ErrSelfApproval is returned when the requester of a pending change tries
+	// to approve it.
+	ErrSelfApproval = errors.New("cannot approve own change")
+	// ErrApprovalUnauthenticated is returned when approving a change, or
+	// making a change that needs approval, without an authenticated identity,
+	// since otherwise a requester could approve their own change by claiming
+	// to be someone else.
+	ErrApprovalUnauthenticated = errors.New("approval requires authentication")
+)
+
+// SetApprovalTimeout sets how long changes to protected features may be
+// approved for. It applies to subsequent changes only.
+func (s *Store) SetApprovalTimeout(d time.Duration) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.approvalTimeout = d
+}
+
+// requiresApproval returns whether any of the changes are to a protected
+// feature, or would make a feature protected.
+func requiresApproval(changes []*featureChange) bool {
+	for _, change := range changes {
+		if change.before.GetProtected() || (change.after != nil && change.after.Protected) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requestApprovalLocked holds the given changes, requested by c, for approval,
+// persisting the pending change along with any extra mutations, and returns
+// it. Callers must hold s.m.
+func (s *Store) requestApprovalLocked(c *caller, changes []*featureChange, extra ...*Mutation) (*featurepb.PendingChange, error) {
+	if !c.authenticated {
+		return nil, fmt.Errorf("%w: %s is not authenticated", ErrApprovalUnauthenticated, c.actor)
+	}
+
+	id, err := newID(func(id string) bool {
+		_, ok := s.pending[id]
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	pc := &featurepb.PendingChange{
+		Id:                 id,
+		Changes:            make([]*featurepb.FeatureChange, 0, len(changes)),
+		RequestedBy:        c.actor,
+		Reason:             c.reason,
+		CreateTimeUnixNano: now.UnixNano(),
+		ExpireTimeUnixNano: now.Add(s.approvalTimeout).UnixNano(),
+	}
+
+	for _, change := range changes {
+		fc := &featurepb.FeatureChange{
+			Name:   change.name,
+			Before: change.before,
+		}
+
+		if change.after != nil {
+			// The version is assigned when the change is approved.
+			change.after.Version = 0
+			fc.After = change.after.Feature
+		}
+
+		pc.Changes = append(pc.Changes, fc)
+	}
+
+	mutations := append([]*Mutation{{Type: SetPendingChangeMutation, PendingChange: pc}}, extra...)
+	if err := s.backend.Apply(mutations...); err != nil {
+		return nil, err
+	}
+
+	s.pending[id] = pc
+	log.Printf("[approval] %s requested change %s to %s", c.actor, id, strings.Join(pendingChangeFeatures(pc), ", "))
+
+	return pc, nil
+}
+
+// newID returns a short random ID, for which taken returns false.
+func newID(taken func(id string) bool) (string, error) {
+	buf := make([]byte, 4)
+
+	for {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+
+		id := hex.EncodeToString(buf)
+		if !taken(id) {
+			return id, nil
+		}
+	}
+}
+
+// pendingChangeFeatures returns the names of the features pc changes.
+func pendingChangeFeatures(pc *featurepb.PendingChange) []string {
+	names := make([]string, 0, len(pc.Changes))
+	for _, fc := range pc.Changes {
+		names = append(names, fc.Name)
+	}
+
+	return names
+}
+
+// expirePendingLocked removes pending changes that can no longer be approved.
+// Callers must hold s.m.
+func (s *Store) expirePendingLocked() error {
+	now := s.now().UnixNano()
+
+	var (
+		expired   []string
+		mutations []*Mutation
+	)
+
+	for id, pc := range s.pending {
+		if pc.ExpireTimeUnixNano <= now {
+			expired = append(expired, id)
+			mutations = append(mutations, &Mutation{Type: DeletePendingChangeMutation, Name: id})
+		}
+	}
+
+	if len(expired) == 0 {
+		return nil
+	}
+
+	if err := s.backend.Apply(mutations...); err != nil {
+		return err
+	}
+
+	for _, id := range expired {
+		log.Printf("[approval] change %s to %s expired", id, strings.Join(pendingChangeFeatures(s.pending[id]), ", "))
+		delete(s.pending, id)
+	}
+
+	return nil
+}
+
+// pendingChangeLocked returns the pending change with the given ID, which the
+// identity in ctx, if any, must be allowed to change. Callers must hold s.m.
+func (s *Store) pendingChangeLocked(ctx context.Context, id string) (*featurepb.PendingChange, error) {
+	if err := s.expirePendingLocked(); err != nil {
+		return nil, err
+	}
+
+	pc, ok := s.pending[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoPendingChange, id)
+	}
+
+	if err := checkCanChange(ctx, pendingChangeFeatures(pc)...); err != nil {
+		return nil, err
+	}
+
+	return pc, nil
+}
+
+// ListPendingChanges is part of the featurepb.FeaturesServer interface.
+func (s *Store) ListPendingChanges(ctx context.Context, req *featurepb.ListPendingChangesRequest) (*featurepb.ListPendingChangesResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if err := s.expirePendingLocked(); err != nil {
+		return nil, rpcError(err)
+	}
+
+	resp := &featurepb.ListPendingChangesResponse{
+		Pending: make([]*featurepb.PendingChange, 0, len(s.pending)),
+	}
+
+	for _, pc := range s.pending {
+		resp.Pending = append(resp.Pending, pc)
+	}
+
+	sort.Slice(resp.Pending, func(i, j int) bool {
+		a, b := resp.Pending[i], resp.Pending[j]
+		if a.CreateTimeUnixNano != b.CreateTimeUnixNano {
+			return a.CreateTimeUnixNano < b.CreateTimeUnixNano
+		}
+
+		return a.Id < b.Id
+	})
+
+	return resp, nil
+}
+
+// ApproveChange is part of the featurepb.FeaturesServer interface. It makes a
+// pending change, as long as the approver is authenticated and is not its
+// requester, and none of the features it changes have changed since it was
+// requested. The change is
+// recorded in the feature history as made by its requester.
+func (s *Store) ApproveChange(ctx context.Context, req *featurepb.ApproveChangeRequest) (*featurepb.ApproveChangeResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	pc, err := s.pendingChangeLocked(ctx, req.Id)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	approver := callerFromContext(ctx, "ApproveChange")
+	if !approver.authenticated {
+		return nil, rpcError(fmt.Errorf("%w: %s is not authenticated", ErrApprovalUnauthenticated, approver.actor))
+	}
+
+	if approver.actor == pc.RequestedBy {
+		return nil, rpcError(fmt.Errorf("%w: %s requested change %s", ErrSelfApproval, approver.actor, pc.Id))
+	}
+
+	var (
+		changes = make([]*featureChange, 0, len(pc.Changes))
+		v       = s.validationLocked()
+	)
+
+	for _, fc := range pc.Changes {
+		// A change that creates a feature expects it to still not exist.
+		if err := s.checkVersionLocked(fc.Name, fc.Before.GetVersion(), fc.Before == nil); err != nil {
+			return nil, rpcError(err)
+		}
+
+		change := &featureChange{name: fc.Name}
+
+		if feat, ok := s.features[fc.Name]; ok {
+			change.before = feat.Feature
+		}
+
+		if fc.After != nil {
+			// Segments the change refers to may have changed since.
+			change.after = s.newFeatureLocked(v, fc.After)
+		}
+
+		changes = append(changes, change)
+	}
+
+	if err := v.err(); err != nil {
+		return nil, rpcError(err)
+	}
+
+	c := &caller{
+		actor:      pc.RequestedBy,
+		peer:       approver.peer,
+		method:     approver.method,
+		reason:     pc.Reason,
+		approvedBy: approver.actor,
+	}
+
+	if _, err := s.commitLocked(c, changes, &Mutation{Type: DeletePendingChangeMutation, Name: pc.Id}); err != nil {
+		return nil, rpcError(err)
+	}
+
+	delete(s.pending, pc.Id)
+	log.Printf("[approval] %s approved change %s by %s", approver.actor, pc.Id, pc.RequestedBy)
+
+	resp := &featurepb.ApproveChangeResponse{
+		Changes: make([]*featurepb.FeatureChange, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		fc := &featurepb.FeatureChange{
+			Name:   change.name,
+			Before: change.before,
+		}
+
+		if change.after != nil {
+			fc.After = change.after.Feature
+		}
+
+		resp.Changes = append(resp.Changes, fc)
+	}
+
+	return resp, nil
+}
+
+// RejectChange is part of the featurepb.FeaturesServer interface. Requesters
+// may reject their own changes, to withdraw them.
+func (s *Store) RejectChange(ctx context.Context, req *featurepb.RejectChangeRequest) (*featurepb.RejectChangeResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	pc, err := s.pendingChangeLocked(ctx, req.Id)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	if err := s.backend.Apply(&Mutation{Type: DeletePendingChangeMutation, Name: pc.Id}); err != nil {
+		return nil, rpcError(err)
+	}
+
+	delete(s.pending, pc.Id)
+	log.Printf("[approval] %s rejected change %s by %s", actorFromContext(ctx), pc.Id, pc.RequestedBy)
+
+	return &featurepb.RejectChangeResponse{
+		Pending: pc,
+	}, nil
+}
@@ -0,0 +1,369 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// as returns a context for calling the store directly as the given editor, as
+// authenticated by an Authenticator.
+func as(actor string) context.Context {
+	return context.WithValue(context.Background(), identityKey{}, &Identity{Name: actor, Role: RoleEditor})
+}
+
+func TestApproveChange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a, err := NewAuthenticator([]*Credential{
+		{Name: "alice", Token: "a", Role: RoleEditor},
+		{Name: "bob", Token: "b", Role: RoleEditor},
+		{Name: "payments", Token: "p", Role: RoleEditor, Prefixes: []string{"payments/"}},
+		{Name: "reader", Token: "r", Role: RoleReader},
+	})
+	if err != nil {
+		t.Fatalf("NewAuthenticator error = %v", err)
+	}
+
+	s := NewStore()
+	s.SetAuditLog(openAuditLog(t, filepath.Join(t.TempDir(), "audit.log"), false))
+
+	client := newTestClient(t, s, a.ServerOptions()...)
+
+	token := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, AuthorizationMetadataKey, "Bearer "+token)
+	}
+
+	alice, bob := token("a"), token("b")
+
+	// Unprotected features are changed immediately.
+	resp, err := client.SetFeature(alice, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "unprotected", Type: featurepb.Feature_CONSTANT},
+	})
+	if err != nil || resp.Pending != nil {
+		t.Fatalf("SetFeature(unprotected) = %v, %v; want no pending change", resp, err)
+	}
+
+	resp, err = client.SetFeature(alice, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "kill_switch", Type: featurepb.Feature_CONSTANT, Enabled: true, Protected: true},
+	})
+	if err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	pending := resp.Pending
+	if pending == nil || pending.RequestedBy != "alice" || len(pending.Changes) != 1 {
+		t.Fatalf("SetFeature pending = %v, want a change requested by alice", pending)
+	}
+
+	if _, err := s.Get("kill_switch", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(kill_switch) before approval error = %v, want ErrNoFeature", err)
+	}
+
+	list, err := client.ListPendingChanges(token("r"), &featurepb.ListPendingChangesRequest{})
+	if err != nil || len(list.Pending) != 1 || list.Pending[0].Id != pending.Id {
+		t.Fatalf("ListPendingChanges() = %v, %v; want %s", list, err, pending.Id)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+		wantErr  error
+	}{
+		{
+			name:     "requester",
+			ctx:      alice,
+			wantCode: codes.PermissionDenied,
+			wantErr:  ErrSelfApproval,
+		},
+		{
+			name:     "out of scope",
+			ctx:      token("p"),
+			wantCode: codes.PermissionDenied,
+			wantErr:  ErrPermissionDenied,
+		},
+		{
+			name:     "reader",
+			ctx:      token("r"),
+			wantCode: codes.PermissionDenied,
+			wantErr:  ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ApproveChange(tt.ctx, &featurepb.ApproveChangeRequest{Id: pending.Id})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("ApproveChange() code = %v, want %v (err = %v)", got, tt.wantCode, err)
+			}
+
+			if !IsError(err, tt.wantErr) {
+				t.Errorf("ApproveChange() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	approved, err := client.ApproveChange(bob, &featurepb.ApproveChangeRequest{Id: pending.Id})
+	if err != nil {
+		t.Fatalf("ApproveChange error = %v", err)
+	}
+
+	if len(approved.Changes) != 1 || approved.Changes[0].After.Version != 1 {
+		t.Errorf("ApproveChange() = %v, want kill_switch at v1", approved)
+	}
+
+	if on, err := s.Get("kill_switch", nil); err != nil || !on {
+		t.Errorf("Get(kill_switch) after approval = %v, %v; want true", on, err)
+	}
+
+	// The change is recorded as alice's, approved by bob.
+	entries, err := client.ListAuditEntries(bob, &featurepb.ListAuditEntriesRequest{Feature: "kill_switch"})
+	if err != nil {
+		t.Fatalf("ListAuditEntries error = %v", err)
+	}
+
+	if e := entries.Entries[0]; len(entries.Entries) != 1 || e.Actor != "alice" || e.ApprovedBy != "bob" || e.Method != "ApproveChange" {
+		t.Errorf("ListAuditEntries() = %v, want one entry by alice, approved by bob", entries.Entries)
+	}
+
+	if _, err := client.ApproveChange(bob, &featurepb.ApproveChangeRequest{Id: pending.Id}); !IsError(err, ErrNoPendingChange) {
+		t.Errorf("ApproveChange() again error = %v, want ErrNoPendingChange", err)
+	}
+
+	// Deleting (or unprotecting) a protected feature needs approval too, and
+	// requesters may withdraw their own changes.
+	del, err := client.DeleteFeature(alice, &featurepb.DeleteFeatureRequest{Name: "kill_switch"})
+	if err != nil || del.Pending == nil {
+		t.Fatalf("DeleteFeature() = %v, %v; want a pending change", del, err)
+	}
+
+	if _, err := client.RejectChange(alice, &featurepb.RejectChangeRequest{Id: del.Pending.Id}); err != nil {
+		t.Fatalf("RejectChange error = %v", err)
+	}
+
+	if _, err := client.ApproveChange(bob, &featurepb.ApproveChangeRequest{Id: del.Pending.Id}); !IsError(err, ErrNoPendingChange) {
+		t.Errorf("ApproveChange() after RejectChange error = %v, want ErrNoPendingChange", err)
+	}
+
+	if _, err := s.Get("kill_switch", nil); err != nil {
+		t.Errorf("Get(kill_switch) after rejected delete error = %v", err)
+	}
+}
+
+func TestApprovalRequiresAuthentication(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := NewStore()
+	client := newTestClient(t, s)
+
+	claim := func(actor string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, ActorMetadataKey, actor)
+	}
+
+	// Without an Authenticator, callers can claim to be anyone, so they can't
+	// request changes to protected features...
+	_, err := client.SetFeature(claim("alice"), &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "kill_switch", Type: featurepb.Feature_CONSTANT, Protected: true},
+	})
+	if status.Code(err) != codes.FailedPrecondition || !IsError(err, ErrApprovalUnauthenticated) {
+		t.Errorf("SetFeature(protected) error = %v, want ErrApprovalUnauthenticated", err)
+	}
+
+	if _, err := s.Get("kill_switch", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(kill_switch) error = %v, want ErrNoFeature", err)
+	}
+
+	// ... or approve them, even if the requester was authenticated.
+	resp, err := s.SetFeature(as("alice"), &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "kill_switch", Type: featurepb.Feature_CONSTANT, Protected: true},
+	})
+	if err != nil || resp.Pending == nil {
+		t.Fatalf("SetFeature(protected) = %v, %v; want a pending change", resp, err)
+	}
+
+	_, err = client.ApproveChange(claim("bob"), &featurepb.ApproveChangeRequest{Id: resp.Pending.Id})
+	if status.Code(err) != codes.FailedPrecondition || !IsError(err, ErrApprovalUnauthenticated) {
+		t.Errorf("ApproveChange() error = %v, want ErrApprovalUnauthenticated", err)
+	}
+
+	if _, err := s.Get("kill_switch", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(kill_switch) after ApproveChange error = %v, want ErrNoFeature", err)
+	}
+}
+
+func TestApproveChangeConflict(t *testing.T) {
+	s := NewStore()
+
+	set := func(enabled bool) *featurepb.PendingChange {
+		t.Helper()
+
+		resp, err := s.SetFeature(as("alice"), &featurepb.SetFeatureRequest{
+			Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_CONSTANT, Enabled: enabled, Protected: true},
+		})
+		if err != nil {
+			t.Fatalf("SetFeature error = %v", err)
+		}
+
+		return resp.Pending
+	}
+
+	first, second := set(true), set(false)
+
+	if _, err := s.ApproveChange(as("bob"), &featurepb.ApproveChangeRequest{Id: first.Id}); err != nil {
+		t.Fatalf("ApproveChange(first) error = %v", err)
+	}
+
+	// The second change was requested before f existed.
+	if _, err := s.ApproveChange(as("bob"), &featurepb.ApproveChangeRequest{Id: second.Id}); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("ApproveChange(second) error = %v, want ErrVersionConflict", err)
+	}
+
+	third := set(false)
+	fourth := set(true)
+
+	if _, err := s.ApproveChange(as("bob"), &featurepb.ApproveChangeRequest{Id: third.Id}); err != nil {
+		t.Fatalf("ApproveChange(third) error = %v", err)
+	}
+
+	if _, err := s.ApproveChange(as("bob"), &featurepb.ApproveChangeRequest{Id: fourth.Id}); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("ApproveChange(fourth) error = %v, want ErrVersionConflict", err)
+	}
+
+	if on, err := s.Get("f", nil); err != nil || on {
+		t.Errorf("Get(f) = %v, %v; want false", on, err)
+	}
+}
+
+func TestApplyFeaturesProtected(t *testing.T) {
+	s := NewStore()
+
+	if _, err := s.SetFeature(as("alice"), &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT},
+	}); err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	protected := setOp("b", true)
+	protected.GetSet().Protected = true
+
+	resp, err := s.ApplyFeatures(as("alice"), &featurepb.ApplyFeaturesRequest{
+		Operations: []*featurepb.FeatureOperation{setOp("a", true), protected},
+	})
+	if err != nil {
+		t.Fatalf("ApplyFeatures error = %v", err)
+	}
+
+	if resp.Pending == nil || len(resp.Pending.Changes) != 2 {
+		t.Fatalf("ApplyFeatures() pending = %v, want both changes", resp.Pending)
+	}
+
+	// None of the batch is applied until it is approved.
+	if on, err := s.Get("a", nil); err != nil || on {
+		t.Errorf("Get(a) before approval = %v, %v; want false", on, err)
+	}
+
+	if _, err := s.ApproveChange(as("bob"), &featurepb.ApproveChangeRequest{Id: resp.Pending.Id}); err != nil {
+		t.Fatalf("ApproveChange error = %v", err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		if on, err := s.Get(name, nil); err != nil || !on {
+			t.Errorf("Get(%s) after approval = %v, %v; want true", name, on, err)
+		}
+	}
+}
+
+func TestPendingChangeExpiry(t *testing.T) {
+	s := NewStore()
+	s.SetApprovalTimeout(time.Hour)
+
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+
+	resp, err := s.SetFeature(as("alice"), &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_CONSTANT, Protected: true},
+	})
+	if err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	if want := now.Add(time.Hour).UnixNano(); resp.Pending.ExpireTimeUnixNano != want {
+		t.Errorf("pending expire time = %d, want %d", resp.Pending.ExpireTimeUnixNano, want)
+	}
+
+	now = now.Add(time.Hour)
+
+	list, err := s.ListPendingChanges(as("bob"), &featurepb.ListPendingChangesRequest{})
+	if err != nil || len(list.Pending) != 0 {
+		t.Errorf("ListPendingChanges() after expiry = %v, %v; want none", list, err)
+	}
+
+	if _, err := s.ApproveChange(as("bob"), &featurepb.ApproveChangeRequest{Id: resp.Pending.Id}); !errors.Is(err, ErrNoPendingChange) {
+		t.Errorf("ApproveChange() after expiry error = %v, want ErrNoPendingChange", err)
+	}
+}
+
+func TestPendingChangePersistence(t *testing.T) {
+	for _, tt := range durableBackends {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			s, err := OpenStore(tt.open(t, dir))
+			if err != nil {
+				t.Fatalf("OpenStore error = %v", err)
+			}
+
+			var ids []string
+			for _, name := range []string{"a", "b"} {
+				resp, err := s.SetFeature(as("alice"), &featurepb.SetFeatureRequest{
+					Feature: &featurepb.Feature{Name: name, Type: featurepb.Feature_CONSTANT, Enabled: true, Protected: true},
+				})
+				if err != nil {
+					t.Fatalf("SetFeature(%s) error = %v", name, err)
+				}
+
+				ids = append(ids, resp.Pending.Id)
+			}
+
+			if _, err := s.RejectChange(as("bob"), &featurepb.RejectChangeRequest{Id: ids[1]}); err != nil {
+				t.Fatalf("RejectChange error = %v", err)
+			}
+
+			s.Close()
+
+			// Reopening the store, as if after a restart, recovers the
+			// pending change, which can still be approved.
+			s, err = OpenStore(tt.open(t, dir))
+			if err != nil {
+				t.Fatalf("OpenStore error = %v", err)
+			}
+			defer s.Close()
+
+			list, err := s.ListPendingChanges(as("bob"), &featurepb.ListPendingChangesRequest{})
+			if err != nil || len(list.Pending) != 1 || list.Pending[0].Id != ids[0] {
+				t.Fatalf("ListPendingChanges() after reopen = %v, %v; want %s", list, err, ids[0])
+			}
+
+			if _, err := s.ApproveChange(as("bob"), &featurepb.ApproveChangeRequest{Id: ids[0]}); err != nil {
+				t.Fatalf("ApproveChange error = %v", err)
+			}
+
+			if on, err := s.Get("a", nil); err != nil || !on {
+				t.Errorf("Get(a) after approval = %v, %v; want true", on, err)
+			}
+		})
+	}
+}
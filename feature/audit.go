@@ -0,0 +1,463 @@
+package feature
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	// ErrNoAuditLog is returned by ListAuditEntries when the store has no
+	// audit log.
+	ErrNoAuditLog = errors.New("audit log is not enabled")
+	// ErrAuditChainBroken is returned when an audit log's hash chain does not
+	// verify, i.e. it has been modified since it was written.
+	ErrAuditChainBroken = errors.New("audit log hash chain is broken")
+)
+
+// AuditLog is an append-only record of every change made to a store's
+// features, as JSON lines of featurepb.AuditEntry. Every append is synced to
+// disk before returning, and an append that fails leaves the log as it was.
+//
+// If the log is hash-chained, each entry records the hash of the entry before
+// it, and its own hash, so that modifying or removing any entry but the last
+// can be detected (see VerifyAuditLog).
+type AuditLog struct {
+	path      string
+	hashChain bool
+
+	m        sync.Mutex
+	f        auditFile
+	lastHash string
+	// err is set if a failed append could not be undone, after which the log
+	// may end in a partial entry, and further appends are refused.
+	err error
+}
+
+// auditFile is the part of *os.File that an AuditLog appends with.
+type auditFile interface {
+	io.Writer
+	io.Seeker
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// auditMark is the end of an audit log at some point, which it can be
+// truncated back to.
+type auditMark struct {
+	offset   int64
+	lastHash string
+}
+
+// OpenAuditLog opens (creating, if needed) the audit log at path. If hashChain
+// is set, new entries are hash-chained; if the log already has chained
+// entries, they are verified, and the chain continues from the last of them.
+//
+// A partially written entry at the end of the log (as left by a crash during
+// an append) is discarded.
+func OpenAuditLog(path string, hashChain bool) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &AuditLog{
+		path:      path,
+		hashChain: hashChain,
+		f:         f,
+	}
+
+	entries, offset, err := readAuditEntries(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if hashChain {
+		if _, err := verifyAuditEntries(entries); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if n := len(entries); n > 0 {
+			l.lastHash = entries[n-1].Hash
+		}
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if fi.Size() > offset {
+		log.Printf("[audit] discarding partial entry at offset %d of %s", offset, path)
+
+		if err := f.Truncate(offset); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Close closes the audit log.
+func (l *AuditLog) Close() error {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	return l.f.Close()
+}
+
+// Append adds entries to the log, chaining them if the log is hash-chained.
+// If they can't all be written, none are.
+func (l *AuditLog) Append(entries ...*featurepb.AuditEntry) error {
+	_, err := l.append(entries...)
+	return err
+}
+
+// append is Append, but also returns the end of the log before the entries,
+// so that they can be removed with rollback if the change they record is not
+// made.
+func (l *AuditLog) append(entries ...*featurepb.AuditEntry) (auditMark, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.err != nil {
+		return auditMark{}, l.err
+	}
+
+	var (
+		buf      bytes.Buffer
+		lastHash = l.lastHash
+		m        = jsonpb.Marshaler{}
+	)
+
+	for _, e := range entries {
+		if l.hashChain {
+			e.PrevHash = lastHash
+			e.Hash = ""
+
+			hash, err := hashAuditEntry(e)
+			if err != nil {
+				return auditMark{}, err
+			}
+
+			e.Hash = hash
+			lastHash = hash
+		}
+
+		if err := m.Marshal(&buf, e); err != nil {
+			return auditMark{}, err
+		}
+
+		buf.WriteByte('\n')
+	}
+
+	offset, err := l.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return auditMark{}, err
+	}
+
+	mark := auditMark{offset: offset, lastHash: l.lastHash}
+
+	if err := l.write(buf.Bytes()); err != nil {
+		// Don't leave a partial entry behind for later appends to follow.
+		l.truncateLocked(mark)
+		return auditMark{}, err
+	}
+
+	l.lastHash = lastHash
+	return mark, nil
+}
+
+func (l *AuditLog) write(data []byte) error {
+	if _, err := l.f.Write(data); err != nil {
+		return err
+	}
+
+	return l.f.Sync()
+}
+
+// rollback removes the entries appended since mark. Callers must ensure
+// nothing else has appended to the log since.
+func (l *AuditLog) rollback(mark auditMark) error {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	return l.truncateLocked(mark)
+}
+
+// truncateLocked truncates the log back to mark. If that fails, the log is
+// marked unusable, since it may now end in a partial entry. Callers must hold
+// l.m.
+func (l *AuditLog) truncateLocked(mark auditMark) error {
+	err := l.f.Truncate(mark.offset)
+	if err == nil {
+		_, err = l.f.Seek(mark.offset, io.SeekStart)
+	}
+
+	if err == nil {
+		err = l.f.Sync()
+	}
+
+	if err != nil {
+		l.err = fmt.Errorf("audit log %s is unusable after failing to undo an append: %w", l.path, err)
+		return l.err
+	}
+
+	l.lastHash = mark.lastHash
+	return nil
+}
+
+// Entries returns the entries in the log that match req (see
+// featurepb.ListAuditEntriesRequest), oldest first.
+func (l *AuditLog) Entries(req *featurepb.ListAuditEntriesRequest) ([]*featurepb.AuditEntry, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries, _, err := readAuditEntries(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterAuditEntries(entries, req), nil
+}
+
+// ReadAuditLog returns every entry in the audit log at path, oldest first.
+func ReadAuditLog(path string) ([]*featurepb.AuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries, _, err := readAuditEntries(f)
+	return entries, err
+}
+
+// VerifyAuditLog checks the hash chain of the audit log at path, and returns
+// the number of chained entries. Entries written before the log was
+// hash-chained are not checked, but every entry after the first chained one
+// must be chained. Note that removing entries from the end of a log leaves a
+// valid chain; to detect that, keep a copy of the last entry's hash elsewhere.
+func VerifyAuditLog(path string) (int, error) {
+	entries, err := ReadAuditLog(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return verifyAuditEntries(entries)
+}
+
+// readAuditEntries reads every complete entry from r, and returns them along
+// with the offset of the end of the last one.
+func readAuditEntries(r io.Reader) ([]*featurepb.AuditEntry, int64, error) {
+	var (
+		entries []*featurepb.AuditEntry
+		offset  int64
+		br      = bufio.NewReader(r)
+		u       = jsonpb.Unmarshaler{AllowUnknownFields: true}
+	)
+
+	for line := 1; ; line++ {
+		data, err := br.ReadBytes('\n')
+		if err == io.EOF {
+			// Anything after the last newline is a partially written entry.
+			return entries, offset, nil
+		}
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		e := &featurepb.AuditEntry{}
+		if err := u.Unmarshal(bytes.NewReader(data), e); err != nil {
+			return nil, 0, fmt.Errorf("audit log line %d: %w", line, err)
+		}
+
+		entries = append(entries, e)
+		offset += int64(len(data))
+	}
+}
+
+func verifyAuditEntries(entries []*featurepb.AuditEntry) (int, error) {
+	var (
+		chained  int
+		lastHash string
+	)
+
+	for i, e := range entries {
+		if e.Hash == "" {
+			if chained > 0 {
+				return 0, fmt.Errorf("%w: entry %d is not chained", ErrAuditChainBroken, i+1)
+			}
+
+			continue
+		}
+
+		if e.PrevHash != lastHash {
+			return 0, fmt.Errorf("%w: entry %d does not follow the previous entry", ErrAuditChainBroken, i+1)
+		}
+
+		c := proto.Clone(e).(*featurepb.AuditEntry)
+		c.Hash = ""
+
+		hash, err := hashAuditEntry(c)
+		if err != nil {
+			return 0, err
+		}
+
+		if hash != e.Hash {
+			return 0, fmt.Errorf("%w: entry %d has been modified", ErrAuditChainBroken, i+1)
+		}
+
+		lastHash = e.Hash
+		chained++
+	}
+
+	return chained, nil
+}
+
+// hashAuditEntry returns the hash of an entry (which includes the hash of the
+// entry before it) without its own hash set.
+func hashAuditEntry(e *featurepb.AuditEntry) (string, error) {
+	data, err := (&jsonpb.Marshaler{}).MarshalToString(e)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func filterAuditEntries(entries []*featurepb.AuditEntry, req *featurepb.ListAuditEntriesRequest) []*featurepb.AuditEntry {
+	var matched []*featurepb.AuditEntry
+
+	for _, e := range entries {
+		switch {
+		case req.Feature != "" && e.Feature != req.Feature:
+		case req.Actor != "" && e.Actor != req.Actor:
+		case req.SinceUnixNano != 0 && e.TimeUnixNano < req.SinceUnixNano:
+		case req.UntilUnixNano != 0 && e.TimeUnixNano >= req.UntilUnixNano:
+		default:
+			matched = append(matched, e)
+		}
+	}
+
+	if req.Limit != 0 && len(matched) > int(req.Limit) {
+		matched = matched[len(matched)-int(req.Limit):]
+	}
+
+	return matched
+}
+
+// revisionAction returns what a revision did to its feature: "create",
+// "update" or "delete".
+func revisionAction(rev *featurepb.FeatureRevision) string {
+	switch {
+	case rev.Before == nil:
+		return "create"
+	case rev.After == nil:
+		return "delete"
+	}
+
+	return "update"
+}
+
+// SetAuditLog sets the audit log that changes to the store's features are
+// recorded in. The store does not take ownership of it; callers must close it
+// after closing the store.
+func (s *Store) SetAuditLog(l *AuditLog) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.audit = l
+}
+
+// auditLocked records the given revisions, made by c, in the audit log, if
+// the store has one. It is called before the revisions are committed, so that
+// no change goes unrecorded: if they can't be recorded, the change must not be
+// made. If they are recorded, but then fail to commit, the caller must call
+// the returned rollback to remove them again. Callers must hold s.m.
+func (s *Store) auditLocked(c *caller, revisions []*featurepb.FeatureRevision) (rollback func(), err error) {
+	if s.audit == nil || len(revisions) == 0 {
+		return func() {}, nil
+	}
+
+	entries := make([]*featurepb.AuditEntry, 0, len(revisions))
+	for _, rev := range revisions {
+		entries = append(entries, &featurepb.AuditEntry{
+			TimeUnixNano: rev.TimeUnixNano,
+			Action:       revisionAction(rev),
+			Feature:      rev.Name,
+			Version:      rev.Version,
+			Actor:        rev.Actor,
+			Peer:         c.peer,
+			Method:       c.method,
+			Reason:       c.reason,
+			ApprovedBy:   c.approvedBy,
+			Before:       rev.Before,
+			After:        rev.After,
+		})
+	}
+
+	audit := s.audit
+
+	mark, err := audit.append(entries...)
+	if err != nil {
+		return nil, fmt.Errorf("recording change in audit log: %w", err)
+	}
+
+	return func() {
+		if err := audit.rollback(mark); err != nil {
+			log.Printf("[audit] failed to remove %d uncommitted change(s) by %s: %v", len(entries), c.actor, err)
+		}
+	}, nil
+}
+
+// ListAuditEntries is part of the featurepb.FeaturesServer interface.
+func (s *Store) ListAuditEntries(ctx context.Context, req *featurepb.ListAuditEntriesRequest) (*featurepb.ListAuditEntriesResponse, error) {
+	s.m.RLock()
+	audit := s.audit
+	s.m.RUnlock()
+
+	if audit == nil {
+		return nil, rpcError(ErrNoAuditLog)
+	}
+
+	entries, err := audit.Entries(req)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	return &featurepb.ListAuditEntriesResponse{
+		Entries: entries,
+	}, nil
+}
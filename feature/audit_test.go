@@ -0,0 +1,426 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func openAuditLog(t *testing.T, path string, hashChain bool) *AuditLog {
+	t.Helper()
+
+	l, err := OpenAuditLog(path, hashChain)
+	if err != nil {
+		t.Fatalf("OpenAuditLog error = %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	return l
+}
+
+func TestAuditLog(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+
+	s := NewStore()
+	s.SetAuditLog(openAuditLog(t, filepath.Join(dir, "audit.log"), false))
+
+	client := newTestClient(t, s)
+
+	config := filepath.Join(dir, "flags.json")
+	if err := ioutil.WriteFile(config, []byte(`{"a": {"type": "CONSTANT"}}`), 0644); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+
+	if err := s.InitFromFile(config); err != nil {
+		t.Fatalf("InitFromFile error = %v", err)
+	}
+
+	alice := metadata.AppendToOutgoingContext(ctx, ActorMetadataKey, "alice", ReasonMetadataKey, "INC-123")
+
+	if _, err := client.SetFeature(alice, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT, Enabled: true},
+	}); err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	if _, err := client.ApplyFeatures(ctx, &featurepb.ApplyFeaturesRequest{
+		Operations: []*featurepb.FeatureOperation{setOp("b", true), deleteOp("a")},
+	}); err != nil {
+		t.Fatalf("ApplyFeatures error = %v", err)
+	}
+
+	resp, err := client.ListAuditEntries(ctx, &featurepb.ListAuditEntriesRequest{})
+	if err != nil {
+		t.Fatalf("ListAuditEntries error = %v", err)
+	}
+
+	var got []string
+	for _, e := range resp.Entries {
+		got = append(got, strings.Join([]string{e.Method, e.Action, e.Feature, e.Reason}, ":"))
+	}
+
+	want := []string{
+		"init:create:a:",
+		"SetFeature:update:a:INC-123",
+		"ApplyFeatures:create:b:",
+		"ApplyFeatures:delete:a:",
+	}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ListAuditEntries() = %v, want %v", got, want)
+	}
+
+	set := resp.Entries[1]
+	if set.Actor != "alice" || set.Peer == "" || set.Before.Enabled || !set.After.Enabled || set.Version != 2 {
+		t.Errorf("SetFeature entry = %v, want alice from a peer, v2, before disabled and after enabled", set)
+	}
+
+	if set.Hash != "" {
+		t.Errorf("SetFeature entry hash = %q, want none without a hash chain", set.Hash)
+	}
+}
+
+func TestListAuditEntries(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewStore()
+
+	if _, err := s.ListAuditEntries(ctx, &featurepb.ListAuditEntriesRequest{}); !errors.Is(err, ErrNoAuditLog) {
+		t.Errorf("ListAuditEntries without audit log error = %v, want ErrNoAuditLog", err)
+	}
+
+	s.SetAuditLog(openAuditLog(t, filepath.Join(t.TempDir(), "audit.log"), false))
+
+	start := time.Unix(1000, 0)
+	now := start
+	s.now = func() time.Time { return now }
+
+	for i, change := range []struct{ actor, name string }{
+		{"alice", "a"},
+		{"bob", "a"},
+		{"alice", "b"},
+		{"bob", "b"},
+	} {
+		now = start.Add(time.Duration(i) * time.Hour)
+		ctx := metadata.NewIncomingContext(ctx, metadata.Pairs(ActorMetadataKey, change.actor))
+
+		if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+			Feature: &featurepb.Feature{Name: change.name, Type: featurepb.Feature_CONSTANT, Enabled: i%2 == 0},
+		}); err != nil {
+			t.Fatalf("SetFeature error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		req  *featurepb.ListAuditEntriesRequest
+		// want are the matching entries, as actor:feature.
+		want []string
+	}{
+		{
+			name: "all",
+			req:  &featurepb.ListAuditEntriesRequest{},
+			want: []string{"alice:a", "bob:a", "alice:b", "bob:b"},
+		},
+		{
+			name: "feature",
+			req:  &featurepb.ListAuditEntriesRequest{Feature: "b"},
+			want: []string{"alice:b", "bob:b"},
+		},
+		{
+			name: "actor",
+			req:  &featurepb.ListAuditEntriesRequest{Actor: "bob"},
+			want: []string{"bob:a", "bob:b"},
+		},
+		{
+			name: "time range",
+			req: &featurepb.ListAuditEntriesRequest{
+				SinceUnixNano: start.Add(time.Hour).UnixNano(),
+				UntilUnixNano: start.Add(3 * time.Hour).UnixNano(),
+			},
+			want: []string{"bob:a", "alice:b"},
+		},
+		{
+			name: "limit",
+			req:  &featurepb.ListAuditEntriesRequest{Actor: "alice", Limit: 1},
+			want: []string{"alice:b"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ListAuditEntries(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("ListAuditEntries error = %v", err)
+			}
+
+			var got []string
+			for _, e := range resp.Entries {
+				got = append(got, e.Actor+":"+e.Feature)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ListAuditEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLogHashChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	appendEntries := func(l *AuditLog, features ...string) {
+		t.Helper()
+
+		for _, name := range features {
+			if err := l.Append(&featurepb.AuditEntry{Feature: name, Action: "create"}); err != nil {
+				t.Fatalf("Append error = %v", err)
+			}
+		}
+	}
+
+	// Entries from before the log was chained are not checked.
+	l, err := OpenAuditLog(path, false)
+	if err != nil {
+		t.Fatalf("OpenAuditLog error = %v", err)
+	}
+
+	appendEntries(l, "a")
+	l.Close()
+
+	l, err = OpenAuditLog(path, true)
+	if err != nil {
+		t.Fatalf("OpenAuditLog error = %v", err)
+	}
+
+	appendEntries(l, "b", "c")
+	l.Close()
+
+	// Reopening continues the chain.
+	l, err = OpenAuditLog(path, true)
+	if err != nil {
+		t.Fatalf("OpenAuditLog error = %v", err)
+	}
+
+	appendEntries(l, "d")
+	l.Close()
+
+	if n, err := VerifyAuditLog(path); err != nil || n != 3 {
+		t.Fatalf("VerifyAuditLog() = %d, %v; want 3 chained entries", n, err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile error = %v", err)
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+	}{
+		{
+			name: "modified",
+			tamper: func(lines []string) []string {
+				lines[2] = strings.Replace(lines[2], `"c"`, `"x"`, 1)
+				return lines
+			},
+		},
+		{
+			name: "removed",
+			tamper: func(lines []string) []string {
+				return append(lines[:2:2], lines[3:]...)
+			},
+		},
+		{
+			name: "reordered",
+			tamper: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+				return lines
+			},
+		},
+		{
+			name: "unchained entry inserted",
+			tamper: func(lines []string) []string {
+				return append(lines[:2:2], append([]string{lines[0]}, lines[2:]...)...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := filepath.Join(t.TempDir(), "audit.log")
+
+			lines := tt.tamper(append([]string(nil), lines...))
+			if err := ioutil.WriteFile(tampered, []byte(strings.Join(lines, "")), 0600); err != nil {
+				t.Fatalf("WriteFile error = %v", err)
+			}
+
+			if _, err := VerifyAuditLog(tampered); !errors.Is(err, ErrAuditChainBroken) {
+				t.Errorf("VerifyAuditLog error = %v, want ErrAuditChainBroken", err)
+			}
+
+			if _, err := OpenAuditLog(tampered, true); !errors.Is(err, ErrAuditChainBroken) {
+				t.Errorf("OpenAuditLog error = %v, want ErrAuditChainBroken", err)
+			}
+		})
+	}
+}
+
+func TestAuditLogPartialEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	l := openAuditLog(t, path, true)
+	if err := l.Append(&featurepb.AuditEntry{Feature: "a"}); err != nil {
+		t.Fatalf("Append error = %v", err)
+	}
+	l.Close()
+
+	// Simulate a crash partway through an append.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("OpenFile error = %v", err)
+	}
+
+	if _, err := f.WriteString(`{"feature": "b", "prev`); err != nil {
+		t.Fatalf("WriteString error = %v", err)
+	}
+	f.Close()
+
+	l = openAuditLog(t, path, true)
+	if err := l.Append(&featurepb.AuditEntry{Feature: "c"}); err != nil {
+		t.Fatalf("Append error = %v", err)
+	}
+
+	entries, err := ReadAuditLog(path)
+	if err != nil {
+		t.Fatalf("ReadAuditLog error = %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].Feature != "a" || entries[1].Feature != "c" {
+		t.Errorf("ReadAuditLog() = %v, want a and c", entries)
+	}
+
+	if n, err := VerifyAuditLog(path); err != nil || n != 2 {
+		t.Errorf("VerifyAuditLog() = %d, %v; want 2 chained entries", n, err)
+	}
+}
+
+var errDiskFull = errors.New("disk full")
+
+// failingAuditFile writes only part of each write while fail is set, as a
+// full disk might.
+type failingAuditFile struct {
+	auditFile
+	fail bool
+}
+
+func (f *failingAuditFile) Write(p []byte) (int, error) {
+	if !f.fail {
+		return f.auditFile.Write(p)
+	}
+
+	n, _ := f.auditFile.Write(p[:len(p)/2])
+	return n, errDiskFull
+}
+
+func TestAuditLogFailedAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	l := openAuditLog(t, path, true)
+	if err := l.Append(&featurepb.AuditEntry{Feature: "a"}); err != nil {
+		t.Fatalf("Append error = %v", err)
+	}
+
+	f := &failingAuditFile{auditFile: l.f, fail: true}
+	l.f = f
+
+	if err := l.Append(&featurepb.AuditEntry{Feature: "b"}); !errors.Is(err, errDiskFull) {
+		t.Fatalf("Append error = %v, want errDiskFull", err)
+	}
+
+	f.fail = false
+	if err := l.Append(&featurepb.AuditEntry{Feature: "c"}); err != nil {
+		t.Fatalf("Append error = %v", err)
+	}
+	l.Close()
+
+	// The partial entry for b was removed, so the log reopens, and its chain
+	// skips b.
+	openAuditLog(t, path, true)
+
+	entries, err := ReadAuditLog(path)
+	if err != nil {
+		t.Fatalf("ReadAuditLog error = %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].Feature != "a" || entries[1].Feature != "c" {
+		t.Errorf("ReadAuditLog() = %v, want a and c", entries)
+	}
+
+	if n, err := VerifyAuditLog(path); err != nil || n != 2 {
+		t.Errorf("VerifyAuditLog() = %d, %v; want 2 chained entries", n, err)
+	}
+}
+
+func TestAuditLogBeforeCommit(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	l := openAuditLog(t, path, false)
+	f := &failingAuditFile{auditFile: l.f, fail: true}
+	l.f = f
+
+	s := NewStore()
+	s.SetAuditLog(l)
+
+	// A change that can't be recorded is not made.
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT},
+	}); !errors.Is(err, errDiskFull) {
+		t.Errorf("SetFeature error = %v, want errDiskFull", err)
+	}
+
+	if _, err := s.Get("a", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(a) error = %v, want ErrNoFeature", err)
+	}
+
+	if len(s.history["a"]) != 0 {
+		t.Errorf("history of a = %v, want none", s.history["a"])
+	}
+
+	// A change that is recorded, but then fails to commit, is removed from
+	// the log again.
+	f.fail = false
+
+	s, err := OpenStore(failingBackend{NewMemoryBackend()})
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+	s.SetAuditLog(l)
+
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT},
+	}); !errors.Is(err, errBackend) {
+		t.Errorf("SetFeature error = %v, want errBackend", err)
+	}
+
+	if entries, err := ReadAuditLog(path); err != nil || len(entries) != 0 {
+		t.Errorf("ReadAuditLog() = %v, %v; want no entries", entries, err)
+	}
+}
@@ -0,0 +1,452 @@
+package feature
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	// ErrUnauthenticated is returned for a request without a valid bearer
+	// token, when the server requires one.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrPermissionDenied is returned for a request that the caller's role
+	// or scopes do not allow.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrInvalidCredentials is returned for a malformed credentials file.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// AuthorizationMetadataKey is the gRPC metadata key that clients send their
+// bearer token in, as "Bearer <token>".
+const AuthorizationMetadataKey = "authorization"
+
+const bearerPrefix = "bearer "
+
+// Role is what a caller is allowed to do. Each role may do everything the
+// roles before it may.
+type Role int
+
+const (
+	// RoleReader may get, evaluate and watch features and segments, and list
+	// feature history and the audit log.
+	RoleReader Role = iota + 1
+	// RoleEditor may also set, delete, apply and roll back features.
+	RoleEditor
+	// RoleAdmin may also set and delete segments, and call any other RPC.
+	RoleAdmin
+)
+
+var roleNames = map[Role]string{
+	RoleReader: "reader",
+	RoleEditor: "editor",
+	RoleAdmin:  "admin",
+}
+
+func (r Role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Role(%d)", int(r))
+}
+
+// MarshalText encodes the role by name, for the credentials file.
+func (r Role) MarshalText() ([]byte, error) {
+	if _, ok := roleNames[r]; !ok {
+		return nil, fmt.Errorf("%w: unknown role %d", ErrInvalidCredentials, int(r))
+	}
+
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText decodes a role by name.
+func (r *Role) UnmarshalText(text []byte) error {
+	for role, name := range roleNames {
+		if name == string(text) {
+			*r = role
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: unknown role %q", ErrInvalidCredentials, text)
+}
+
+// methodRoles is the role required to call each RPC. RPCs not listed require
+// RoleAdmin, so new RPCs are locked down until they are added here.
+var methodRoles = map[string]Role{
+	"/feature.Features/GetFeature":            RoleReader,
+	"/feature.Features/GetFeatures":           RoleReader,
+	"/feature.Features/EvaluateFeature":       RoleReader,
+	"/feature.Features/EvaluateFeatures":      RoleReader,
+	"/feature.Features/ListFeatureHistory":    RoleReader,
+	"/feature.Features/ListAuditEntries":      RoleReader,
+	"/feature.Features/WatchFeatures":         RoleReader,
+	"/feature.Features/GetSegment":            RoleReader,
+	"/feature.Features/GetSegments":           RoleReader,
+	"/feature.Features/ListPendingChanges":    RoleReader,
+	"/feature.Features/ListScheduledChanges":  RoleReader,
+	"/feature.Features/SetFeature":            RoleEditor,
+	"/feature.Features/DeleteFeature":         RoleEditor,
+	"/feature.Features/ApplyFeatures":         RoleEditor,
+	"/feature.Features/RollbackFeature":       RoleEditor,
+	"/feature.Features/ApproveChange":         RoleEditor,
+	"/feature.Features/RejectChange":          RoleEditor,
+	"/feature.Features/ScheduleFeature":       RoleEditor,
+	"/feature.Features/CancelScheduledChange": RoleEditor,
+	"/feature.Features/SetSegment":            RoleAdmin,
+	"/feature.Features/DeleteSegment":         RoleAdmin,
+}
+
+// Credential is an entry in a credentials file: a token, and who presenting it
+// authenticates as.
+type Credential struct {
+	// Name identifies the caller, e.g. in the feature history.
+	Name string `json:"name"`
+	// Token is the bearer token. Prefer TokenSHA256, so that the credentials
+	// file does not hold the tokens themselves.
+	Token string `json:"token,omitempty"`
+	// TokenSHA256 is the hex-encoded SHA-256 hash of the bearer token.
+	TokenSHA256 string `json:"token_sha256,omitempty"`
+	Role        Role   `json:"role"`
+	// Prefixes, if set, limits the features the caller may change to those
+	// whose names start with one of them. It does not limit reads.
+	Prefixes []string `json:"prefixes,omitempty"`
+}
+
+// Identity is an authenticated caller.
+type Identity struct {
+	Name     string
+	Role     Role
+	Prefixes []string
+}
+
+// CanChange reports whether the caller's scopes allow it to change the named
+// feature.
+func (id *Identity) CanChange(name string) bool {
+	if len(id.Prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range id.Prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type identityKey struct{}
+
+// IdentityFromContext returns the caller authenticated by the Authenticator's
+// interceptors, if any.
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
+
+// Authenticator authenticates Features RPCs with bearer tokens, and authorizes
+// them by the caller's role and scopes. Install it on a gRPC server with
+// UnaryInterceptor and StreamInterceptor.
+type Authenticator struct {
+	m      sync.RWMutex
+	tokens map[[sha256.Size]byte]*Identity
+}
+
+// NewAuthenticator returns an Authenticator that accepts the given credentials.
+func NewAuthenticator(creds []*Credential) (*Authenticator, error) {
+	a := &Authenticator{}
+	if err := a.SetCredentials(creds); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// LoadAuthenticator returns an Authenticator that accepts the credentials in
+// the file at path. See LoadFile for the format.
+func LoadAuthenticator(path string) (*Authenticator, error) {
+	a := &Authenticator{}
+	if err := a.LoadFile(path); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// LoadFile replaces the accepted credentials with those in the file at path,
+// which holds a JSON object of the form:
+//
+//	{
+//	  "credentials": [
+//	    {"name": "ci", "token_sha256": "9f86d0...", "role": "editor", "prefixes": ["payments/"]},
+//	    {"name": "alice", "token": "s3cret", "role": "admin"}
+//	  ]
+//	}
+//
+// If the file is invalid, the credentials are left unchanged.
+func (a *Authenticator) LoadFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var file struct {
+		Credentials []*Credential `json:"credentials"`
+	}
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := a.SetCredentials(file.Credentials); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// SetCredentials replaces the accepted credentials. If any are invalid, the
+// credentials are left unchanged.
+func (a *Authenticator) SetCredentials(creds []*Credential) error {
+	tokens := make(map[[sha256.Size]byte]*Identity, len(creds))
+
+	for i, c := range creds {
+		if c == nil || c.Name == "" {
+			return fmt.Errorf("%w: credential %d has no name", ErrInvalidCredentials, i)
+		}
+
+		if _, ok := roleNames[c.Role]; !ok {
+			return fmt.Errorf("%w: %s has no role", ErrInvalidCredentials, c.Name)
+		}
+
+		var hash [sha256.Size]byte
+
+		switch {
+		case c.Token != "" && c.TokenSHA256 != "":
+			return fmt.Errorf("%w: %s has both token and token_sha256", ErrInvalidCredentials, c.Name)
+		case c.Token != "":
+			hash = sha256.Sum256([]byte(c.Token))
+		case c.TokenSHA256 != "":
+			b, err := hex.DecodeString(c.TokenSHA256)
+			if err != nil || len(b) != sha256.Size {
+				return fmt.Errorf("%w: %s has a malformed token_sha256", ErrInvalidCredentials, c.Name)
+			}
+
+			copy(hash[:], b)
+		default:
+			return fmt.Errorf("%w: %s has no token", ErrInvalidCredentials, c.Name)
+		}
+
+		if other, ok := tokens[hash]; ok {
+			return fmt.Errorf("%w: %s and %s have the same token", ErrInvalidCredentials, other.Name, c.Name)
+		}
+
+		tokens[hash] = &Identity{
+			Name:     c.Name,
+			Role:     c.Role,
+			Prefixes: append([]string(nil), c.Prefixes...),
+		}
+	}
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	a.tokens = tokens
+	return nil
+}
+
+// authenticate returns the caller presenting the bearer token in ctx.
+func (a *Authenticator) authenticate(ctx context.Context) (*Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	vals := md.Get(AuthorizationMetadataKey)
+	if len(vals) == 0 {
+		return nil, fmt.Errorf("%w: no bearer token", ErrUnauthenticated)
+	}
+
+	if len(vals[0]) < len(bearerPrefix) || !strings.EqualFold(vals[0][:len(bearerPrefix)], bearerPrefix) {
+		return nil, fmt.Errorf("%w: authorization is not a bearer token", ErrUnauthenticated)
+	}
+
+	// Tokens are looked up by hash, so the lookup does not leak how much of
+	// a token matched.
+	hash := sha256.Sum256([]byte(strings.TrimSpace(vals[0][len(bearerPrefix):])))
+
+	a.m.RLock()
+	defer a.m.RUnlock()
+
+	id, ok := a.tokens[hash]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown token", ErrUnauthenticated)
+	}
+
+	return id, nil
+}
+
+// authorize authenticates the caller of method, and checks that it may make
+// the request, which is nil for streaming RPCs.
+func (a *Authenticator) authorize(ctx context.Context, method string, req interface{}) (*Identity, error) {
+	id, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	required, ok := methodRoles[method]
+	if !ok {
+		required = RoleAdmin
+	}
+
+	if id.Role < required {
+		return nil, &statusError{
+			code: codes.PermissionDenied,
+			err:  fmt.Errorf("%w: %s has role %s, but %s requires %s", ErrPermissionDenied, id.Name, id.Role, path.Base(method), required),
+			metadata: map[string]string{
+				"caller":        id.Name,
+				"role":          id.Role.String(),
+				"required_role": required.String(),
+			},
+		}
+	}
+
+	if err := id.checkCanChange(changedFeatures(req)...); err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// checkCanChange returns a PermissionDenied error if the caller may not change
+// all of the named features.
+func (id *Identity) checkCanChange(names ...string) error {
+	for _, name := range names {
+		if !id.CanChange(name) {
+			return &statusError{
+				code: codes.PermissionDenied,
+				err:  fmt.Errorf("%w: %s may only change features starting with %s, not %s", ErrPermissionDenied, id.Name, strings.Join(id.Prefixes, ", "), name),
+				metadata: map[string]string{
+					"caller":  id.Name,
+					"feature": name,
+				},
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkCanChange returns a PermissionDenied error if the caller authenticated
+// in ctx, if any, may not change all of the named features. It is for RPCs
+// whose requests don't name the features they change (see changedFeatures).
+func checkCanChange(ctx context.Context, names ...string) error {
+	id, ok := IdentityFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return id.checkCanChange(names...)
+}
+
+// changedFeatures returns the names of the features that req changes.
+func changedFeatures(req interface{}) []string {
+	switch req := req.(type) {
+	case *featurepb.SetFeatureRequest:
+		return []string{req.Feature.GetName()}
+	case *featurepb.DeleteFeatureRequest:
+		return []string{req.Name}
+	case *featurepb.RollbackFeatureRequest:
+		return []string{req.Name}
+	case *featurepb.ScheduleFeatureRequest:
+		return []string{req.Name}
+	case *featurepb.ApplyFeaturesRequest:
+		names := make([]string, 0, len(req.Operations))
+		for _, op := range req.Operations {
+			switch op := op.GetOp().(type) {
+			case *featurepb.FeatureOperation_Set:
+				names = append(names, op.Set.GetName())
+			case *featurepb.FeatureOperation_Delete:
+				names = append(names, op.Delete)
+			}
+		}
+
+		return names
+	}
+
+	return nil
+}
+
+// UnaryInterceptor returns a gRPC interceptor that rejects unary RPCs the
+// caller is not authorized to make.
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id, err := a.authorize(ctx, info.FullMethod, req)
+		if err != nil {
+			return nil, rpcError(err)
+		}
+
+		return handler(context.WithValue(ctx, identityKey{}, id), req)
+	}
+}
+
+// StreamInterceptor returns a gRPC interceptor that rejects streaming RPCs the
+// caller is not authorized to make.
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id, err := a.authorize(ss.Context(), info.FullMethod, nil)
+		if err != nil {
+			return rpcError(err)
+		}
+
+		return handler(srv, &identityStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), identityKey{}, id)})
+	}
+}
+
+// ServerOptions returns the options to install the Authenticator on a gRPC
+// server.
+func (a *Authenticator) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(a.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(a.StreamInterceptor()),
+	}
+}
+
+// identityStream is a grpc.ServerStream whose context carries the caller's
+// Identity.
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context { return s.ctx }
+
+// BearerToken is a credentials.PerRPCCredentials that sends a bearer token
+// with every RPC, for servers using an Authenticator:
+//
+//	cc, err := grpc.Dial(addr, grpc.WithPerRPCCredentials(feature.BearerToken(token)), ...)
+//
+// It does not require transport security, so the token is sent in the clear
+// over an insecure connection.
+type BearerToken string
+
+// GetRequestMetadata is part of the credentials.PerRPCCredentials interface.
+func (t BearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{AuthorizationMetadataKey: "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity is part of the credentials.PerRPCCredentials
+// interface.
+func (t BearerToken) RequireTransportSecurity() bool { return false }
@@ -0,0 +1,240 @@
+package feature
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestAuthenticator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a, err := NewAuthenticator([]*Credential{
+		{Name: "reader", Token: "r", Role: RoleReader},
+		{Name: "editor", Token: "e", Role: RoleEditor},
+		{Name: "payments", Token: "p", Role: RoleEditor, Prefixes: []string{"payments/"}},
+		{Name: "admin", Token: "a", Role: RoleAdmin},
+	})
+	if err != nil {
+		t.Fatalf("NewAuthenticator error = %v", err)
+	}
+
+	s := NewStore()
+	client := newTestClient(t, s, a.ServerOptions()...)
+
+	set := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+				Feature: &featurepb.Feature{Name: name, Type: featurepb.Feature_CONSTANT},
+			})
+			return err
+		}
+	}
+
+	get := func(ctx context.Context) error {
+		_, err := client.GetFeatures(ctx, &featurepb.GetFeaturesRequest{})
+		return err
+	}
+
+	setSegment := func(ctx context.Context) error {
+		_, err := client.SetSegment(ctx, &featurepb.SetSegmentRequest{
+			Segment: &featurepb.Segment{Name: "beta", Key: "user_id", Included: []string{"1"}},
+		})
+		return err
+	}
+
+	apply := func(names ...string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			req := &featurepb.ApplyFeaturesRequest{DryRun: true}
+			for _, name := range names {
+				req.Operations = append(req.Operations, setOp(name, true))
+			}
+
+			_, err := client.ApplyFeatures(ctx, req)
+			return err
+		}
+	}
+
+	watch := func(ctx context.Context) error {
+		stream, err := client.WatchFeatures(ctx, &featurepb.WatchFeaturesRequest{})
+		if err != nil {
+			return err
+		}
+
+		_, err = stream.Recv()
+		return err
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		call  func(ctx context.Context) error
+		want  codes.Code
+	}{
+		{name: "no token", call: get, want: codes.Unauthenticated},
+		{name: "unknown token", token: "Bearer x", call: get, want: codes.Unauthenticated},
+		{name: "not a bearer token", token: "Basic r", call: get, want: codes.Unauthenticated},
+		{name: "reader can read", token: "Bearer r", call: get},
+		{name: "reader can watch", token: "Bearer r", call: watch},
+		{name: "no token cannot watch", call: watch, want: codes.Unauthenticated},
+		{name: "reader cannot set", token: "Bearer r", call: set("f"), want: codes.PermissionDenied},
+		{name: "editor can set", token: "Bearer e", call: set("f")},
+		{name: "scheme is case-insensitive", token: "bearer e", call: set("f")},
+		{name: "editor cannot set segments", token: "Bearer e", call: setSegment, want: codes.PermissionDenied},
+		{name: "admin can set segments", token: "Bearer a", call: setSegment},
+		{name: "scoped editor in scope", token: "Bearer p", call: set("payments/f")},
+		{name: "scoped editor out of scope", token: "Bearer p", call: set("f"), want: codes.PermissionDenied},
+		{name: "scoped editor can read everything", token: "Bearer p", call: get},
+		{name: "scoped apply in scope", token: "Bearer p", call: apply("payments/a", "payments/b")},
+		{name: "scoped apply out of scope", token: "Bearer p", call: apply("payments/a", "b"), want: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ctx
+			if tt.token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, AuthorizationMetadataKey, tt.token)
+			}
+
+			if err := tt.call(ctx); status.Code(err) != tt.want {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Errors convert back to the package's errors.
+	err = set("f")(metadata.AppendToOutgoingContext(ctx, AuthorizationMetadataKey, "Bearer r"))
+	if !IsError(err, ErrPermissionDenied) {
+		t.Errorf("SetFeature as reader error = %v, want ErrPermissionDenied", err)
+	}
+
+	if err := get(ctx); !IsError(err, ErrUnauthenticated) {
+		t.Errorf("GetFeatures without token error = %v, want ErrUnauthenticated", err)
+	}
+}
+
+func TestAuthenticatorActor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a, err := NewAuthenticator([]*Credential{{Name: "ci", Token: "t", Role: RoleEditor}})
+	if err != nil {
+		t.Fatalf("NewAuthenticator error = %v", err)
+	}
+
+	s := NewStore()
+	client := newTestClient(t, s, a.ServerOptions()...)
+
+	// The authenticated caller is recorded, not the actor it claims to be.
+	ctx = metadata.AppendToOutgoingContext(ctx, AuthorizationMetadataKey, "Bearer t", ActorMetadataKey, "mallory")
+
+	if _, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_CONSTANT},
+	}); err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	resp, err := client.ListFeatureHistory(ctx, &featurepb.ListFeatureHistoryRequest{Name: "f"})
+	if err != nil {
+		t.Fatalf("ListFeatureHistory error = %v", err)
+	}
+
+	if got := resp.Revisions[0].Actor; got != "ci" {
+		t.Errorf("revision actor = %q, want ci", got)
+	}
+}
+
+func TestLoadAuthenticator(t *testing.T) {
+	hash := sha256.Sum256([]byte("s3cret"))
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			file: `{"credentials": [
+				{"name": "a", "token": "t", "role": "reader"},
+				{"name": "b", "token_sha256": "` + hex.EncodeToString(hash[:]) + `", "role": "admin", "prefixes": ["x/"]}
+			]}`,
+		},
+		{
+			name:    "unknown role",
+			file:    `{"credentials": [{"name": "a", "token": "t", "role": "owner"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "no role",
+			file:    `{"credentials": [{"name": "a", "token": "t"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "no token",
+			file:    `{"credentials": [{"name": "a", "role": "reader"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "both tokens",
+			file:    `{"credentials": [{"name": "a", "token": "t", "token_sha256": "` + hex.EncodeToString(hash[:]) + `", "role": "reader"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed hash",
+			file:    `{"credentials": [{"name": "a", "token_sha256": "abc", "role": "reader"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "duplicate token",
+			file:    `{"credentials": [{"name": "a", "token": "t", "role": "reader"}, {"name": "b", "token": "t", "role": "admin"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "credentials.json")
+			if err := ioutil.WriteFile(path, []byte(tt.file), 0600); err != nil {
+				t.Fatalf("WriteFile error = %v", err)
+			}
+
+			_, err := LoadAuthenticator(path)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Errorf("LoadAuthenticator error = %v, want ErrInvalidCredentials", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("LoadAuthenticator error = %v", err)
+			}
+		})
+	}
+
+	// A hashed token authenticates with the token itself.
+	a, err := NewAuthenticator([]*Credential{{Name: "b", TokenSHA256: hex.EncodeToString(hash[:]), Role: RoleReader}})
+	if err != nil {
+		t.Fatalf("NewAuthenticator error = %v", err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationMetadataKey, "Bearer s3cret"))
+	if id, err := a.authenticate(ctx); err != nil || id.Name != "b" {
+		t.Errorf("authenticate() = %v, %v; want b", id, err)
+	}
+}
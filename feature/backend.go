@@ -0,0 +1,192 @@
+package feature
+
+import (
+	"fmt"
+	"sync"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// Backend persists the features and segments held by a Store. The Store keeps
+// its own in-memory copy for evaluation, so a Backend is only read once, when
+// the Store is opened, and is then written on every change.
+type Backend interface {
+	// Load returns all persisted features and segments.
+	Load() (*State, error)
+	// Apply persists the given mutations. Either all of the mutations are
+	// persisted, or (if an error is returned) none of them are.
+	Apply(mutations ...*Mutation) error
+	// Close releases any resources held by the backend.
+	Close() error
+}
+
+// selfWriter is implemented by backends that write to files which may also be
+// watched by Watch, so that the watcher can ignore the backend's own writes.
+type selfWriter interface {
+	wrote(path string, data []byte) bool
+}
+
+// State is the full set of features, segments, feature history, pending
+// changes and scheduled changes persisted by a Backend.
+type State struct {
+	Features map[string]*featurepb.Feature
+	Segments map[string]*featurepb.Segment
+	// History holds the revisions of each feature, oldest first.
+	History map[string][]*featurepb.FeatureRevision
+	// PendingChanges holds the changes to protected features awaiting
+	// approval, keyed by ID.
+	PendingChanges map[string]*featurepb.PendingChange
+	// Schedules holds the scheduled changes still to be made, keyed by ID.
+	Schedules map[string]*featurepb.ScheduledChange
+}
+
+func newState() *State {
+	return &State{
+		Features:       map[string]*featurepb.Feature{},
+		Segments:       map[string]*featurepb.Segment{},
+		History:        map[string][]*featurepb.FeatureRevision{},
+		PendingChanges: map[string]*featurepb.PendingChange{},
+		Schedules:      map[string]*featurepb.ScheduledChange{},
+	}
+}
+
+// apply applies the mutations to the state.
+func (s *State) apply(mutations ...*Mutation) error {
+	for _, m := range mutations {
+		switch m.Type {
+		case SetFeatureMutation:
+			s.Features[m.Feature.Name] = m.Feature
+		case DeleteFeatureMutation:
+			delete(s.Features, m.Name)
+		case SetSegmentMutation:
+			s.Segments[m.Segment.Name] = m.Segment
+		case DeleteSegmentMutation:
+			delete(s.Segments, m.Name)
+		case AppendRevisionMutation:
+			// Versions only increase, so a revision no newer than the last one
+			// has already been appended. Skipping it keeps replaying a log on
+			// top of a snapshot that includes it idempotent.
+			history := s.History[m.Revision.Name]
+			if len(history) > 0 && m.Revision.Version <= history[len(history)-1].Version {
+				continue
+			}
+
+			// The slice may be shared with a clone, so always copy on append.
+			s.History[m.Revision.Name] = append(history[:len(history):len(history)], m.Revision)
+		case SetPendingChangeMutation:
+			s.PendingChanges[m.PendingChange.Id] = m.PendingChange
+		case DeletePendingChangeMutation:
+			delete(s.PendingChanges, m.Name)
+		case SetScheduleMutation:
+			s.Schedules[m.Schedule.Id] = m.Schedule
+		case DeleteScheduleMutation:
+			delete(s.Schedules, m.Name)
+		default:
+			return fmt.Errorf("unknown mutation type %d", m.Type)
+		}
+	}
+
+	return nil
+}
+
+// clone returns a shallow copy of the state. The maps are copied, but the
+// protobuf messages they hold are shared.
+func (s *State) clone() *State {
+	c := &State{
+		Features:       make(map[string]*featurepb.Feature, len(s.Features)),
+		Segments:       make(map[string]*featurepb.Segment, len(s.Segments)),
+		History:        make(map[string][]*featurepb.FeatureRevision, len(s.History)),
+		PendingChanges: make(map[string]*featurepb.PendingChange, len(s.PendingChanges)),
+		Schedules:      make(map[string]*featurepb.ScheduledChange, len(s.Schedules)),
+	}
+
+	for name, f := range s.Features {
+		c.Features[name] = f
+	}
+
+	for name, seg := range s.Segments {
+		c.Segments[name] = seg
+	}
+
+	for name, history := range s.History {
+		c.History[name] = history
+	}
+
+	for id, pc := range s.PendingChanges {
+		c.PendingChanges[id] = pc
+	}
+
+	for id, sc := range s.Schedules {
+		c.Schedules[id] = sc
+	}
+
+	return c
+}
+
+// MutationType is the kind of change described by a Mutation.
+type MutationType int
+
+const (
+	SetFeatureMutation MutationType = iota + 1
+	DeleteFeatureMutation
+	SetSegmentMutation
+	DeleteSegmentMutation
+	AppendRevisionMutation
+	SetPendingChangeMutation
+	DeletePendingChangeMutation
+	SetScheduleMutation
+	DeleteScheduleMutation
+)
+
+// Mutation is a single change to be persisted by a Backend. Feature is set for
+// SetFeatureMutation, Segment is set for SetSegmentMutation, Revision is set
+// for AppendRevisionMutation, PendingChange is set for
+// SetPendingChangeMutation, Schedule is set for SetScheduleMutation, and Name
+// (the ID, for a pending or scheduled change) is set for the delete mutations.
+type Mutation struct {
+	Type          MutationType
+	Feature       *featurepb.Feature
+	Segment       *featurepb.Segment
+	Revision      *featurepb.FeatureRevision
+	PendingChange *featurepb.PendingChange
+	Schedule      *featurepb.ScheduledChange
+	Name          string
+}
+
+// memoryBackend is a Backend that persists nothing beyond the lifetime of the
+// process.
+type memoryBackend struct {
+	m     sync.Mutex
+	state *State
+}
+
+// NewMemoryBackend returns a Backend that keeps state in memory only. It is
+// the backend used by NewStore.
+func NewMemoryBackend() Backend {
+	return &memoryBackend{state: newState()}
+}
+
+// Load is part of the Backend interface.
+func (b *memoryBackend) Load() (*State, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.state.clone(), nil
+}
+
+// Apply is part of the Backend interface.
+func (b *memoryBackend) Apply(mutations ...*Mutation) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	state := b.state.clone()
+	if err := state.apply(mutations...); err != nil {
+		return err
+	}
+
+	b.state = state
+	return nil
+}
+
+// Close is part of the Backend interface.
+func (b *memoryBackend) Close() error { return nil }
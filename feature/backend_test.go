@@ -0,0 +1,228 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestFileBackend(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	open := func() *Store {
+		t.Helper()
+
+		b, err := NewFileBackend(path)
+		if err != nil {
+			t.Fatalf("NewFileBackend error = %v", err)
+		}
+
+		s, err := OpenStore(b)
+		if err != nil {
+			t.Fatalf("OpenStore error = %v", err)
+		}
+
+		return s
+	}
+
+	s := open()
+
+	if _, err := s.SetSegment(ctx, &featurepb.SetSegmentRequest{
+		Segment: &featurepb.Segment{Name: "staff", Key: "user_id", Included: []string{"1"}},
+	}); err != nil {
+		t.Fatalf("SetSegment error = %v", err)
+	}
+
+	for _, f := range []*featurepb.Feature{
+		{Name: "staff_only", Type: featurepb.Feature_EXPRESSION, Expression: "[segment:staff]"},
+		{Name: "doomed", Type: featurepb.Feature_CONSTANT, Enabled: true},
+	} {
+		if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{Feature: f}); err != nil {
+			t.Fatalf("SetFeature(%s) error = %v", f.Name, err)
+		}
+	}
+
+	if _, err := s.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "doomed"}); err != nil {
+		t.Fatalf("DeleteFeature error = %v", err)
+	}
+
+	s.Close()
+
+	// Reopening the store, as if after a restart, recovers the changes.
+	s = open()
+	defer s.Close()
+
+	if on, err := s.Get("staff_only", map[string]interface{}{"user_id": 1}); err != nil || !on {
+		t.Errorf("Get(staff_only) after reopen = %v, %v; want true", on, err)
+	}
+
+	if _, err := s.Get("doomed", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(doomed) after reopen error = %v, want ErrNoFeature", err)
+	}
+
+	resp, err := s.GetFeature(ctx, &featurepb.GetFeatureRequest{Name: "staff_only"})
+	if err != nil || resp.Feature.Version != 1 {
+		t.Errorf("GetFeature(staff_only) after reopen = %v, %v; want version 1", resp, err)
+	}
+}
+
+// durableBackends are the backends that persist state across restarts, each
+// opened in a given directory.
+var durableBackends = []struct {
+	name string
+	open func(t *testing.T, dir string) Backend
+}{
+	{
+		name: "file",
+		open: func(t *testing.T, dir string) Backend {
+			b, err := NewFileBackend(filepath.Join(dir, "state.json"))
+			if err != nil {
+				t.Fatalf("NewFileBackend error = %v", err)
+			}
+
+			return b
+		},
+	},
+	{
+		name: "log",
+		open: func(t *testing.T, dir string) Backend {
+			b, err := NewLogBackend(dir)
+			if err != nil {
+				t.Fatalf("NewLogBackend error = %v", err)
+			}
+
+			return b
+		},
+	},
+}
+
+// failingBackend is a Backend whose writes always fail.
+type failingBackend struct{ Backend }
+
+var errBackend = errors.New("backend unavailable")
+
+func (failingBackend) Apply(mutations ...*Mutation) error { return errBackend }
+
+func TestStoreBackendError(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := OpenStore(failingBackend{NewMemoryBackend()})
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT},
+	}); !errors.Is(err, errBackend) {
+		t.Errorf("SetFeature error = %v, want %v", err, errBackend)
+	}
+
+	if _, err := s.Get("a", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(a) after failed SetFeature error = %v, want ErrNoFeature", err)
+	}
+
+	if _, err := s.SetSegment(ctx, &featurepb.SetSegmentRequest{
+		Segment: &featurepb.Segment{Name: "seg", Key: "k"},
+	}); !errors.Is(err, errBackend) {
+		t.Errorf("SetSegment error = %v, want %v", err, errBackend)
+	}
+}
+
+func TestConfigFileBackend(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "features.json")
+	writeConfig := func(config string) {
+		t.Helper()
+
+		if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+			t.Fatalf("WriteFile error = %v", err)
+		}
+	}
+
+	writeConfig(`{"a": {"type": "CONSTANT", "enabled": true}}`)
+
+	b, err := NewConfigFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewConfigFileBackend error = %v", err)
+	}
+
+	s, err := OpenStore(b)
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+	defer s.Close()
+
+	if err := s.Watch(ctx, path); err != nil {
+		t.Fatalf("Watch error = %v", err)
+	}
+
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "b", Type: featurepb.Feature_CONSTANT, Enabled: true},
+	}); err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	if !s.isOwnWrite(path) {
+		t.Error("isOwnWrite() = false after SetFeature")
+	}
+
+	// The config file is readable by a fresh store.
+	fresh := NewStore()
+	if err := fresh.InitFromFile(path); err != nil {
+		t.Fatalf("InitFromFile error = %v", err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		if on, err := fresh.Get(name, nil); err != nil || !on {
+			t.Errorf("fresh.Get(%s) = %v, %v; want true", name, on, err)
+		}
+	}
+
+	// The watcher ignores our own write, so the SET of b is the only event.
+	time.Sleep(100 * time.Millisecond)
+
+	s.m.RLock()
+	revision := s.events.revision
+	s.m.RUnlock()
+
+	if revision != 1 {
+		t.Errorf("revision after SetFeature = %d, want 1", revision)
+	}
+
+	// External edits are still picked up.
+	writeConfig(`{"a": {"type": "CONSTANT", "enabled": false}}`)
+
+	eventually(t, "external edit to be reloaded", func() bool {
+		_, err := s.Get("b", nil)
+		return errors.Is(err, ErrNoFeature)
+	})
+
+	// The reloaded config is written back in canonical form, which the watcher
+	// must not reload again.
+	time.Sleep(100 * time.Millisecond)
+
+	s.m.RLock()
+	revision = s.events.revision
+	s.m.RUnlock()
+
+	if revision != 3 {
+		t.Errorf("revision after external edit = %d, want 3 (SET a, DELETE b)", revision)
+	}
+
+	fresh = NewStore()
+	if err := fresh.InitFromFile(path); err != nil {
+		t.Fatalf("InitFromFile error = %v", err)
+	}
+
+	if on, err := fresh.Get("a", nil); err != nil || on {
+		t.Errorf("fresh.Get(a) after external edit = %v, %v; want false", on, err)
+	}
+}
@@ -0,0 +1,94 @@
+package feature
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// bucket deterministically maps the given salt and bucketing key value to an
+// integer in [0, 100). The same (salt, key) pair always maps to the same
+// bucket, so an entity enabled at percentage p remains enabled at any
+// percentage greater than p.
+func bucket(salt string, key interface{}) uint32 {
+	h := sha1.Sum([]byte(salt + "." + keyString(key)))
+	return uint32(binary.BigEndian.Uint64(h[:8]) % 100)
+}
+
+// keyString returns the string form of a bucketing or segment key value.
+//
+// Numbers are formatted the same way regardless of their Go type, so that a
+// key passed as an int in-process and one that arrives over RPC as a float64
+// (as all numbers in a protobuf Struct do) map to the same bucket, and match
+// the same segment members. Without this, 1234567 as a float64 would be
+// "1.234567e+06".
+func keyString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.FormatInt(int64(v), 10)
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return floatKeyString(float64(v))
+	case float64:
+		return floatKeyString(v)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// floatKeyString formats whole numbers without an exponent or fraction, so
+// they match the same value as an integer.
+func floatKeyString(f float64) string {
+	if f == math.Trunc(f) && !math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(f)
+}
+
+// bucketForParameters returns the bucket for the given parameters, based on
+// the feature's bucketing key and salt.
+//
+// If the feature has no bucketing key, a random bucket is returned, and ok is
+// true. If the feature has a bucketing key, but that key is not present in the
+// parameters, then ok is false, and the entity should not be considered part
+// of any bucket.
+func (f *Feature) bucketForParameters(parameters map[string]interface{}) (n uint32, ok bool) {
+	if f.BucketingKey == "" {
+		return uint32(rand.Intn(100)), true
+	}
+
+	key, ok := parameters[f.BucketingKey]
+	if !ok {
+		return 0, false
+	}
+
+	salt := f.Salt
+	if salt == "" {
+		salt = f.Name
+	}
+
+	return bucket(salt, key), true
+}
@@ -0,0 +1,146 @@
+package feature
+
+import (
+	"fmt"
+	"testing"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestBucketIsSticky(t *testing.T) {
+	f := &Feature{
+		Feature: &featurepb.Feature{
+			Name:         "sticky",
+			Type:         featurepb.Feature_PERCENTAGE_BASED,
+			Percentage:   30,
+			BucketingKey: "user_id",
+		},
+	}
+
+	for i := 0; i < 100; i++ {
+		params := map[string]interface{}{"user_id": i}
+
+		first, err := f.IsEnabledForParameters(params)
+		if err != nil {
+			t.Fatalf("IsEnabledForParameters(%v) error = %v", params, err)
+		}
+
+		for j := 0; j < 10; j++ {
+			again, _ := f.IsEnabledForParameters(params)
+			if again != first {
+				t.Fatalf("IsEnabledForParameters(%v) flipped from %v to %v", params, first, again)
+			}
+		}
+	}
+}
+
+func TestBucketRaisingPercentageOnlyAdds(t *testing.T) {
+	f := &Feature{
+		Feature: &featurepb.Feature{
+			Name:         "ramp",
+			Type:         featurepb.Feature_PERCENTAGE_BASED,
+			BucketingKey: "user_id",
+		},
+	}
+
+	enabled := map[string]bool{}
+
+	for p := uint32(0); p <= 100; p += 10 {
+		f.Percentage = p
+		count := 0
+
+		for i := 0; i < 1000; i++ {
+			id := fmt.Sprintf("user-%d", i)
+			on, err := f.IsEnabledForParameters(map[string]interface{}{"user_id": id})
+			if err != nil {
+				t.Fatalf("IsEnabledForParameters error = %v", err)
+			}
+
+			if enabled[id] && !on {
+				t.Errorf("%s was enabled below %d%% but disabled at %d%%", id, p, p)
+			}
+
+			if on {
+				enabled[id] = true
+				count++
+			}
+		}
+
+		switch p {
+		case 0:
+			if count != 0 {
+				t.Errorf("at 0%% got %d enabled, want 0", count)
+			}
+		case 100:
+			if count != 1000 {
+				t.Errorf("at 100%% got %d enabled, want 1000", count)
+			}
+		}
+	}
+}
+
+func TestBucketMissingKey(t *testing.T) {
+	f := &Feature{
+		Feature: &featurepb.Feature{
+			Name:         "missing",
+			Type:         featurepb.Feature_PERCENTAGE_BASED,
+			Percentage:   100,
+			BucketingKey: "user_id",
+		},
+	}
+
+	on, err := f.IsEnabledForParameters(map[string]interface{}{"org_id": 1})
+	if err != nil {
+		t.Fatalf("IsEnabledForParameters error = %v", err)
+	}
+
+	if on {
+		t.Errorf("IsEnabledForParameters without bucketing key = true, want false")
+	}
+}
+
+func TestBucketSalt(t *testing.T) {
+	a, b := bucket("a", "user-1"), bucket("a", "user-1")
+	if a != b {
+		t.Errorf("bucket(a, user-1) not deterministic: %d != %d", a, b)
+	}
+
+	differ := false
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		if bucket("a", key) != bucket("b", key) {
+			differ = true
+			break
+		}
+	}
+
+	if !differ {
+		t.Error("different salts produced identical buckets for 100 keys")
+	}
+}
+
+func TestKeyString(t *testing.T) {
+	tests := []struct {
+		key  interface{}
+		want string
+	}{
+		{key: "abc", want: "abc"},
+		{key: 1234567, want: "1234567"},
+		{key: int64(-42), want: "-42"},
+		{key: uint32(7), want: "7"},
+		{key: float64(1234567), want: "1234567"},
+		{key: float32(100), want: "100"},
+		{key: 1.5, want: "1.5"},
+		{key: true, want: "true"},
+	}
+
+	for _, tt := range tests {
+		if got := keyString(tt.key); got != tt.want {
+			t.Errorf("keyString(%#v) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	if bucket("salt", 1234567) != bucket("salt", float64(1234567)) {
+		t.Errorf("bucket differs for 1234567 as int and as float64")
+	}
+}
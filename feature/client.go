@@ -0,0 +1,402 @@
+package feature
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+const (
+	clientMinBackoff             = 100 * time.Millisecond
+	clientMaxBackoff             = 30 * time.Second
+	clientSegmentRefreshInterval = 30 * time.Second
+	clientSegmentRefreshTimeout  = 10 * time.Second
+)
+
+// Client evaluates features locally, using an in-memory copy of all features
+// held by a remote Features server. The copy is kept up to date in the
+// background via the WatchFeatures RPC. Segments are refreshed via GetSegments
+// periodically, and whenever a feature references a segment the Client does
+// not have.
+//
+// If the server becomes unreachable, the Client continues to serve the
+// last-known-good features, and reconnects with exponential backoff.
+type Client struct {
+	client featurepb.FeaturesClient
+
+	m        sync.RWMutex
+	features map[string]*Feature
+	segments map[string]*Segment
+
+	// segmentsRequested counts requests to refresh segments, and
+	// segmentsRefreshed is the value it had when the last successful refresh
+	// started. snapshotRequest is the request made on receiving the first
+	// snapshot of features; the Client is ready once segments have been
+	// refreshed since then. segmentsWanted wakes syncSegments; requests made
+	// while one is already pending are coalesced.
+	segmentsRequested uint64
+	segmentsRefreshed uint64
+	snapshotRequest   uint64
+	segmentsWanted    chan struct{}
+
+	// epoch and revision are where the last WatchFeatures stream left off,
+	// for the next one to resume from. They are only used by syncFeatures.
+	epoch    string
+	revision uint64
+
+	ready     chan struct{}
+	readyOnce sync.Once
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewClient returns a Client that syncs features from the given server in the
+// background until the context is cancelled or Close is called. Callers that
+// need features to be loaded before proceeding should call WaitUntilReady.
+func NewClient(ctx context.Context, client featurepb.FeaturesClient) *Client {
+	ctx, cancel := context.WithCancel(ctx)
+
+	c := &Client{
+		client:         client,
+		features:       map[string]*Feature{},
+		segments:       map[string]*Segment{},
+		segmentsWanted: make(chan struct{}, 1),
+		ready:          make(chan struct{}),
+		cancel:         cancel,
+	}
+
+	c.wg.Add(2)
+	go c.syncFeatures(ctx)
+	go c.syncSegments(ctx)
+
+	return c
+}
+
+// Close stops the background sync. The Client continues to serve the features
+// it last synced.
+func (c *Client) Close() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+// Ready returns whether the Client has completed its first sync of both
+// features and segments with the server.
+func (c *Client) Ready() bool {
+	select {
+	case <-c.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// WaitUntilReady blocks until the Client has completed its first sync of both
+// features and segments with the server, or until the context is done.
+func (c *Client) WaitUntilReady(ctx context.Context) error {
+	select {
+	case <-c.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Get returns whether a feature is enabled or not.
+func (c *Client) Get(name string, parameters map[string]interface{}) (bool, error) {
+	feat, err := c.getFeature(name)
+	if err != nil {
+		return false, err
+	}
+
+	return feat.IsEnabledForParameters(parameters)
+}
+
+// GetVariant returns the name of the variant assigned for the given
+// parameters.
+func (c *Client) GetVariant(name string, parameters map[string]interface{}) (string, error) {
+	feat, err := c.getFeature(name)
+	if err != nil {
+		return "", err
+	}
+
+	return feat.Variant(parameters)
+}
+
+// GetDetail returns the result of evaluating a feature, along with the reason
+// for it. See the package-level GetDetail.
+func (c *Client) GetDetail(name string, parameters map[string]interface{}) *Detail {
+	feat, err := c.getFeature(name)
+	if err != nil {
+		return &Detail{
+			Name:      name,
+			Reason:    featurepb.EvaluationDetail_FEATURE_NOT_FOUND,
+			Bucket:    -1,
+			RuleIndex: -1,
+			Err:       err,
+		}
+	}
+
+	return feat.Detail(parameters)
+}
+
+func (c *Client) getFeature(name string) (*Feature, error) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	if feat, ok := c.features[name]; ok {
+		return feat, nil
+	}
+
+	return nil, fmt.Errorf("%w with name %s", ErrNoFeature, name)
+}
+
+// getSegment allows the Client to act as a segmentSource for the features it
+// evaluates.
+func (c *Client) getSegment(name string) (*Segment, error) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	if seg, ok := c.segments[name]; ok {
+		return seg, nil
+	}
+
+	return nil, fmt.Errorf("%w with name %s", ErrNoSegment, name)
+}
+
+// syncFeatures watches the server for feature changes, reconnecting with
+// exponential backoff, until the context is done.
+func (c *Client) syncFeatures(ctx context.Context) {
+	defer c.wg.Done()
+
+	backoff := clientMinBackoff
+
+	for {
+		synced, err := c.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if synced {
+			backoff = clientMinBackoff
+		}
+
+		log.Printf("[client] feature watch ended, retrying in %v: %v", backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > clientMaxBackoff {
+			backoff = clientMaxBackoff
+		}
+	}
+}
+
+// watch runs a single WatchFeatures stream, applying the snapshot and events
+// to the local copy of features. It returns whether it received anything,
+// along with the error that ended the stream.
+//
+// Streams resume from where the last one left off. If the server has since
+// restarted, or no longer has the events we missed, it sends a snapshot
+// instead.
+func (c *Client) watch(ctx context.Context) (synced bool, err error) {
+	stream, err := c.client.WatchFeatures(ctx, &featurepb.WatchFeaturesRequest{
+		FromRevision: c.revision,
+		Epoch:        c.epoch,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return synced, fmt.Errorf("stream closed by server")
+		}
+
+		if err != nil {
+			return synced, err
+		}
+
+		switch {
+		case resp.Snapshot != nil:
+			c.applySnapshot(resp.Snapshot)
+			c.epoch, c.revision = resp.Snapshot.Epoch, resp.Snapshot.Revision
+			synced = true
+		case resp.Event != nil:
+			c.applyEvent(resp.Event)
+			c.revision = resp.Event.Revision
+			synced = true
+		}
+	}
+}
+
+func (c *Client) applySnapshot(snapshot *featurepb.FeatureSnapshot) {
+	features := make(map[string]*Feature, len(snapshot.Features))
+	for _, fpb := range snapshot.Features {
+		features[fpb.Name] = c.newFeature(fpb)
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.features = features
+
+	// Segments may have changed while the stream was down (or, on the first
+	// snapshot, while the first refresh was in flight), so always refresh
+	// them.
+	if c.snapshotRequest == 0 {
+		c.snapshotRequest = c.requestSegmentsLocked()
+	} else {
+		c.requestSegmentsLocked()
+	}
+}
+
+// newFeature wraps a feature received from the server, resolving segments
+// from the Client. Expressions are parsed up front, so that concurrent
+// evaluations do not race to parse them.
+func (c *Client) newFeature(fpb *featurepb.Feature) *Feature {
+	f := &Feature{Feature: fpb, segments: c}
+	if err := f.parse(); err != nil {
+		log.Printf("[client] feature %s: %v", f.Name, err)
+	}
+
+	return f
+}
+
+func (c *Client) applyEvent(event *featurepb.FeatureEvent) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	switch event.Type {
+	case featurepb.FeatureEvent_SET:
+		f := c.newFeature(event.Feature)
+		c.features[f.Name] = f
+
+		// Segments must exist before features can reference them, so a
+		// refresh will find any that are new.
+		if c.hasMissingSegmentsLocked(f) {
+			c.requestSegmentsLocked()
+		}
+	case featurepb.FeatureEvent_DELETE:
+		delete(c.features, event.Feature.Name)
+	}
+}
+
+// hasMissingSegmentsLocked returns whether f references any segment the
+// Client does not have. Callers must hold c.m.
+func (c *Client) hasMissingSegmentsLocked(f *Feature) bool {
+	refs, _ := f.SegmentRefs()
+	for _, name := range refs {
+		if _, ok := c.segments[name]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requestSegmentsLocked asks syncSegments to refresh segments now, returning
+// the number of the request. Callers must hold c.m.
+func (c *Client) requestSegmentsLocked() uint64 {
+	c.segmentsRequested++
+
+	select {
+	case c.segmentsWanted <- struct{}{}:
+	default:
+	}
+
+	return c.segmentsRequested
+}
+
+// markReadyLocked marks the Client ready once it has a snapshot of features,
+// and has refreshed segments since. Callers must hold c.m.
+func (c *Client) markReadyLocked() {
+	if c.snapshotRequest > 0 && c.segmentsRefreshed >= c.snapshotRequest {
+		c.readyOnce.Do(func() { close(c.ready) })
+	}
+}
+
+// syncSegments refreshes the local copy of segments periodically, and on
+// request, until the context is done. Failed refreshes keep the
+// last-known-good segments, and are retried with exponential backoff.
+func (c *Client) syncSegments(ctx context.Context) {
+	defer c.wg.Done()
+
+	backoff := clientMinBackoff
+
+	for {
+		wait := clientSegmentRefreshInterval
+
+		err := c.refreshSegments(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			log.Printf("[client] error refreshing segments, retrying in %v: %v", backoff, err)
+
+			wait = backoff
+			backoff *= 2
+			if backoff > clientMaxBackoff {
+				backoff = clientMaxBackoff
+			}
+		default:
+			backoff = clientMinBackoff
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-c.segmentsWanted:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// refreshSegments fetches all segments from the server, replacing the local
+// copy.
+func (c *Client) refreshSegments(ctx context.Context) error {
+	c.m.RLock()
+	request := c.segmentsRequested
+	c.m.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, clientSegmentRefreshTimeout)
+	defer cancel()
+
+	resp, err := c.client.GetSegments(ctx, &featurepb.GetSegmentsRequest{})
+	if err != nil {
+		return err
+	}
+
+	segments := make(map[string]*Segment, len(resp.Segments))
+	for _, spb := range resp.Segments {
+		seg := &Segment{Segment: spb}
+		if err := seg.parseRules(); err != nil {
+			log.Printf("[client] segment %s: %v", spb.Name, err)
+		}
+
+		segments[spb.Name] = seg
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.segments = segments
+	c.segmentsRefreshed = request
+	c.markReadyLocked()
+
+	return nil
+}
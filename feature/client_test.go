@@ -0,0 +1,159 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// eventually polls cond until it returns true, failing the test after a
+// timeout.
+func eventually(t *testing.T, msg string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", msg)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := NewStore()
+	if _, err := s.SetSegment(ctx, &featurepb.SetSegmentRequest{
+		Segment: &featurepb.Segment{Name: "staff", Key: "user_id", Included: []string{"1"}},
+	}); err != nil {
+		t.Fatalf("SetSegment error = %v", err)
+	}
+
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "staff_only", Type: featurepb.Feature_EXPRESSION, Expression: "[segment:staff]"},
+	}); err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	s.Register(gs)
+	go gs.Serve(lis)
+
+	cc, err := grpc.Dial("bufconn",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+	if err != nil {
+		t.Fatalf("grpc.Dial error = %v", err)
+	}
+	defer cc.Close()
+
+	c := NewClient(ctx, featurepb.NewFeaturesClient(cc))
+	defer c.Close()
+
+	if err := c.WaitUntilReady(ctx); err != nil {
+		t.Fatalf("WaitUntilReady error = %v", err)
+	}
+
+	if !c.Ready() {
+		t.Error("Ready() = false after WaitUntilReady")
+	}
+
+	on, err := c.Get("staff_only", map[string]interface{}{"user_id": 1})
+	if err != nil || !on {
+		t.Errorf("Get(staff_only, 1) = %v, %v; want true", on, err)
+	}
+
+	on, err = c.Get("staff_only", map[string]interface{}{"user_id": 2})
+	if err != nil || on {
+		t.Errorf("Get(staff_only, 2) = %v, %v; want false", on, err)
+	}
+
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "new", Type: featurepb.Feature_CONSTANT, Enabled: true},
+	}); err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	eventually(t, "new feature to sync", func() bool {
+		on, err := c.Get("new", nil)
+		return err == nil && on
+	})
+
+	// A segment created after startup is fetched as soon as a feature
+	// references it, rather than at the next periodic refresh.
+	if _, err := s.SetSegment(ctx, &featurepb.SetSegmentRequest{
+		Segment: &featurepb.Segment{Name: "beta", Key: "user_id", Included: []string{"2"}},
+	}); err != nil {
+		t.Fatalf("SetSegment error = %v", err)
+	}
+
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "beta_only", Type: featurepb.Feature_EXPRESSION, Expression: "[segment:beta]"},
+	}); err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	eventually(t, "beta_only to sync", func() bool {
+		on, err := c.Get("beta_only", map[string]interface{}{"user_id": 2})
+		return err == nil && on
+	})
+
+	// With the server gone, the client keeps serving last-known-good values.
+	gs.Stop()
+
+	on, err = c.Get("new", nil)
+	if err != nil || !on {
+		t.Errorf("Get(new) after server stopped = %v, %v; want true", on, err)
+	}
+
+	if _, err := c.Get("missing", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(missing) error = %v, want ErrNoFeature", err)
+	}
+}
+
+func TestClientNotReady(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lis := bufconn.Listen(1 << 20)
+	lis.Close()
+
+	cc, err := grpc.Dial("bufconn",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+	if err != nil {
+		t.Fatalf("grpc.Dial error = %v", err)
+	}
+	defer cc.Close()
+
+	c := NewClient(ctx, featurepb.NewFeaturesClient(cc))
+	defer c.Close()
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer waitCancel()
+
+	if err := c.WaitUntilReady(waitCtx); err == nil {
+		t.Error("WaitUntilReady() succeeded with no server")
+	}
+
+	if c.Ready() {
+		t.Error("Ready() = true with no server")
+	}
+}
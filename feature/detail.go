@@ -0,0 +1,208 @@
+package feature
+
+import (
+	"encoding/json"
+	"fmt"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// Detail is the result of evaluating a feature, along with an explanation of
+// why it resolved the way it did.
+type Detail struct {
+	Name    string
+	Enabled bool
+	// Variant is the assigned variant, for VARIANT features and RULES features
+	// whose outcome names a variant.
+	Variant string
+	// Value is the feature's value, as returned by ValueForParameters, or nil
+	// if the feature has no value for the parameters.
+	Value  interface{}
+	Reason featurepb.EvaluationDetail_Reason
+	// Bucket is the entity's bucket in [0, 100), or -1 if the evaluation did
+	// not bucket the entity.
+	Bucket int
+	// RuleIndex is the index of the matching rule for RULE_MATCH, or -1.
+	RuleIndex int
+	// Version is the version of the feature that was evaluated.
+	Version uint64
+	// Err is the error that caused the feature to resolve to the default, for
+	// ERROR and FEATURE_NOT_FOUND.
+	Err error
+}
+
+// Detail evaluates the feature for the given parameters, and returns the
+// result along with the reason for it. Evaluation errors are reported in the
+// returned Detail, with a reason of ERROR, rather than returned directly.
+func (f *Feature) Detail(parameters map[string]interface{}) *Detail {
+	d := &Detail{
+		Name:      f.Name,
+		Bucket:    -1,
+		RuleIndex: -1,
+		Version:   f.Version,
+	}
+
+	switch f.Type {
+	case featurepb.Feature_CONSTANT:
+		d.Reason = featurepb.EvaluationDetail_CONSTANT
+		d.Enabled = f.Enabled
+	case featurepb.Feature_PERCENTAGE_BASED:
+		d.Reason = featurepb.EvaluationDetail_PERCENTAGE
+		if n, ok := f.bucketForParameters(parameters); ok {
+			d.Bucket = int(n)
+			d.Enabled = n < f.Percentage
+		}
+	case featurepb.Feature_EXPRESSION:
+		d.Reason = featurepb.EvaluationDetail_EXPRESSION
+
+		result, err := f.evaluateExpression(parameters)
+		if err != nil {
+			return d.error(err)
+		}
+
+		v, ok := result.(bool)
+		if !ok {
+			// The expression computes the feature's value directly, and the
+			// feature is enabled if the value is truthy.
+			d.Enabled = truthy(result)
+			if d.Enabled {
+				d.Value = result
+			}
+
+			return d
+		}
+
+		d.Enabled = v
+	case featurepb.Feature_VARIANT:
+		d.Reason = featurepb.EvaluationDetail_VARIANT
+		if n, ok := f.bucketForParameters(parameters); ok {
+			d.Bucket = int(n)
+			d.Variant = f.variantForBucket(n)
+			d.Enabled = d.Variant != ""
+		}
+	case featurepb.Feature_RULES:
+		o, i, err := f.matchOutcome(parameters)
+		if err != nil {
+			return d.error(err)
+		}
+
+		d.Reason = featurepb.EvaluationDetail_FALLTHROUGH
+		if i >= 0 {
+			d.Reason = featurepb.EvaluationDetail_RULE_MATCH
+			d.RuleIndex = i
+		}
+
+		switch kind := o.GetKind().(type) {
+		case *featurepb.Outcome_Enabled:
+			d.Enabled = kind.Enabled
+		case *featurepb.Outcome_Percentage:
+			if n, ok := f.bucketForParameters(parameters); ok {
+				d.Bucket = int(n)
+				d.Enabled = n < kind.Percentage
+			}
+		case *featurepb.Outcome_Variant:
+			d.Enabled = true
+			d.Variant = kind.Variant
+		}
+
+		if d.Enabled && o.GetValue().GetKind() != nil {
+			d.Value = valueOf(o.Value)
+		}
+	default:
+		return d.error(fmt.Errorf("%w %v for %s", ErrUnknownFeatureType, f.Type, f.Name))
+	}
+
+	if d.Enabled && d.Value == nil && f.Value.GetKind() != nil {
+		d.Value = valueOf(f.Value)
+	}
+
+	return d
+}
+
+// error resets the detail to the default result, with a reason of ERROR.
+func (d *Detail) error(err error) *Detail {
+	d.Enabled = false
+	d.Variant = ""
+	d.Value = nil
+	d.Reason = featurepb.EvaluationDetail_ERROR
+	d.Err = err
+
+	return d
+}
+
+// Proto converts the detail into an EvaluationDetail protobuf message.
+func (d *Detail) Proto() *featurepb.EvaluationDetail {
+	pb := &featurepb.EvaluationDetail{
+		Name:      d.Name,
+		Enabled:   d.Enabled,
+		Variant:   d.Variant,
+		Value:     toValue(d.Value),
+		Reason:    d.Reason,
+		Bucket:    int32(d.Bucket),
+		RuleIndex: int32(d.RuleIndex),
+		Version:   d.Version,
+	}
+
+	if d.Err != nil {
+		pb.Error = d.Err.Error()
+	}
+
+	return pb
+}
+
+// toValue converts a native Go value, as returned by ValueForParameters, into a
+// Value message. Values of any other type (e.g. the result of an expression)
+// are JSON-encoded.
+func toValue(v interface{}) *featurepb.Value {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return &featurepb.Value{Kind: &featurepb.Value_StringValue{StringValue: v}}
+	case int64:
+		return &featurepb.Value{Kind: &featurepb.Value_IntValue{IntValue: v}}
+	case float64:
+		return &featurepb.Value{Kind: &featurepb.Value_FloatValue{FloatValue: v}}
+	case json.RawMessage:
+		return &featurepb.Value{Kind: &featurepb.Value_JsonValue{JsonValue: string(v)}}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	return &featurepb.Value{Kind: &featurepb.Value_JsonValue{JsonValue: string(data)}}
+}
+
+// GetDetail returns whether a feature is enabled, along with its variant,
+// value, and the reason for the result. It never returns nil; if the feature
+// does not exist, the reason is FEATURE_NOT_FOUND.
+func GetDetail(name string, parameters map[string]interface{}) *Detail {
+	return defaultStore.GetDetail(name, parameters)
+}
+
+// GetDetail is like the package-level GetDetail, but evaluates a feature in
+// the store.
+func (s *Store) GetDetail(name string, parameters map[string]interface{}) *Detail {
+	return s.evaluate(name, parameters)
+}
+
+// truthy returns whether the non-bool result of an expression enables its
+// feature: it does unless the result is nil, zero, or the empty string.
+func truthy(result interface{}) bool {
+	switch v := result.(type) {
+	case nil:
+		return false
+	case float64:
+		return v != 0
+	case int:
+		return v != 0
+	case int64:
+		return v != 0
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
@@ -0,0 +1,336 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestDetail(t *testing.T) {
+	tests := []struct {
+		name    string
+		feature *featurepb.Feature
+		params  map[string]interface{}
+		enabled bool
+		reason  featurepb.EvaluationDetail_Reason
+		bucket  int
+		rule    int
+		err     bool
+	}{
+		{
+			name:    "constant",
+			feature: &featurepb.Feature{Type: featurepb.Feature_CONSTANT, Enabled: true},
+			enabled: true,
+			reason:  featurepb.EvaluationDetail_CONSTANT,
+			bucket:  -1,
+			rule:    -1,
+		},
+		{
+			name:    "percentage",
+			feature: &featurepb.Feature{Type: featurepb.Feature_PERCENTAGE_BASED, Percentage: 100, BucketingKey: "id", Salt: "s"},
+			params:  map[string]interface{}{"id": 1},
+			enabled: true,
+			reason:  featurepb.EvaluationDetail_PERCENTAGE,
+			bucket:  int(bucket("s", 1)),
+			rule:    -1,
+		},
+		{
+			name:    "expression",
+			feature: &featurepb.Feature{Type: featurepb.Feature_EXPRESSION, Expression: "x > 1"},
+			params:  map[string]interface{}{"x": 0},
+			enabled: false,
+			reason:  featurepb.EvaluationDetail_EXPRESSION,
+			bucket:  -1,
+			rule:    -1,
+		},
+		{
+			name:    "expression error",
+			feature: &featurepb.Feature{Type: featurepb.Feature_EXPRESSION, Expression: "x > 1"},
+			enabled: false,
+			reason:  featurepb.EvaluationDetail_ERROR,
+			bucket:  -1,
+			rule:    -1,
+			err:     true,
+		},
+		{
+			name: "rule match",
+			feature: &featurepb.Feature{
+				Type: featurepb.Feature_RULES,
+				Rules: []*featurepb.Rule{
+					{Expression: "x > 10", Outcome: &featurepb.Outcome{Kind: &featurepb.Outcome_Enabled{Enabled: false}}},
+					{Expression: "x > 1", Outcome: &featurepb.Outcome{Kind: &featurepb.Outcome_Enabled{Enabled: true}}},
+				},
+			},
+			params:  map[string]interface{}{"x": 5},
+			enabled: true,
+			reason:  featurepb.EvaluationDetail_RULE_MATCH,
+			bucket:  -1,
+			rule:    1,
+		},
+		{
+			name: "fallthrough",
+			feature: &featurepb.Feature{
+				Type: featurepb.Feature_RULES,
+				Rules: []*featurepb.Rule{
+					{Expression: "x > 10", Outcome: &featurepb.Outcome{Kind: &featurepb.Outcome_Enabled{Enabled: true}}},
+				},
+			},
+			params:  map[string]interface{}{"x": 5},
+			enabled: false,
+			reason:  featurepb.EvaluationDetail_FALLTHROUGH,
+			bucket:  -1,
+			rule:    -1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.feature.Name = tt.name
+			f := &Feature{Feature: tt.feature}
+
+			d := f.Detail(tt.params)
+			if d.Enabled != tt.enabled || d.Reason != tt.reason || d.Bucket != tt.bucket || d.RuleIndex != tt.rule || (d.Err != nil) != tt.err {
+				t.Errorf("Detail() = %+v; want enabled=%v reason=%v bucket=%d rule=%d err=%v", d, tt.enabled, tt.reason, tt.bucket, tt.rule, tt.err)
+			}
+		})
+	}
+}
+
+func TestDetailExpressionValue(t *testing.T) {
+	// An expression that doesn't return a bool is enabled if its result is
+	// truthy, and the result is then the feature's value.
+	tests := []struct {
+		expression string
+		params     map[string]interface{}
+		enabled    bool
+		value      interface{}
+	}{
+		{expression: "0", enabled: false},
+		{expression: "x * 2", params: map[string]interface{}{"x": 0}, enabled: false},
+		{expression: "x * 2", params: map[string]interface{}{"x": 2}, enabled: true, value: float64(4)},
+		{expression: "''", enabled: false},
+		{expression: "'a'", enabled: true, value: "a"},
+		{expression: "x", params: map[string]interface{}{"x": nil}, enabled: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.expression, func(t *testing.T) {
+			f := &Feature{Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_EXPRESSION, Expression: tt.expression}}
+
+			d := f.Detail(tt.params)
+			if d.Err != nil || d.Enabled != tt.enabled || d.Value != tt.value {
+				t.Errorf("Detail(%v) = %+v; want enabled=%v value=%v", tt.params, d, tt.enabled, tt.value)
+			}
+
+			if on, err := f.IsEnabledForParameters(tt.params); err != nil || on != tt.enabled {
+				t.Errorf("IsEnabledForParameters(%v) = %v, %v; want %v", tt.params, on, err, tt.enabled)
+			}
+		})
+	}
+}
+
+func TestEvaluateFeature(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	for i := 0; i < 2; i++ {
+		_, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+			Feature: &featurepb.Feature{
+				Name:       "eval",
+				Type:       featurepb.Feature_EXPRESSION,
+				Expression: "country == 'US'",
+			},
+		})
+		if err != nil {
+			t.Fatalf("SetFeature error = %v", err)
+		}
+	}
+
+	params, err := structpb.NewStruct(map[string]interface{}{"country": "US"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.EvaluateFeature(ctx, &featurepb.EvaluateFeatureRequest{Name: "eval", Parameters: params})
+	if err != nil {
+		t.Fatalf("EvaluateFeature error = %v", err)
+	}
+
+	if d := resp.Detail; !d.Enabled || d.Reason != featurepb.EvaluationDetail_EXPRESSION || d.Version != 2 {
+		t.Errorf("EvaluateFeature(eval) = %+v; want enabled, EXPRESSION, version 2", d)
+	}
+
+	resp, err = s.EvaluateFeature(ctx, &featurepb.EvaluateFeatureRequest{Name: "missing"})
+	if err != nil {
+		t.Fatalf("EvaluateFeature error = %v", err)
+	}
+
+	if d := resp.Detail; d.Enabled || d.Reason != featurepb.EvaluationDetail_FEATURE_NOT_FOUND || d.Error == "" {
+		t.Errorf("EvaluateFeature(missing) = %+v; want FEATURE_NOT_FOUND", d)
+	}
+
+	if d := s.evaluate("missing", nil); !errors.Is(d.Err, ErrNoFeature) {
+		t.Errorf("evaluate(missing) error = %v, want ErrNoFeature", d.Err)
+	}
+}
+
+func TestEvaluateFeatures(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	s.features = map[string]*Feature{
+		"b": {Feature: &featurepb.Feature{Name: "b", Type: featurepb.Feature_CONSTANT, Enabled: true, Version: 1}},
+		"a": {Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_EXPRESSION, Expression: "n >= 3", Version: 1}},
+	}
+
+	params, err := structpb.NewStruct(map[string]interface{}{"n": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.EvaluateFeatures(ctx, &featurepb.EvaluateFeaturesRequest{
+		Names:      []string{"b", "missing", "a"},
+		Parameters: params,
+	})
+	if err != nil {
+		t.Fatalf("EvaluateFeatures error = %v", err)
+	}
+
+	want := []struct {
+		name    string
+		enabled bool
+		reason  featurepb.EvaluationDetail_Reason
+	}{
+		{name: "b", enabled: true, reason: featurepb.EvaluationDetail_CONSTANT},
+		{name: "missing", enabled: false, reason: featurepb.EvaluationDetail_FEATURE_NOT_FOUND},
+		{name: "a", enabled: true, reason: featurepb.EvaluationDetail_EXPRESSION},
+	}
+
+	if len(resp.Details) != len(want) {
+		t.Fatalf("EvaluateFeatures returned %d details, want %d", len(resp.Details), len(want))
+	}
+
+	for i, w := range want {
+		d := resp.Details[i]
+		if d.Name != w.name || d.Enabled != w.enabled || d.Reason != w.reason {
+			t.Errorf("details[%d] = %+v; want %+v", i, d, w)
+		}
+	}
+
+	resp, err = s.EvaluateFeatures(ctx, &featurepb.EvaluateFeaturesRequest{})
+	if err != nil {
+		t.Fatalf("EvaluateFeatures error = %v", err)
+	}
+
+	if len(resp.Details) != 2 || resp.Details[0].Name != "a" || resp.Details[1].Name != "b" {
+		t.Errorf("EvaluateFeatures(all) = %v; want [a b]", resp.Details)
+	}
+}
+
+func TestEvaluateFeatureMatchesLocal(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	_, err := s.SetSegment(ctx, &featurepb.SetSegmentRequest{
+		Segment: &featurepb.Segment{Name: "beta", Key: "user_id", Included: []string{"1234567"}},
+	})
+	if err != nil {
+		t.Fatalf("SetSegment error = %v", err)
+	}
+
+	for _, f := range []*featurepb.Feature{
+		{Name: "ramp", Type: featurepb.Feature_PERCENTAGE_BASED, Percentage: 50, BucketingKey: "user_id"},
+		{Name: "beta_only", Type: featurepb.Feature_EXPRESSION, Expression: "[segment:beta]"},
+	} {
+		if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{Feature: f}); err != nil {
+			t.Fatalf("SetFeature(%s) error = %v", f.Name, err)
+		}
+	}
+
+	client := newTestClient(t, s)
+
+	for _, id := range []int{0, 7, 1234567, 9876543210, 1 << 40} {
+		params, err := structpb.NewStruct(map[string]interface{}{"user_id": id})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := client.EvaluateFeatures(ctx, &featurepb.EvaluateFeaturesRequest{Parameters: params})
+		if err != nil {
+			t.Fatalf("EvaluateFeatures error = %v", err)
+		}
+
+		for _, remote := range resp.Details {
+			local := s.GetDetail(remote.Name, map[string]interface{}{"user_id": id}).Proto()
+			if remote.Enabled != local.Enabled || remote.Bucket != local.Bucket || remote.Reason != local.Reason {
+				t.Errorf("user_id=%d: EvaluateFeatures(%s) = %+v, but GetDetail = %+v", id, remote.Name, remote, local)
+			}
+		}
+	}
+
+	if d := s.GetDetail("beta_only", map[string]interface{}{"user_id": float64(1234567)}); !d.Enabled {
+		t.Errorf("GetDetail(beta_only, user_id=1234567.0) = %+v; want enabled by segment", d)
+	}
+}
+
+func TestGetMatchesGetDetail(t *testing.T) {
+	s := NewStore()
+	s.features = map[string]*Feature{}
+
+	for _, f := range []*featurepb.Feature{
+		{Name: "constant", Type: featurepb.Feature_CONSTANT, Enabled: true},
+		{Name: "percentage", Type: featurepb.Feature_PERCENTAGE_BASED, Percentage: 50, BucketingKey: "id"},
+		{Name: "expression", Type: featurepb.Feature_EXPRESSION, Expression: "x > 1"},
+		{Name: "expression_value", Type: featurepb.Feature_EXPRESSION, Expression: "x > 1 ? 'a' : 'b'"},
+		{Name: "expression_error", Type: featurepb.Feature_EXPRESSION, Expression: "missing > 1"},
+		{
+			Name: "variant",
+			Type: featurepb.Feature_VARIANT,
+			Variants: []*featurepb.Feature_Variant{
+				{Name: "a", Weight: 50},
+				{Name: "b", Weight: 50},
+			},
+			BucketingKey: "id",
+		},
+		{
+			Name: "rules",
+			Type: featurepb.Feature_RULES,
+			Rules: []*featurepb.Rule{
+				{Expression: "x > 10", Outcome: &featurepb.Outcome{Kind: &featurepb.Outcome_Enabled{Enabled: false}}},
+				{Expression: "x > 1", Outcome: &featurepb.Outcome{Kind: &featurepb.Outcome_Percentage{Percentage: 50}}},
+			},
+			Fallthrough:  &featurepb.Outcome{Kind: &featurepb.Outcome_Variant{Variant: "a"}},
+			BucketingKey: "id",
+		},
+		{Name: "unknown", Type: featurepb.Feature_Type(100)},
+	} {
+		s.features[f.Name] = &Feature{Feature: f, segments: s}
+	}
+
+	params := []map[string]interface{}{
+		nil,
+		{"x": 0, "id": 1},
+		{"x": 5, "id": 2},
+		{"x": 20, "id": 3},
+	}
+
+	for name := range s.features {
+		for _, p := range params {
+			enabled, err := s.Get(name, p)
+			d := s.GetDetail(name, p)
+
+			if enabled != d.Enabled || (err != nil) != (d.Err != nil) {
+				t.Errorf("%s with %v: Get() = %v, %v; GetDetail() = %+v", name, p, enabled, err, d)
+			}
+		}
+	}
+
+	if enabled, err := s.Get("expression_value", map[string]interface{}{"x": 0}); err != nil || !enabled {
+		t.Errorf("Get(expression_value) = %v, %v; want enabled, since a non-empty string result is the feature's value", enabled, err)
+	}
+}
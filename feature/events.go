@@ -0,0 +1,143 @@
+package feature
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+const (
+	// eventLogSize is the number of past events the server retains for
+	// resuming WatchFeatures streams.
+	eventLogSize = 1024
+	// subscriberBufferSize is the number of events that may be queued for a
+	// WatchFeatures stream before it is considered lagging, and must be
+	// resynced with a snapshot.
+	subscriberBufferSize = 64
+)
+
+// eventLog records changes to the server's features, and fans them out to
+// WatchFeatures subscribers. It is not safe for concurrent use; callers must
+// hold the server's lock.
+type eventLog struct {
+	// epoch identifies this log, so that clients resuming from a revision can
+	// tell whether it was from this log, or e.g. from before a restart.
+	epoch       string
+	revision    uint64
+	events      []*featurepb.FeatureEvent
+	subscribers map[*subscriber]struct{}
+}
+
+// subscriber receives events on ch. If the subscriber falls behind, ch is
+// closed, and the subscriber must resubscribe.
+type subscriber struct {
+	ch chan *featurepb.FeatureEvent
+}
+
+func newEventLog() *eventLog {
+	return &eventLog{
+		epoch:       newEpoch(),
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+// newEpoch returns a random ID for an event log.
+func newEpoch() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		// The time is still unique across restarts.
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+
+	return hex.EncodeToString(buf)
+}
+
+// publish assigns the next revision to an event for the given feature, records
+// it, and sends it to all subscribers.
+func (l *eventLog) publish(typ featurepb.FeatureEvent_Type, f *featurepb.Feature) {
+	l.revision++
+
+	event := &featurepb.FeatureEvent{
+		Type:     typ,
+		Revision: l.revision,
+		Feature:  f,
+	}
+
+	l.events = append(l.events, event)
+	if len(l.events) > eventLogSize {
+		l.events = l.events[len(l.events)-eventLogSize:]
+	}
+
+	for sub := range l.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			// Lagging subscriber. Drop it, and let it catch up from the log
+			// (or a snapshot) when it resubscribes.
+			close(sub.ch)
+			delete(l.subscribers, sub)
+		}
+	}
+}
+
+// since returns all events after the given revision of the given epoch. If ok
+// is false, the log no longer contains all of those events, or the revision is
+// from another epoch (e.g. from before a server restart), and the caller needs
+// a snapshot.
+func (l *eventLog) since(epoch string, revision uint64) (events []*featurepb.FeatureEvent, ok bool) {
+	if epoch != l.epoch || revision > l.revision {
+		return nil, false
+	}
+
+	if revision == l.revision {
+		return nil, true
+	}
+
+	if len(l.events) == 0 || l.events[0].Revision > revision+1 {
+		return nil, false
+	}
+
+	i := int(revision + 1 - l.events[0].Revision)
+	return append([]*featurepb.FeatureEvent(nil), l.events[i:]...), true
+}
+
+func (l *eventLog) subscribe() *subscriber {
+	sub := &subscriber{
+		ch: make(chan *featurepb.FeatureEvent, subscriberBufferSize),
+	}
+
+	l.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (l *eventLog) unsubscribe(sub *subscriber) {
+	if _, ok := l.subscribers[sub]; ok {
+		delete(l.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// publishDiff publishes SET events for every feature in after that is new or
+// changed relative to before, and DELETE events for every feature in before
+// that is not in after. It is used when the entire feature set is replaced,
+// e.g. by InitFromFile.
+func (l *eventLog) publishDiff(before, after map[string]*Feature) {
+	for name, feat := range before {
+		if _, ok := after[name]; !ok {
+			l.publish(featurepb.FeatureEvent_DELETE, feat.Feature)
+		}
+	}
+
+	for name, feat := range after {
+		if old, ok := before[name]; ok && proto.Equal(old.Feature, feat.Feature) {
+			continue
+		}
+
+		l.publish(featurepb.FeatureEvent_SET, feat.Feature)
+	}
+}
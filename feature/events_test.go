@@ -0,0 +1,213 @@
+package feature
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// newTestClient serves s over an in-memory listener, and returns a client
+// connected to it.
+func newTestClient(t *testing.T, s *Store, opts ...grpc.ServerOption) featurepb.FeaturesClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer(opts...)
+	s.Register(gs)
+
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	cc, err := grpc.Dial("bufconn",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+	if err != nil {
+		t.Fatalf("grpc.Dial error = %v", err)
+	}
+	t.Cleanup(func() { cc.Close() })
+
+	return featurepb.NewFeaturesClient(cc)
+}
+
+func recvWatch(t *testing.T, stream featurepb.Features_WatchFeaturesClient) *featurepb.WatchFeaturesResponse {
+	t.Helper()
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+
+	return resp
+}
+
+func TestWatchFeatures(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := NewStore()
+	client := newTestClient(t, s)
+
+	if _, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT, Enabled: true},
+	}); err != nil {
+		t.Fatalf("SetFeature(a) error = %v", err)
+	}
+
+	stream, err := client.WatchFeatures(ctx, &featurepb.WatchFeaturesRequest{})
+	if err != nil {
+		t.Fatalf("WatchFeatures error = %v", err)
+	}
+
+	resp := recvWatch(t, stream)
+	if resp.Snapshot == nil || resp.Snapshot.Revision != 1 || len(resp.Snapshot.Features) != 1 || resp.Snapshot.Epoch == "" {
+		t.Fatalf("first response = %v, want snapshot at revision 1 with 1 feature", resp)
+	}
+
+	epoch := resp.Snapshot.Epoch
+
+	if _, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "b", Type: featurepb.Feature_CONSTANT},
+	}); err != nil {
+		t.Fatalf("SetFeature(b) error = %v", err)
+	}
+
+	if _, err := client.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "a"}); err != nil {
+		t.Fatalf("DeleteFeature(a) error = %v", err)
+	}
+
+	resp = recvWatch(t, stream)
+	if e := resp.Event; e == nil || e.Type != featurepb.FeatureEvent_SET || e.Revision != 2 || e.Feature.Name != "b" {
+		t.Errorf("second response = %v, want SET b at revision 2", resp)
+	}
+
+	resp = recvWatch(t, stream)
+	if e := resp.Event; e == nil || e.Type != featurepb.FeatureEvent_DELETE || e.Revision != 3 || e.Feature.Name != "a" {
+		t.Errorf("third response = %v, want DELETE a at revision 3", resp)
+	}
+
+	// Resuming from a known revision replays only the missed events.
+	resumed, err := client.WatchFeatures(ctx, &featurepb.WatchFeaturesRequest{FromRevision: 2, Epoch: epoch})
+	if err != nil {
+		t.Fatalf("WatchFeatures(from 2) error = %v", err)
+	}
+
+	resp = recvWatch(t, resumed)
+	if e := resp.Event; resp.Snapshot != nil || e == nil || e.Revision != 3 {
+		t.Errorf("resumed response = %v, want event at revision 3", resp)
+	}
+
+	// Resuming from an unknown revision (e.g. from before a server restart)
+	// falls back to a snapshot.
+	future, err := client.WatchFeatures(ctx, &featurepb.WatchFeaturesRequest{FromRevision: 100, Epoch: epoch})
+	if err != nil {
+		t.Fatalf("WatchFeatures(from 100) error = %v", err)
+	}
+
+	resp = recvWatch(t, future)
+	if resp.Snapshot == nil || resp.Snapshot.Revision != 3 || len(resp.Snapshot.Features) != 1 {
+		t.Errorf("future response = %v, want snapshot at revision 3 with 1 feature", resp)
+	}
+}
+
+func TestWatchFeaturesAfterRestart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	backend := NewMemoryBackend()
+
+	s, err := OpenStore(backend)
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+
+	set := func(client featurepb.FeaturesClient, name string) {
+		t.Helper()
+
+		if _, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+			Feature: &featurepb.Feature{Name: name, Type: featurepb.Feature_CONSTANT},
+		}); err != nil {
+			t.Fatalf("SetFeature(%s) error = %v", name, err)
+		}
+	}
+
+	client := newTestClient(t, s)
+	set(client, "a")
+
+	stream, err := client.WatchFeatures(ctx, &featurepb.WatchFeaturesRequest{})
+	if err != nil {
+		t.Fatalf("WatchFeatures error = %v", err)
+	}
+
+	before := recvWatch(t, stream).Snapshot
+
+	// After a restart, revisions start again from 1. Once the new server has
+	// passed the client's revision, resuming must not replay its events on
+	// top of the old history.
+	s, err = OpenStore(backend)
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+
+	client = newTestClient(t, s)
+	set(client, "b")
+	set(client, "c")
+
+	resumed, err := client.WatchFeatures(ctx, &featurepb.WatchFeaturesRequest{
+		FromRevision: before.Revision,
+		Epoch:        before.Epoch,
+	})
+	if err != nil {
+		t.Fatalf("WatchFeatures error = %v", err)
+	}
+
+	resp := recvWatch(t, resumed)
+	if resp.Snapshot == nil || resp.Snapshot.Epoch == before.Epoch || len(resp.Snapshot.Features) != 3 {
+		t.Errorf("resumed response = %v, want snapshot of a new epoch with 3 features", resp)
+	}
+}
+
+func TestEventLog(t *testing.T) {
+	l := newEventLog()
+	sub := l.subscribe()
+
+	for i := 0; i < eventLogSize+10; i++ {
+		l.publish(featurepb.FeatureEvent_SET, &featurepb.Feature{Name: "f"})
+	}
+
+	if _, ok := l.since(l.epoch, 5); ok {
+		t.Error("since(5) ok = true after log truncation, want false")
+	}
+
+	events, ok := l.since(l.epoch, l.revision-3)
+	if !ok || len(events) != 3 || events[0].Revision != l.revision-2 {
+		t.Errorf("since(revision-3) = %v, %v; want the last 3 events", events, ok)
+	}
+
+	if _, ok := l.since("other", l.revision-3); ok {
+		t.Error("since(other epoch) ok = true, want false")
+	}
+
+	// The subscriber never read, so it should have been dropped once its
+	// buffer filled.
+	n := 0
+	for range sub.ch {
+		n++
+	}
+
+	if n != subscriberBufferSize {
+		t.Errorf("lagging subscriber received %d events before being dropped, want %d", n, subscriberBufferSize)
+	}
+
+	if len(l.subscribers) != 0 {
+		t.Errorf("lagging subscriber was not removed")
+	}
+}
@@ -3,8 +3,6 @@ package feature
 import (
 	"bytes"
 	"errors"
-	"fmt"
-	"math/rand"
 
 	"github.com/Knetic/govaluate"
 	"github.com/golang/protobuf/jsonpb"
@@ -21,7 +19,12 @@ var (
 // Feature wraps an underlying Feature protobuf message.
 type Feature struct {
 	*featurepb.Feature
-	expr *govaluate.EvaluableExpression
+	expr  *govaluate.EvaluableExpression
+	rules []*govaluate.EvaluableExpression
+
+	// segments is used to resolve segment references in expressions. If nil,
+	// the default store's segments are used.
+	segments segmentSource
 }
 
 // IsEnabled returns whether the given feature is enabled. It returns an error
@@ -33,57 +36,29 @@ func (f *Feature) IsEnabled() (bool, error) {
 	return f.IsEnabledForParameters(nil)
 }
 
-// IsEnabled returns whether the given feature is enabled for the given
-// parameters. It returns an error either if the feature has an unknown type,
-// or if it is an EXPRESSION feature and an error was encountered during
-// expression evaluation.
+// IsEnabledForParameters returns whether the given feature is enabled for
+// the given parameters. It returns an error either if the feature has an
+// unknown type, or if it is an EXPRESSION or RULES feature and an error was
+// encountered during expression evaluation.
+//
+// PERCENTAGE_BASED features with a BucketingKey are enabled based on a hash of
+// that parameter's value, so a given entity is consistently enabled or
+// disabled. If the parameter is missing, the feature is disabled.
+//
+// EXPRESSION features whose expression evaluates to something other than a
+// bool are enabled unless the result is nil, zero, or the empty string, and
+// then the result is their value (see ValueForParameters).
+//
+// VARIANT features are enabled if the parameters are assigned any variant. Use
+// Variant to get the name of the assigned variant.
+//
+// RULES features evaluate each rule in order, and use the outcome of the first
+// matching rule, or the fallthrough outcome if no rule matches.
+//
+// This is the same evaluation as Detail, without the explanation.
 func (f *Feature) IsEnabledForParameters(parameters map[string]interface{}) (bool, error) {
-	switch f.Type {
-	case featurepb.Feature_CONSTANT:
-		return f.Enabled, nil
-	case featurepb.Feature_PERCENTAGE_BASED:
-		n := rand.Intn(100)
-		return uint32(n) < f.Percentage, nil
-	case featurepb.Feature_EXPRESSION:
-		if err := f.parseExpression(); err != nil {
-			return false, err
-		}
-
-		result, err := f.expr.Evaluate(parameters)
-		if err != nil {
-			return false, err
-		}
-
-		v, ok := result.(bool)
-		if !ok {
-			return false, fmt.Errorf("expression %v did not return a bool: %v", f.expr, result)
-		}
-
-		return v, nil
-	}
-
-	return false, fmt.Errorf("%w %v for %s", ErrUnknownFeatureType, f.Type, f.Name)
-}
-
-func (f *Feature) Validate() (bool, error) {
-	switch f.Type {
-	case featurepb.Feature_CONSTANT:
-		return true, nil
-	case featurepb.Feature_PERCENTAGE_BASED:
-		if f.Percentage < 0 || f.Percentage > 100 {
-			return false, fmt.Errorf("%w: percentage must be in [0, 100] (got %d)", ErrInvalidFeature, f.Percentage)
-		}
-
-		return true, nil
-	case featurepb.Feature_EXPRESSION:
-		if f.Expression == "" {
-			return false, fmt.Errorf("%w: expression cannot be empty", ErrInvalidFeature)
-		}
-
-		return true, nil
-	}
-
-	return false, fmt.Errorf("%w: %v", ErrUnknownFeatureType, f.Type)
+	d := f.Detail(parameters)
+	return d.Enabled, d.Err
 }
 
 // MarshalJSON implements json.Marshaler for Feature. It marshals only the
@@ -103,8 +78,8 @@ func (f *Feature) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements json.Unmarshaler for Feature. It unmarshals the
-// underlying protobuf message, and, if the Feature is an EXPRESSION type,
-// parses the expression string as well.
+// underlying protobuf message, and, if the Feature is an EXPRESSION or RULES
+// type, parses the expression strings as well.
 func (f *Feature) UnmarshalJSON(data []byte) error {
 	if f.Feature == nil {
 		f.Feature = &featurepb.Feature{}
@@ -115,13 +90,32 @@ func (f *Feature) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if f.Type == featurepb.Feature_EXPRESSION {
+	return f.parse()
+}
+
+// parse parses the feature's expression strings, if it is an EXPRESSION or
+// RULES type.
+func (f *Feature) parse() error {
+	switch f.Type {
+	case featurepb.Feature_EXPRESSION:
 		return f.parseExpression()
+	case featurepb.Feature_RULES:
+		return f.parseRules()
 	}
 
 	return nil
 }
 
+// evaluateExpression parses (if needed) and evaluates the feature's expression
+// against the given parameters, returning the raw result.
+func (f *Feature) evaluateExpression(parameters map[string]interface{}) (interface{}, error) {
+	if err := f.parseExpression(); err != nil {
+		return nil, err
+	}
+
+	return f.expr.Eval(f.parameters(parameters))
+}
+
 // parseExpression parses the feature's expression string. The resulting
 // expression is reused, so parseExpression will be a no-op on subsequent calls.
 //
@@ -136,9 +130,14 @@ func (f *Feature) parseExpression() (err error) {
 	return err
 }
 
-// Get returns whether a feature is enabled or not.
+// Get returns whether a feature in the default store is enabled or not.
 func Get(name string, parameters map[string]interface{}) (bool, error) {
-	feat, err := inst.getFeature(name)
+	return defaultStore.Get(name, parameters)
+}
+
+// Get returns whether a feature is enabled or not.
+func (s *Store) Get(name string, parameters map[string]interface{}) (bool, error) {
+	feat, err := s.getFeature(name)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,362 @@
+package feature
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/jsonpb"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// FileBackend is a Backend that persists the full state as a single JSON file,
+// rewriting it atomically on every change. It is suitable for small numbers of
+// features that change infrequently.
+type FileBackend struct {
+	path    string
+	marshal func(*State) ([]byte, error)
+
+	m     sync.Mutex
+	state *State
+	// written is the checksum of the last contents written to path. See
+	// wrote.
+	written [sha256.Size]byte
+}
+
+var _ Backend = (*FileBackend)(nil)
+
+// stateFile is the on-disk format of a FileBackend. Features, segments, and
+// feature histories are keyed by name, and pending changes by ID, with each
+// value being the jsonpb encoding of the message(s).
+type stateFile struct {
+	Features       map[string]json.RawMessage   `json:"features"`
+	Segments       map[string]json.RawMessage   `json:"segments"`
+	History        map[string][]json.RawMessage `json:"history,omitempty"`
+	PendingChanges map[string]json.RawMessage   `json:"pending_changes,omitempty"`
+	Schedules      map[string]json.RawMessage   `json:"schedules,omitempty"`
+}
+
+// NewFileBackend returns a FileBackend persisting features and segments to the
+// given path. The file is created on the first write if it does not exist.
+func NewFileBackend(path string) (*FileBackend, error) {
+	return newFileBackend(path, marshalState, unmarshalState)
+}
+
+// NewConfigFileBackend returns a FileBackend that persists features to the
+// given config file, in the same format read by InitFromFile. This allows
+// changes made through the API to be written back to the config file, rather
+// than being clobbered by the next edit to it.
+//
+// Config reloads (see Watch) are written back through the backend too, so an
+// externally edited config file is rewritten in canonical form. The config
+// format has no place for segments or feature history, so they are kept in
+// memory only.
+func NewConfigFileBackend(path string) (*FileBackend, error) {
+	return newFileBackend(path, marshalConfig, unmarshalConfig)
+}
+
+func newFileBackend(path string, marshal func(*State) ([]byte, error), unmarshal func([]byte) (*State, error)) (*FileBackend, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &FileBackend{path: path, marshal: marshal, state: newState()}
+
+	data, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return b, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return b, nil
+	}
+
+	b.state, err = unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return b, nil
+}
+
+// Load is part of the Backend interface.
+func (b *FileBackend) Load() (*State, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.state.clone(), nil
+}
+
+// Apply is part of the Backend interface. The mutations are applied by
+// rewriting the whole file.
+func (b *FileBackend) Apply(mutations ...*Mutation) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	state := b.state.clone()
+	if err := state.apply(mutations...); err != nil {
+		return err
+	}
+
+	data, err := b.marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(b.path, data); err != nil {
+		return err
+	}
+
+	b.state = state
+	b.written = sha256.Sum256(data)
+	return nil
+}
+
+// wrote returns whether data is what the backend last wrote to path. It allows
+// Watch to ignore the backend's own writes to a watched config file, rather
+// than reloading (and rewriting) it in a loop.
+func (b *FileBackend) wrote(path string, data []byte) bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return path == b.path && sha256.Sum256(data) == b.written
+}
+
+// Close is part of the Backend interface.
+func (b *FileBackend) Close() error { return nil }
+
+func marshalState(state *State) ([]byte, error) {
+	sf := stateFile{
+		Features: make(map[string]json.RawMessage, len(state.Features)),
+		Segments: make(map[string]json.RawMessage, len(state.Segments)),
+	}
+
+	m := jsonpb.Marshaler{}
+
+	for name, f := range state.Features {
+		s, err := m.MarshalToString(f)
+		if err != nil {
+			return nil, err
+		}
+
+		sf.Features[name] = json.RawMessage(s)
+	}
+
+	for name, seg := range state.Segments {
+		s, err := m.MarshalToString(seg)
+		if err != nil {
+			return nil, err
+		}
+
+		sf.Segments[name] = json.RawMessage(s)
+	}
+
+	if len(state.History) > 0 {
+		sf.History = make(map[string][]json.RawMessage, len(state.History))
+	}
+
+	for name, history := range state.History {
+		revs := make([]json.RawMessage, 0, len(history))
+		for _, rev := range history {
+			s, err := m.MarshalToString(rev)
+			if err != nil {
+				return nil, err
+			}
+
+			revs = append(revs, json.RawMessage(s))
+		}
+
+		sf.History[name] = revs
+	}
+
+	if len(state.PendingChanges) > 0 {
+		sf.PendingChanges = make(map[string]json.RawMessage, len(state.PendingChanges))
+	}
+
+	for id, pc := range state.PendingChanges {
+		s, err := m.MarshalToString(pc)
+		if err != nil {
+			return nil, err
+		}
+
+		sf.PendingChanges[id] = json.RawMessage(s)
+	}
+
+	if len(state.Schedules) > 0 {
+		sf.Schedules = make(map[string]json.RawMessage, len(state.Schedules))
+	}
+
+	for id, sc := range state.Schedules {
+		s, err := m.MarshalToString(sc)
+		if err != nil {
+			return nil, err
+		}
+
+		sf.Schedules[id] = json.RawMessage(s)
+	}
+
+	return json.MarshalIndent(sf, "", "    ")
+}
+
+func unmarshalState(data []byte) (*State, error) {
+	var sf stateFile
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return nil, err
+	}
+
+	var (
+		state = newState()
+		u     = jsonpb.Unmarshaler{}
+	)
+
+	for name, data := range sf.Features {
+		f := &featurepb.Feature{}
+		if err := u.Unmarshal(bytes.NewReader(data), f); err != nil {
+			return nil, err
+		}
+
+		f.Name = name
+		state.Features[name] = f
+	}
+
+	for name, data := range sf.Segments {
+		seg := &featurepb.Segment{}
+		if err := u.Unmarshal(bytes.NewReader(data), seg); err != nil {
+			return nil, err
+		}
+
+		seg.Name = name
+		state.Segments[name] = seg
+	}
+
+	for name, revs := range sf.History {
+		history := make([]*featurepb.FeatureRevision, 0, len(revs))
+		for _, data := range revs {
+			rev := &featurepb.FeatureRevision{}
+			if err := u.Unmarshal(bytes.NewReader(data), rev); err != nil {
+				return nil, err
+			}
+
+			history = append(history, rev)
+		}
+
+		state.History[name] = history
+	}
+
+	for id, data := range sf.PendingChanges {
+		pc := &featurepb.PendingChange{}
+		if err := u.Unmarshal(bytes.NewReader(data), pc); err != nil {
+			return nil, err
+		}
+
+		pc.Id = id
+		state.PendingChanges[id] = pc
+	}
+
+	for id, data := range sf.Schedules {
+		sc := &featurepb.ScheduledChange{}
+		if err := u.Unmarshal(bytes.NewReader(data), sc); err != nil {
+			return nil, err
+		}
+
+		sc.Id = id
+		state.Schedules[id] = sc
+	}
+
+	return state, nil
+}
+
+// marshalConfig marshals the state's features as a config file (see
+// InitFromFile).
+func marshalConfig(state *State) ([]byte, error) {
+	m := make(map[string]*Feature, len(state.Features))
+	for name, f := range state.Features {
+		m[name] = &Feature{Feature: f}
+	}
+
+	return json.MarshalIndent(m, "", "    ")
+}
+
+func unmarshalConfig(data []byte) (*State, error) {
+	m, err := UnmarshalConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	state := newState()
+	for name, f := range m {
+		if f == nil {
+			return nil, fmt.Errorf("feature %s is empty", name)
+		}
+
+		f.Name = name
+		state.Features[name] = f.Feature
+	}
+
+	return state, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory as
+// path, syncs it, and renames it over path, so that readers (and crashes) only
+// ever observe the old or the new contents.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+
+	// Cleanup is a no-op once the rename succeeds.
+	defer os.Remove(tmp.Name())
+
+	// Preserve the permissions of the file being replaced.
+	if fi, err := os.Stat(path); err == nil {
+		if err := tmp.Chmod(fi.Mode()); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// syncDir fsyncs a directory, so that renames and file creations within it
+// are durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
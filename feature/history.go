@@ -0,0 +1,80 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var ErrNoRevision = errors.New("no such revision")
+
+// ListFeatureHistory is part of the featurepb.FeaturesServer interface. It
+// returns every revision of the named feature, including revisions from
+// before the feature was last deleted.
+func (s *Store) ListFeatureHistory(ctx context.Context, req *featurepb.ListFeatureHistoryRequest) (*featurepb.ListFeatureHistoryResponse, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	history, ok := s.history[req.Name]
+	if !ok {
+		return nil, rpcError(fmt.Errorf("%w with name %s", ErrNoFeature, req.Name))
+	}
+
+	revisions := make([]*featurepb.FeatureRevision, len(history))
+	copy(revisions, history)
+
+	return &featurepb.ListFeatureHistoryResponse{
+		Revisions: revisions,
+	}, nil
+}
+
+// RollbackFeature is part of the featurepb.FeaturesServer interface. It
+// restores the named feature to its state as of the given revision, as a new
+// revision. Rolling back to a revision that deleted the feature deletes it.
+//
+// The restored feature is validated as if it were set with SetFeature, so,
+// for example, a rollback fails if the feature referenced a segment that no
+// longer exists.
+func (s *Store) RollbackFeature(ctx context.Context, req *featurepb.RollbackFeatureRequest) (*featurepb.RollbackFeatureResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var target *featurepb.FeatureRevision
+	for _, rev := range s.history[req.Name] {
+		if rev.Version == req.Version {
+			target = rev
+			break
+		}
+	}
+
+	if target == nil {
+		return nil, rpcError(fmt.Errorf("%w: %s has no revision %d", ErrNoRevision, req.Name, req.Version))
+	}
+
+	c := callerFromContext(ctx, "RollbackFeature")
+
+	if target.After == nil {
+		before, pending, err := s.deleteFeatureLocked(c, req.Name)
+		if err != nil {
+			return nil, rpcError(err)
+		}
+
+		return &featurepb.RollbackFeatureResponse{
+			Before:  before,
+			Pending: pending,
+		}, nil
+	}
+
+	before, after, pending, err := s.setFeatureLocked(c, target.After)
+	if err != nil {
+		return nil, rpcError(fmt.Errorf("cannot roll back %s to revision %d: %w", req.Name, req.Version, err))
+	}
+
+	return &featurepb.RollbackFeatureResponse{
+		Before:  before,
+		After:   after,
+		Pending: pending,
+	}, nil
+}
@@ -0,0 +1,187 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestFeatureHistory(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ActorMetadataKey, "alice"))
+
+	s := NewStore()
+	s.now = func() time.Time { return time.Unix(1000, 0) }
+
+	set := func(enabled bool) {
+		t.Helper()
+
+		if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+			Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_CONSTANT, Enabled: enabled},
+		}); err != nil {
+			t.Fatalf("SetFeature error = %v", err)
+		}
+	}
+
+	set(true)
+	set(false)
+
+	if _, err := s.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "f"}); err != nil {
+		t.Fatalf("DeleteFeature error = %v", err)
+	}
+
+	// Re-creating a deleted feature continues its version sequence.
+	set(true)
+
+	resp, err := s.ListFeatureHistory(ctx, &featurepb.ListFeatureHistoryRequest{Name: "f"})
+	if err != nil {
+		t.Fatalf("ListFeatureHistory error = %v", err)
+	}
+
+	if len(resp.Revisions) != 4 {
+		t.Fatalf("ListFeatureHistory returned %d revisions, want 4", len(resp.Revisions))
+	}
+
+	for i, rev := range resp.Revisions {
+		if rev.Version != uint64(i+1) || rev.Actor != "alice" || rev.TimeUnixNano != time.Unix(1000, 0).UnixNano() {
+			t.Errorf("revision %d = %v, want version %d by alice at t=1000", i, rev, i+1)
+		}
+	}
+
+	if rev := resp.Revisions[0]; rev.Before != nil || !rev.After.Enabled {
+		t.Errorf("first revision = %v, want creation of enabled feature", rev)
+	}
+
+	if rev := resp.Revisions[2]; rev.Before == nil || rev.After != nil {
+		t.Errorf("third revision = %v, want deletion", rev)
+	}
+
+	if feat, _ := s.getFeature("f"); feat.Version != 4 {
+		t.Errorf("version after re-creation = %d, want 4", feat.Version)
+	}
+
+	if _, err := s.ListFeatureHistory(ctx, &featurepb.ListFeatureHistoryRequest{Name: "missing"}); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("ListFeatureHistory(missing) error = %v, want ErrNoFeature", err)
+	}
+}
+
+func TestRollbackFeature(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	for _, enabled := range []bool{true, false} {
+		if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+			Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_CONSTANT, Enabled: enabled},
+		}); err != nil {
+			t.Fatalf("SetFeature error = %v", err)
+		}
+	}
+
+	resp, err := s.RollbackFeature(ctx, &featurepb.RollbackFeatureRequest{Name: "f", Version: 1})
+	if err != nil {
+		t.Fatalf("RollbackFeature error = %v", err)
+	}
+
+	if resp.Before.Enabled || !resp.After.Enabled || resp.After.Version != 3 {
+		t.Errorf("RollbackFeature(1) = %v, want enabled feature at version 3", resp)
+	}
+
+	if on, err := s.Get("f", nil); err != nil || !on {
+		t.Errorf("Get(f) after rollback = %v, %v; want true", on, err)
+	}
+
+	if _, err := s.RollbackFeature(ctx, &featurepb.RollbackFeatureRequest{Name: "f", Version: 10}); !errors.Is(err, ErrNoRevision) {
+		t.Errorf("RollbackFeature(10) error = %v, want ErrNoRevision", err)
+	}
+
+	if _, err := s.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "f"}); err != nil {
+		t.Fatalf("DeleteFeature error = %v", err)
+	}
+
+	// Rolling back to before the deletion re-creates the feature.
+	if _, err := s.RollbackFeature(ctx, &featurepb.RollbackFeatureRequest{Name: "f", Version: 2}); err != nil {
+		t.Fatalf("RollbackFeature(2) error = %v", err)
+	}
+
+	if on, err := s.Get("f", nil); err != nil || on {
+		t.Errorf("Get(f) after rollback to 2 = %v, %v; want false", on, err)
+	}
+
+	// And rolling back to the deletion deletes it again.
+	if _, err := s.RollbackFeature(ctx, &featurepb.RollbackFeatureRequest{Name: "f", Version: 4}); err != nil {
+		t.Fatalf("RollbackFeature(4) error = %v", err)
+	}
+
+	if _, err := s.Get("f", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(f) after rollback to 4 error = %v, want ErrNoFeature", err)
+	}
+}
+
+func TestFeatureHistoryPersistence(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := OpenStore(openLogBackend(t, dir))
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+
+	s.Init(map[string]*Feature{
+		"f": {Feature: &featurepb.Feature{Type: featurepb.Feature_CONSTANT}},
+	})
+
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_CONSTANT, Enabled: true},
+	}); err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	s.Close()
+
+	s, err = OpenStore(openLogBackend(t, dir))
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+	defer s.Close()
+
+	resp, err := s.ListFeatureHistory(ctx, &featurepb.ListFeatureHistoryRequest{Name: "f"})
+	if err != nil {
+		t.Fatalf("ListFeatureHistory error = %v", err)
+	}
+
+	if len(resp.Revisions) != 2 || resp.Revisions[0].Actor != "init" || resp.Revisions[1].Version != 2 {
+		t.Errorf("history after reopen = %v, want 2 revisions, the first by init", resp.Revisions)
+	}
+
+	// The file backend round-trips history too.
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	fb, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileBackend error = %v", err)
+	}
+
+	fs, err := OpenStore(fb)
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+
+	fs.Init(map[string]*Feature{
+		"f": {Feature: &featurepb.Feature{Type: featurepb.Feature_CONSTANT}},
+	})
+
+	fb, err = NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileBackend error = %v", err)
+	}
+
+	state, _ := fb.Load()
+	if len(state.History["f"]) != 1 {
+		t.Errorf("file backend history = %v, want 1 revision", state.History)
+	}
+}
@@ -1,60 +1,213 @@
 package feature
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"log"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
 )
 
 var ErrEmptyConfig = errors.New("empty config file")
 
+// Init replaces the default store's features. See (*Store).Init.
 func Init(m map[string]*Feature) {
-	inst.m.Lock()
-	defer inst.m.Unlock()
-
-	initLocked(m)
+	defaultStore.Init(m)
 }
 
+// InitFromFile replaces the default store's features with those in the given
+// config file. See (*Store).InitFromFile.
 func InitFromFile(path string) error {
-	inst.m.Lock()
-	defer inst.m.Unlock()
+	return defaultStore.InitFromFile(path)
+}
 
-	data, err := ioutil.ReadFile(path)
+// Init replaces the store's features with the given ones, skipping (and
+// logging) any that are nil. If the new features cannot be persisted to the
+// store's backend, the error is logged and the store is left unchanged.
+func (s *Store) Init(m map[string]*Feature) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if err := s.initLocked(&caller{actor: "init", method: "init"}, m); err != nil {
+		log.Printf("[store] error persisting features: %v", err)
+	}
+}
+
+// InitFromFile reads the given json config file, validates every feature in
+// it as SetFeature would (including that the segments it refers to exist),
+// and replaces the store's features with them. If any feature is invalid,
+// or the features cannot be persisted, the store is left unchanged.
+func (s *Store) InitFromFile(path string) error {
+	return s.initFromFile(path, "init")
+}
+
+// initFromFile is InitFromFile, recording the changes as made by the given
+// method (see featurepb.AuditEntry).
+func (s *Store) initFromFile(path string, method string) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	m, err := readConfigFile(path, s.validationLocked())
 	if err != nil {
 		return err
 	}
 
+	return s.initLocked(&caller{actor: "config:" + path, method: method}, m)
+}
+
+// ReadConfigFile reads and validates the features in the given json config
+// file, as InitFromFile does (with the default naming policy), but without
+// loading them into a store. Since there is no store, the segments that
+// features refer to are not checked.
+func ReadConfigFile(path string) (map[string]*Feature, error) {
+	return readConfigFile(path, &validation{namePattern: DefaultNamePattern})
+}
+
+// readConfigFile reads the features in the given json config file, and
+// validates them with v.
+func readConfigFile(path string, v *validation) (map[string]*Feature, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(data) == 0 {
-		return fmt.Errorf("%w %s", ErrEmptyConfig, path)
+		return nil, fmt.Errorf("%w %s", ErrEmptyConfig, path)
 	}
 
-	var m map[string]*Feature
-	if err := json.Unmarshal(data, &m); err != nil {
-		return err
+	m, err := UnmarshalConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateFeatures(v, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// UnmarshalConfig unmarshals the contents of a config file: a JSON object of
+// features, keyed by name. The features are not validated (and their
+// expressions are not parsed); see ValidateFeatures.
+func UnmarshalConfig(data []byte) (map[string]*Feature, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
 	}
 
-	for name, f := range m {
-		f.Name = name
+	var (
+		m = make(map[string]*Feature, len(raw))
+		u = jsonpb.Unmarshaler{}
+	)
 
-		ok, err := f.Validate()
-		if err != nil {
-			return fmt.Errorf("feature %s: %w", f.Name, err)
+	for name, data := range raw {
+		if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+			m[name] = nil
+			continue
 		}
 
-		if !ok {
-			return fmt.Errorf("%w for %s", ErrInvalidFeature, err)
+		fpb := &featurepb.Feature{}
+		if err := u.Unmarshal(bytes.NewReader(data), fpb); err != nil {
+			return nil, fmt.Errorf("feature %s: %w", name, err)
 		}
+
+		m[name] = &Feature{Feature: fpb}
 	}
 
-	initLocked(m)
-	return nil
+	return m, nil
 }
 
-func initLocked(m map[string]*Feature) {
-	inst.features = make(map[string]*Feature, len(m))
+// initLocked replaces the store's features with the given ones, recording a
+// revision (attributed to c) for each feature that is added, changed, or
+// removed. Callers must hold s.m.
+func (s *Store) initLocked(c *caller, m map[string]*Feature) error {
+	var (
+		features  = make(map[string]*Feature, len(m))
+		mutations = make([]*Mutation, 0, len(m)+len(s.features))
+		revisions []*featurepb.FeatureRevision
+	)
 
 	for k, v := range m {
-		inst.features[k] = v
+		if v == nil || v.Feature == nil {
+			log.Printf("[store] skipping empty feature %s", k)
+			continue
+		}
+
+		// Copy the feature so that we can set its name and version, and so
+		// that its segment references resolve against this store, without
+		// modifying the caller's feature.
+		fpb := proto.Clone(v.Feature).(*featurepb.Feature)
+		fpb.Name = k
+
+		// Versions are assigned by the store, so ignore them when checking
+		// whether the feature changed. Only persist features that actually
+		// changed, so that reloading an unchanged config is a no-op.
+		before, ok := s.features[k]
+		if ok {
+			fpb.Version = before.Version
+			if proto.Equal(before.Feature, fpb) {
+				features[k] = before
+				continue
+			}
+		}
+
+		var beforepb *featurepb.Feature
+		if ok {
+			beforepb = before.Feature
+		}
+
+		rev := s.newRevisionLocked(c.actor, k, beforepb, fpb)
+		revisions = append(revisions, rev)
+		mutations = append(mutations,
+			&Mutation{Type: SetFeatureMutation, Feature: fpb},
+			&Mutation{Type: AppendRevisionMutation, Revision: rev},
+		)
+
+		features[k] = &Feature{
+			Feature:  fpb,
+			expr:     v.expr,
+			rules:    v.rules,
+			segments: s,
+		}
 	}
+
+	for name, feat := range s.features {
+		if _, ok := features[name]; !ok {
+			rev := s.newRevisionLocked(c.actor, name, feat.Feature, nil)
+			revisions = append(revisions, rev)
+			mutations = append(mutations,
+				&Mutation{Type: DeleteFeatureMutation, Name: name},
+				&Mutation{Type: AppendRevisionMutation, Revision: rev},
+			)
+		}
+	}
+
+	rollback, err := s.auditLocked(c, revisions)
+	if err != nil {
+		return err
+	}
+
+	if len(mutations) > 0 {
+		if err := s.backend.Apply(mutations...); err != nil {
+			rollback()
+			return err
+		}
+	}
+
+	for _, rev := range revisions {
+		s.appendHistoryLocked(rev)
+	}
+
+	before := s.features
+	s.features = features
+
+	s.events.publishDiff(before, s.features)
+	return nil
 }
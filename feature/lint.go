@@ -0,0 +1,168 @@
+package feature
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Knetic/govaluate"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// The checks made by LintConfig.
+const (
+	// LintNameMismatch flags a feature whose name field differs from its key
+	// in the config file. The key wins, so the name field is misleading.
+	LintNameMismatch = "name-mismatch"
+	// LintMissingDescription flags a feature without a description.
+	LintMissingDescription = "missing-description"
+	// LintConstantPercentage flags a PERCENTAGE_BASED feature at 0% or 100%,
+	// which should be a CONSTANT feature instead.
+	LintConstantPercentage = "constant-percentage"
+	// LintUnknownParameter flags a feature that uses a parameter that is not
+	// one of the known parameters.
+	LintUnknownParameter = "unknown-parameter"
+)
+
+// LintIssue is something about a valid feature that is probably a mistake.
+type LintIssue struct {
+	Feature string `json:"feature"`
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+func (i *LintIssue) String() string {
+	return fmt.Sprintf("%s: %s (%s)", i.Feature, i.Message, i.Check)
+}
+
+// LintConfig checks the contents of a config file for things that are valid,
+// but probably mistakes. If params is non-nil, it lists the parameters that
+// callers pass when evaluating features, and features that use any other
+// parameter are flagged.
+//
+// The features must be valid; if they are not, the error from validating them
+// is returned instead.
+func LintConfig(data []byte, params []string) ([]*LintIssue, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyConfig
+	}
+
+	m, err := UnmarshalConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validation sets each feature's name to its key, so look for mismatches
+	// first.
+	names := make(map[string]string, len(m))
+	for key, f := range m {
+		if f != nil && f.Feature != nil {
+			names[key] = f.Name
+		}
+	}
+
+	if err := ValidateFeatures(m); err != nil {
+		return nil, err
+	}
+
+	var known map[string]bool
+	if params != nil {
+		known = make(map[string]bool, len(params))
+		for _, p := range params {
+			known[p] = true
+		}
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var issues []*LintIssue
+	for _, key := range keys {
+		f := m[key]
+
+		add := func(check string, format string, args ...interface{}) {
+			issues = append(issues, &LintIssue{
+				Feature: key,
+				Check:   check,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		if name := names[key]; name != "" && name != key {
+			add(LintNameMismatch, "name %q does not match key; the key is used", name)
+		}
+
+		if strings.TrimSpace(f.Description) == "" {
+			add(LintMissingDescription, "no description")
+		}
+
+		if f.Type == featurepb.Feature_PERCENTAGE_BASED && (f.Percentage == 0 || f.Percentage == 100) {
+			add(LintConstantPercentage, "percentage is %d%%; use a CONSTANT feature instead", f.Percentage)
+		}
+
+		if known == nil {
+			continue
+		}
+
+		refs, err := f.ParameterRefs()
+		if err != nil {
+			return nil, fmt.Errorf("feature %s: %w", key, err)
+		}
+
+		for _, ref := range refs {
+			if !known[ref] {
+				add(LintUnknownParameter, "uses unknown parameter %s", ref)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// ParameterRefs returns the names of the parameters the feature uses when it
+// is evaluated: those referenced by its expressions (other than references to
+// segments), and its bucketing key.
+func (f *Feature) ParameterRefs() ([]string, error) {
+	var exprs []*govaluate.EvaluableExpression
+
+	switch f.Type {
+	case featurepb.Feature_EXPRESSION:
+		if err := f.parseExpression(); err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, f.expr)
+	case featurepb.Feature_RULES:
+		if err := f.parseRules(); err != nil {
+			return nil, err
+		}
+
+		exprs = f.rules
+	}
+
+	set := map[string]bool{}
+	if f.BucketingKey != "" {
+		set[f.BucketingKey] = true
+	}
+
+	for _, expr := range exprs {
+		for _, v := range expr.Vars() {
+			if !strings.HasPrefix(v, segmentVarPrefix) {
+				set[v] = true
+			}
+		}
+	}
+
+	refs := make([]string, 0, len(set))
+	for name := range set {
+		refs = append(refs, name)
+	}
+
+	sort.Strings(refs)
+	return refs, nil
+}
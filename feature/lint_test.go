@@ -0,0 +1,147 @@
+package feature
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestLintConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		params []string
+		// want are the issues found, as feature:check.
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "clean",
+			config: `{"a": {"type": "CONSTANT", "description": "a"}}`,
+		},
+		{
+			name:   "name mismatch",
+			config: `{"a": {"name": "b", "type": "CONSTANT", "description": "a"}, "c": {"name": "c", "type": "CONSTANT", "description": "c"}}`,
+			want:   []string{"a:" + LintNameMismatch},
+		},
+		{
+			name:   "missing description",
+			config: `{"a": {"type": "CONSTANT", "description": " "}}`,
+			want:   []string{"a:" + LintMissingDescription},
+		},
+		{
+			name: "constant percentages",
+			config: `{
+				"a": {"type": "PERCENTAGE_BASED", "percentage": 0, "description": "a"},
+				"b": {"type": "PERCENTAGE_BASED", "percentage": 100, "description": "b"},
+				"c": {"type": "PERCENTAGE_BASED", "percentage": 50, "description": "c"}
+			}`,
+			want: []string{"a:" + LintConstantPercentage, "b:" + LintConstantPercentage},
+		},
+		{
+			name: "unknown parameters",
+			config: `{
+				"a": {"type": "EXPRESSION", "expression": "country == 'US' && [segment:beta]", "description": "a"},
+				"b": {"type": "RULES", "rules": [{"expression": "plan == 'pro'", "outcome": {"enabled": true}}], "description": "b"},
+				"c": {"type": "PERCENTAGE_BASED", "percentage": 10, "bucketingKey": "user_id", "description": "c"}
+			}`,
+			params: []string{"country"},
+			want:   []string{"b:" + LintUnknownParameter, "c:" + LintUnknownParameter},
+		},
+		{
+			name:   "parameters are only checked if given",
+			config: `{"a": {"type": "EXPRESSION", "expression": "country == 'US'", "description": "a"}}`,
+		},
+		{
+			name:    "invalid",
+			config:  `{"a": {"type": "EXPRESSION", "expression": "x >"}}`,
+			wantErr: ErrInvalidFeature,
+		},
+		{
+			name:    "empty",
+			wantErr: ErrEmptyConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := LintConfig([]byte(tt.config), tt.params)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("LintConfig() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("LintConfig() error = %v", err)
+			}
+
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.Feature+":"+issue.Check)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("LintConfig() = %v, want %v", issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestParameterRefs(t *testing.T) {
+	f := &Feature{Feature: &featurepb.Feature{
+		Name:         "f",
+		Type:         featurepb.Feature_RULES,
+		BucketingKey: "user_id",
+		Rules: []*featurepb.Rule{
+			{Expression: "country == 'US' && [segment:beta]"},
+			{Expression: "plan == 'pro' && country != 'CA'"},
+		},
+	}}
+
+	refs, err := f.ParameterRefs()
+	if err != nil {
+		t.Fatalf("ParameterRefs() error = %v", err)
+	}
+
+	if got := strings.Join(refs, ","); got != "country,plan,user_id" {
+		t.Errorf("ParameterRefs() = %v, want [country plan user_id]", refs)
+	}
+}
+
+func TestReadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, config string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+			t.Fatalf("WriteFile error = %v", err)
+		}
+
+		return path
+	}
+
+	m, err := ReadConfigFile(write("good.json", `{"a": {"type": "EXPRESSION", "expression": "x > 1"}}`))
+	if err != nil {
+		t.Fatalf("ReadConfigFile error = %v", err)
+	}
+
+	if on, err := m["a"].IsEnabledForParameters(map[string]interface{}{"x": 2}); err != nil || !on {
+		t.Errorf("a.IsEnabledForParameters(x=2) = %v, %v; want true", on, err)
+	}
+
+	if _, err := ReadConfigFile(write("bad.json", `{"a": {"type": "EXPRESSION", "expression": "x >"}}`)); !errors.Is(err, ErrInvalidFeature) {
+		t.Errorf("ReadConfigFile(bad.json) error = %v, want ErrInvalidFeature", err)
+	}
+
+	if _, err := ReadConfigFile(write("empty.json", "")); !errors.Is(err, ErrEmptyConfig) {
+		t.Errorf("ReadConfigFile(empty.json) error = %v, want ErrEmptyConfig", err)
+	}
+}
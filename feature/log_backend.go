@@ -0,0 +1,440 @@
+package feature
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/jsonpb"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+const (
+	logFileName      = "wal.log"
+	snapshotFileName = "snapshot.json"
+
+	// logCompactionThreshold is the number of log records after which the log
+	// is compacted into a new snapshot.
+	logCompactionThreshold = 1000
+
+	// logHeaderSize is the size of a log record header: a uint32 payload
+	// length followed by a uint32 CRC-32 (Castagnoli) of the payload.
+	logHeaderSize = 8
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	// errTruncatedRecord is returned when reading a log record that was only
+	// partially written, e.g. because of a crash.
+	errTruncatedRecord = errors.New("truncated log record")
+	// errCorruptRecord is returned when reading a complete log record whose
+	// checksum does not match. Unlike a truncated record, this is never left
+	// by a crash, so it is not safe to discard.
+	errCorruptRecord = errors.New("corrupt log record")
+)
+
+// LogBackend is a Backend that stores state in a directory on disk, as a
+// snapshot plus an append-only log of the mutations made since the snapshot.
+// Every Apply is synced to disk before returning. The log is periodically
+// compacted into a new snapshot.
+//
+// On open, the state is recovered by replaying the log on top of the snapshot.
+// A partially written record at the end of the log (as left by a crash during
+// Apply) is discarded. A corrupt record anywhere in the log is an error, since
+// discarding it would lose it and every record after it.
+type LogBackend struct {
+	dir          string
+	compactAfter int
+
+	m       sync.Mutex
+	state   *State
+	log     *os.File
+	records int
+}
+
+var _ Backend = (*LogBackend)(nil)
+
+// logRecord is the payload of a single log record, holding all the mutations
+// from one call to Apply.
+type logRecord struct {
+	Mutations []*logMutation `json:"mutations"`
+}
+
+type logMutation struct {
+	Type          MutationType    `json:"type"`
+	Feature       json.RawMessage `json:"feature,omitempty"`
+	Segment       json.RawMessage `json:"segment,omitempty"`
+	Revision      json.RawMessage `json:"revision,omitempty"`
+	PendingChange json.RawMessage `json:"pending_change,omitempty"`
+	Schedule      json.RawMessage `json:"schedule,omitempty"`
+	Name          string          `json:"name,omitempty"`
+}
+
+// NewLogBackend opens (creating, if needed) a LogBackend in the given
+// directory, recovering any state persisted there.
+func NewLogBackend(dir string) (*LogBackend, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	b := &LogBackend{
+		dir:          dir,
+		compactAfter: logCompactionThreshold,
+		state:        newState(),
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, snapshotFileName))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		b.state, err = unmarshalState(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", snapshotFileName, err)
+		}
+	}
+
+	b.log, err = os.OpenFile(filepath.Join(dir, logFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the log file's creation is durable.
+	if err := syncDir(dir); err != nil {
+		b.log.Close()
+		return nil, err
+	}
+
+	if err := b.replay(); err != nil {
+		b.log.Close()
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// replay applies every complete record in the log to the state, truncates any
+// partial record from the end of the log, and leaves the log positioned for
+// appending. It fails, without truncating anything, on a corrupt record.
+func (b *LogBackend) replay() error {
+	fi, err := b.log.Stat()
+	if err != nil {
+		return err
+	}
+
+	var (
+		r      = bufio.NewReader(b.log)
+		offset int64
+	)
+
+	for {
+		payload, err := readLogRecord(r, fi.Size()-offset)
+		if err == io.EOF {
+			break
+		}
+
+		if errors.Is(err, errTruncatedRecord) {
+			log.Printf("[log] discarding %v at offset %d of %s", err, offset, logFileName)
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: record at offset %d: %w", logFileName, offset, err)
+		}
+
+		mutations, err := decodeLogRecord(payload)
+		if err != nil {
+			return fmt.Errorf("%s: record at offset %d: %w", logFileName, offset, err)
+		}
+
+		if err := b.state.apply(mutations...); err != nil {
+			return fmt.Errorf("%s: record at offset %d: %w", logFileName, offset, err)
+		}
+
+		offset += int64(logHeaderSize + len(payload))
+		b.records++
+	}
+
+	if err := b.log.Truncate(offset); err != nil {
+		return err
+	}
+
+	_, err = b.log.Seek(offset, io.SeekStart)
+	return err
+}
+
+// Load is part of the Backend interface.
+func (b *LogBackend) Load() (*State, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.state.clone(), nil
+}
+
+// Apply is part of the Backend interface. The mutations are appended to the
+// log as a single record, which is synced before Apply returns.
+func (b *LogBackend) Apply(mutations ...*Mutation) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	state := b.state.clone()
+	if err := state.apply(mutations...); err != nil {
+		return err
+	}
+
+	payload, err := encodeLogRecord(mutations)
+	if err != nil {
+		return err
+	}
+
+	offset, err := b.log.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	if err := b.append(payload); err != nil {
+		// Don't leave a partial record behind for later appends to follow.
+		if terr := b.log.Truncate(offset); terr == nil {
+			b.log.Seek(offset, io.SeekStart)
+		}
+
+		return err
+	}
+
+	b.state = state
+	b.records++
+
+	if b.records >= b.compactAfter {
+		if err := b.compact(); err != nil {
+			// The mutations are durable in the log, so this is not fatal; we
+			// will try again after the next Apply.
+			log.Printf("[log] error compacting %s: %v", b.dir, err)
+		}
+	}
+
+	return nil
+}
+
+func (b *LogBackend) append(payload []byte) error {
+	buf := make([]byte, logHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.Checksum(payload, crcTable))
+	copy(buf[logHeaderSize:], payload)
+
+	if _, err := b.log.Write(buf); err != nil {
+		return err
+	}
+
+	return b.log.Sync()
+}
+
+// compact writes the current state as a new snapshot and empties the log.
+//
+// If we crash after writing the snapshot but before emptying the log, the log
+// will be replayed on top of a snapshot that already includes it. This is
+// harmless, because replaying is idempotent: most mutations set or delete
+// whole objects, and State.apply skips revisions that are already in the
+// history.
+func (b *LogBackend) compact() error {
+	data, err := marshalState(b.state)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(filepath.Join(b.dir, snapshotFileName), data); err != nil {
+		return err
+	}
+
+	if err := b.log.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err := b.log.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := b.log.Sync(); err != nil {
+		return err
+	}
+
+	b.records = 0
+	return nil
+}
+
+// Close is part of the Backend interface.
+func (b *LogBackend) Close() error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.log.Close()
+}
+
+// readLogRecord reads the next record from the log, which has the given number
+// of bytes remaining, returning its payload. It returns io.EOF at a clean end
+// of the log, errTruncatedRecord if the log ends partway through a record, and
+// errCorruptRecord if the record's checksum does not match.
+func readLogRecord(r io.Reader, remaining int64) ([]byte, error) {
+	var header [logHeaderSize]byte
+
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("%w: short header", errTruncatedRecord)
+		}
+
+		return nil, err
+	}
+
+	var (
+		size = binary.BigEndian.Uint32(header[0:4])
+		sum  = binary.BigEndian.Uint32(header[4:8])
+	)
+
+	// Check the size against what's left of the log before allocating, in
+	// case the size itself is garbage.
+	if int64(size) > remaining-logHeaderSize {
+		return nil, fmt.Errorf("%w: short payload", errTruncatedRecord)
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("%w: short payload", errTruncatedRecord)
+		}
+
+		return nil, err
+	}
+
+	if crc32.Checksum(payload, crcTable) != sum {
+		return nil, fmt.Errorf("%w: checksum mismatch", errCorruptRecord)
+	}
+
+	return payload, nil
+}
+
+func encodeLogRecord(mutations []*Mutation) ([]byte, error) {
+	var (
+		rec = logRecord{Mutations: make([]*logMutation, 0, len(mutations))}
+		m   = jsonpb.Marshaler{}
+	)
+
+	for _, mut := range mutations {
+		lm := &logMutation{Type: mut.Type, Name: mut.Name}
+
+		if mut.Feature != nil {
+			s, err := m.MarshalToString(mut.Feature)
+			if err != nil {
+				return nil, err
+			}
+
+			lm.Feature = json.RawMessage(s)
+		}
+
+		if mut.Segment != nil {
+			s, err := m.MarshalToString(mut.Segment)
+			if err != nil {
+				return nil, err
+			}
+
+			lm.Segment = json.RawMessage(s)
+		}
+
+		if mut.Revision != nil {
+			s, err := m.MarshalToString(mut.Revision)
+			if err != nil {
+				return nil, err
+			}
+
+			lm.Revision = json.RawMessage(s)
+		}
+
+		if mut.PendingChange != nil {
+			s, err := m.MarshalToString(mut.PendingChange)
+			if err != nil {
+				return nil, err
+			}
+
+			lm.PendingChange = json.RawMessage(s)
+		}
+
+		if mut.Schedule != nil {
+			s, err := m.MarshalToString(mut.Schedule)
+			if err != nil {
+				return nil, err
+			}
+
+			lm.Schedule = json.RawMessage(s)
+		}
+
+		rec.Mutations = append(rec.Mutations, lm)
+	}
+
+	return json.Marshal(&rec)
+}
+
+func decodeLogRecord(payload []byte) ([]*Mutation, error) {
+	var rec logRecord
+	if err := json.Unmarshal(payload, &rec); err != nil {
+		return nil, err
+	}
+
+	var (
+		mutations = make([]*Mutation, 0, len(rec.Mutations))
+		u         = jsonpb.Unmarshaler{}
+	)
+
+	for _, lm := range rec.Mutations {
+		mut := &Mutation{Type: lm.Type, Name: lm.Name}
+
+		if lm.Feature != nil {
+			mut.Feature = &featurepb.Feature{}
+			if err := u.Unmarshal(bytes.NewReader(lm.Feature), mut.Feature); err != nil {
+				return nil, err
+			}
+		}
+
+		if lm.Segment != nil {
+			mut.Segment = &featurepb.Segment{}
+			if err := u.Unmarshal(bytes.NewReader(lm.Segment), mut.Segment); err != nil {
+				return nil, err
+			}
+		}
+
+		if lm.Revision != nil {
+			mut.Revision = &featurepb.FeatureRevision{}
+			if err := u.Unmarshal(bytes.NewReader(lm.Revision), mut.Revision); err != nil {
+				return nil, err
+			}
+		}
+
+		if lm.PendingChange != nil {
+			mut.PendingChange = &featurepb.PendingChange{}
+			if err := u.Unmarshal(bytes.NewReader(lm.PendingChange), mut.PendingChange); err != nil {
+				return nil, err
+			}
+		}
+
+		if lm.Schedule != nil {
+			mut.Schedule = &featurepb.ScheduledChange{}
+			if err := u.Unmarshal(bytes.NewReader(lm.Schedule), mut.Schedule); err != nil {
+				return nil, err
+			}
+		}
+
+		mutations = append(mutations, mut)
+	}
+
+	return mutations, nil
+}
@@ -0,0 +1,309 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func openLogBackend(t *testing.T, dir string) *LogBackend {
+	t.Helper()
+
+	b, err := NewLogBackend(dir)
+	if err != nil {
+		t.Fatalf("NewLogBackend error = %v", err)
+	}
+
+	return b
+}
+
+func setFeature(name string, enabled bool) *Mutation {
+	return &Mutation{
+		Type:    SetFeatureMutation,
+		Feature: &featurepb.Feature{Name: name, Type: featurepb.Feature_CONSTANT, Enabled: enabled},
+	}
+}
+
+func TestLogBackend(t *testing.T) {
+	dir := t.TempDir()
+	b := openLogBackend(t, dir)
+
+	muts := [][]*Mutation{
+		{setFeature("a", true)},
+		{setFeature("b", true), setFeature("c", false)},
+		{{Type: DeleteFeatureMutation, Name: "b"}},
+		{{Type: SetSegmentMutation, Segment: &featurepb.Segment{Name: "s", Key: "user_id"}}},
+	}
+
+	for _, m := range muts {
+		if err := b.Apply(m...); err != nil {
+			t.Fatalf("Apply error = %v", err)
+		}
+	}
+
+	if err := b.Apply(&Mutation{Type: 100}); err == nil {
+		t.Error("Apply(unknown mutation type) succeeded")
+	}
+
+	b.Close()
+
+	b = openLogBackend(t, dir)
+	defer b.Close()
+
+	state, err := b.Load()
+	if err != nil {
+		t.Fatalf("Load error = %v", err)
+	}
+
+	if len(state.Features) != 2 || !state.Features["a"].Enabled || state.Features["c"] == nil || state.Segments["s"] == nil {
+		t.Errorf("recovered state = %+v, want features a, c and segment s", state)
+	}
+}
+
+func TestLogBackendTruncatedTail(t *testing.T) {
+	// Write two records, and note where the second one starts.
+	src := t.TempDir()
+	b := openLogBackend(t, src)
+
+	if err := b.Apply(setFeature("a", true)); err != nil {
+		t.Fatalf("Apply error = %v", err)
+	}
+
+	fi, err := b.log.Stat()
+	if err != nil {
+		t.Fatalf("Stat error = %v", err)
+	}
+
+	first := fi.Size()
+
+	if err := b.Apply(setFeature("b", true)); err != nil {
+		t.Fatalf("Apply error = %v", err)
+	}
+
+	b.Close()
+
+	full, err := ioutil.ReadFile(filepath.Join(src, logFileName))
+	if err != nil {
+		t.Fatalf("ReadFile error = %v", err)
+	}
+
+	// Simulate a crash at every point during the second write.
+	for n := first; n < int64(len(full)); n++ {
+		t.Run(fmt.Sprintf("%d_bytes", n), func(t *testing.T) {
+			dir := t.TempDir()
+			if err := ioutil.WriteFile(filepath.Join(dir, logFileName), full[:n], 0644); err != nil {
+				t.Fatalf("WriteFile error = %v", err)
+			}
+
+			b := openLogBackend(t, dir)
+			defer b.Close()
+
+			state, _ := b.Load()
+			if len(state.Features) != 1 || state.Features["a"] == nil {
+				t.Fatalf("recovered features = %v, want only a", state.Features)
+			}
+
+			// The partial record is discarded, so new records are readable
+			// after a reopen.
+			if err := b.Apply(setFeature("c", true)); err != nil {
+				t.Fatalf("Apply error = %v", err)
+			}
+
+			b.Close()
+
+			b = openLogBackend(t, dir)
+			defer b.Close()
+
+			state, _ = b.Load()
+			if len(state.Features) != 2 || state.Features["c"] == nil {
+				t.Errorf("features after reopen = %v, want a and c", state.Features)
+			}
+		})
+	}
+}
+
+func TestLogBackendCorruptRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		// flip returns the offset of the byte to corrupt, given the offsets
+		// at which each record starts, and the size of the log.
+		flip func(starts []int64, size int64) int64
+	}{
+		{
+			name: "first record",
+			flip: func(starts []int64, size int64) int64 { return starts[0] + logHeaderSize + 2 },
+		},
+		{
+			name: "middle record",
+			flip: func(starts []int64, size int64) int64 { return starts[1] + logHeaderSize + 2 },
+		},
+		{
+			name: "last record",
+			flip: func(starts []int64, size int64) int64 { return size - 2 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			b := openLogBackend(t, dir)
+
+			var starts []int64
+			for _, name := range []string{"a", "b", "c"} {
+				fi, err := b.log.Stat()
+				if err != nil {
+					t.Fatalf("Stat error = %v", err)
+				}
+
+				starts = append(starts, fi.Size())
+
+				if err := b.Apply(setFeature(name, true)); err != nil {
+					t.Fatalf("Apply error = %v", err)
+				}
+			}
+
+			b.Close()
+
+			path := filepath.Join(dir, logFileName)
+
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile error = %v", err)
+			}
+
+			data[tt.flip(starts, int64(len(data)))] ^= 0xff
+			if err := ioutil.WriteFile(path, data, 0644); err != nil {
+				t.Fatalf("WriteFile error = %v", err)
+			}
+
+			if b, err := NewLogBackend(dir); !errors.Is(err, errCorruptRecord) {
+				if err == nil {
+					b.Close()
+				}
+
+				t.Fatalf("NewLogBackend error = %v, want errCorruptRecord", err)
+			}
+
+			// Nothing is discarded, so the log can still be repaired.
+			after, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile error = %v", err)
+			}
+
+			if len(after) != len(data) {
+				t.Errorf("log is %d bytes after failed open, want %d", len(after), len(data))
+			}
+		})
+	}
+}
+
+func TestLogBackendCompaction(t *testing.T) {
+	dir := t.TempDir()
+	b := openLogBackend(t, dir)
+	b.compactAfter = 3
+
+	for i := 0; i < 7; i++ {
+		if err := b.Apply(setFeature(fmt.Sprintf("f%d", i), true)); err != nil {
+			t.Fatalf("Apply error = %v", err)
+		}
+	}
+
+	if err := b.Apply(&Mutation{Type: DeleteFeatureMutation, Name: "f0"}); err != nil {
+		t.Fatalf("Apply error = %v", err)
+	}
+
+	if b.records != 2 {
+		t.Errorf("records since compaction = %d, want 2", b.records)
+	}
+
+	b.Close()
+
+	if _, err := os.Stat(filepath.Join(dir, snapshotFileName)); err != nil {
+		t.Fatalf("snapshot not written: %v", err)
+	}
+
+	b = openLogBackend(t, dir)
+	defer b.Close()
+
+	state, _ := b.Load()
+	if len(state.Features) != 6 || state.Features["f0"] != nil || state.Features["f6"] == nil {
+		t.Errorf("recovered features = %v, want f1 through f6", state.Features)
+	}
+}
+
+func TestLogBackendCrashDuringCompaction(t *testing.T) {
+	dir := t.TempDir()
+	b := openLogBackend(t, dir)
+
+	for v := uint64(1); v <= 2; v++ {
+		f := &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT, Enabled: v == 2, Version: v}
+		err := b.Apply(
+			&Mutation{Type: SetFeatureMutation, Feature: f},
+			&Mutation{Type: AppendRevisionMutation, Revision: &featurepb.FeatureRevision{Name: "a", Version: v, After: f}},
+		)
+		if err != nil {
+			t.Fatalf("Apply error = %v", err)
+		}
+	}
+
+	// Write the snapshot, as compact does, but crash before emptying the log.
+	data, err := marshalState(b.state)
+	if err != nil {
+		t.Fatalf("marshalState error = %v", err)
+	}
+
+	if err := writeFileAtomic(filepath.Join(dir, snapshotFileName), data); err != nil {
+		t.Fatalf("writeFileAtomic error = %v", err)
+	}
+
+	b.Close()
+
+	b = openLogBackend(t, dir)
+	defer b.Close()
+
+	state, err := b.Load()
+	if err != nil {
+		t.Fatalf("Load error = %v", err)
+	}
+
+	if history := state.History["a"]; len(history) != 2 || history[0].Version != 1 || history[1].Version != 2 {
+		t.Errorf("recovered history = %v, want versions 1 and 2 once each", history)
+	}
+
+	if !state.Features["a"].Enabled {
+		t.Errorf("recovered feature = %v, want enabled", state.Features["a"])
+	}
+}
+
+func TestLogBackendStore(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := OpenStore(openLogBackend(t, dir))
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+
+	s.Init(map[string]*Feature{
+		"a": {Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT, Enabled: true}},
+	})
+	s.Close()
+
+	s, err = OpenStore(openLogBackend(t, dir))
+	if err != nil {
+		t.Fatalf("OpenStore error = %v", err)
+	}
+	defer s.Close()
+
+	if on, err := s.Get("a", nil); err != nil || !on {
+		t.Errorf("Get(a) after reopen = %v, %v; want true", on, err)
+	}
+
+	if _, err := s.Get("b", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(b) error = %v, want ErrNoFeature", err)
+	}
+}
@@ -0,0 +1,69 @@
+package feature
+
+import (
+	"fmt"
+
+	"github.com/Knetic/govaluate"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// matchOutcome evaluates the feature's rules, in order, against the given
+// parameters, and returns the outcome of the first rule that matches, along
+// with that rule's index. If no rule matches, the feature's fallthrough
+// outcome (which may be nil) is returned with an index of -1.
+func (f *Feature) matchOutcome(parameters map[string]interface{}) (*featurepb.Outcome, int, error) {
+	if err := f.parseRules(); err != nil {
+		return nil, -1, err
+	}
+
+	for i, expr := range f.rules {
+		result, err := expr.Eval(f.parameters(parameters))
+		if err != nil {
+			return nil, -1, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		matched, ok := result.(bool)
+		if !ok {
+			return nil, -1, fmt.Errorf("rule %d: expression %v did not return a bool: %v", i, expr, result)
+		}
+
+		if matched {
+			return f.Rules[i].Outcome, i, nil
+		}
+	}
+
+	return f.Fallthrough, -1, nil
+}
+
+// parseRules parses each rule's expression string. Like parseExpression, the
+// parsed expressions are reused, so parseRules will be a no-op on subsequent
+// calls.
+func (f *Feature) parseRules() error {
+	if f.rules != nil {
+		return nil
+	}
+
+	rules := make([]*govaluate.EvaluableExpression, len(f.Rules))
+	for i, rule := range f.Rules {
+		expr, err := govaluate.NewEvaluableExpression(rule.Expression)
+		if err != nil {
+			return fmt.Errorf("could not parse expression %s for rule %d: %w", rule.Expression, i, err)
+		}
+
+		rules[i] = expr
+	}
+
+	f.rules = rules
+	return nil
+}
+
+func (f *Feature) hasVariant(name string) bool {
+	for _, v := range f.Variants {
+		if v.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
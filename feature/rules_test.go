@@ -0,0 +1,138 @@
+package feature
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+const rulesConfig = `{
+  "checkout": {
+    "type": "RULES",
+    "bucketingKey": "user_id",
+    "variants": [
+      {"name": "control", "weight": 50},
+      {"name": "treatment", "weight": 50}
+    ],
+    "rules": [
+      {"expression": "employee == true", "outcome": {"variant": "treatment"}},
+      {"expression": "country == 'US'", "outcome": {"percentage": 100, "value": {"stringValue": "us"}}},
+      {"expression": "country == 'CA'", "outcome": {"enabled": false}}
+    ],
+    "fallthrough": {"enabled": true}
+  }
+}`
+
+func TestRules(t *testing.T) {
+	var m map[string]*Feature
+	if err := json.Unmarshal([]byte(rulesConfig), &m); err != nil {
+		t.Fatalf("json.Unmarshal error = %v", err)
+	}
+
+	f := m["checkout"]
+	f.Name = "checkout"
+
+	if ok, err := f.Validate(); !ok {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		enabled bool
+		variant string
+		value   interface{}
+	}{
+		{
+			name:    "first rule wins",
+			params:  map[string]interface{}{"employee": true, "country": "CA", "user_id": 1},
+			enabled: true,
+			variant: "treatment",
+		},
+		{
+			name:    "percentage outcome with value",
+			params:  map[string]interface{}{"employee": false, "country": "US", "user_id": 1},
+			enabled: true,
+			value:   "us",
+		},
+		{
+			name:    "disabled outcome",
+			params:  map[string]interface{}{"employee": false, "country": "CA", "user_id": 1},
+			enabled: false,
+		},
+		{
+			name:    "fallthrough",
+			params:  map[string]interface{}{"employee": false, "country": "FR", "user_id": 1},
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			enabled, err := f.IsEnabledForParameters(tt.params)
+			if err != nil || enabled != tt.enabled {
+				t.Errorf("IsEnabledForParameters() = %v, %v; want %v", enabled, err, tt.enabled)
+			}
+
+			variant, err := f.Variant(tt.params)
+			if err != nil || variant != tt.variant {
+				t.Errorf("Variant() = %q, %v; want %q", variant, err, tt.variant)
+			}
+
+			value, _, err := f.ValueForParameters(tt.params)
+			if err != nil || value != tt.value {
+				t.Errorf("ValueForParameters() = %v, %v; want %v", value, err, tt.value)
+			}
+		})
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []*featurepb.Rule
+		fall  *featurepb.Outcome
+	}{
+		{
+			name:  "unparseable expression",
+			rules: []*featurepb.Rule{{Expression: "country ==", Outcome: &featurepb.Outcome{Kind: &featurepb.Outcome_Enabled{Enabled: true}}}},
+		},
+		{
+			name:  "empty expression",
+			rules: []*featurepb.Rule{{Outcome: &featurepb.Outcome{Kind: &featurepb.Outcome_Enabled{Enabled: true}}}},
+		},
+		{
+			name:  "missing outcome",
+			rules: []*featurepb.Rule{{Expression: "1 > 0"}},
+		},
+		{
+			name:  "bad percentage",
+			rules: []*featurepb.Rule{{Expression: "1 > 0", Outcome: &featurepb.Outcome{Kind: &featurepb.Outcome_Percentage{Percentage: 101}}}},
+		},
+		{
+			name: "empty fallthrough",
+			fall: &featurepb.Outcome{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Feature{
+				Feature: &featurepb.Feature{
+					Name:        tt.name,
+					Type:        featurepb.Feature_RULES,
+					Rules:       tt.rules,
+					Fallthrough: tt.fall,
+				},
+			}
+
+			if ok, err := f.Validate(); ok || !errors.Is(err, ErrInvalidFeature) {
+				t.Errorf("Validate() = %v, %v; want false, ErrInvalidFeature", ok, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	// ErrNoSchedule is returned when cancelling a scheduled change that does
+	// not exist, or has already been made.
+	ErrNoSchedule = errors.New("no such scheduled change")
+	// ErrInvalidSchedule is returned by ScheduleFeature for a malformed
+	// schedule, e.g. one with steps in the past.
+	ErrInvalidSchedule = errors.New("invalid schedule")
+)
+
+// RunSchedules makes scheduled changes in the background as they fall due,
+// checking every interval, until ctx is done. Steps that fell due while the
+// store was not running them (e.g. while the server was down) are made, in
+// order, on the first check.
+func (s *Store) RunSchedules(ctx context.Context, interval time.Duration) {
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			s.runDueSchedules()
+
+			select {
+			case <-ctx.Done():
+				log.Printf("[schedule] context finished: %v", ctx.Err())
+				return
+			case <-t.C:
+			}
+		}
+	}()
+}
+
+// runDueSchedules makes every scheduled step that is due, by the store's
+// clock, oldest first.
+func (s *Store) runDueSchedules() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	now := s.now().UnixNano()
+
+	for {
+		var next *featurepb.ScheduledChange
+		for _, sc := range s.schedules {
+			if sc.Steps[0].TimeUnixNano <= now && (next == nil || scheduleBefore(sc, next)) {
+				next = sc
+			}
+		}
+
+		if next == nil {
+			return
+		}
+
+		if err := s.runStepLocked(next); err != nil {
+			log.Printf("[schedule] failed to make step of %s to %s, will retry: %v", next.Id, next.Name, err)
+			return
+		}
+	}
+}
+
+// scheduleBefore returns whether a's next step is due before b's.
+func scheduleBefore(a, b *featurepb.ScheduledChange) bool {
+	if a.Steps[0].TimeUnixNano != b.Steps[0].TimeUnixNano {
+		return a.Steps[0].TimeUnixNano < b.Steps[0].TimeUnixNano
+	}
+
+	return a.Id < b.Id
+}
+
+// runStepLocked makes the first step of sc, and removes it from the schedule.
+// If the step can't be made (e.g. the feature no longer has the right type),
+// the rest of the schedule is cancelled, since the later steps likely depend
+// on it. An error is only returned if the backend could not be written, in
+// which case the step should be retried. Callers must hold s.m.
+func (s *Store) runStepLocked(sc *featurepb.ScheduledChange) error {
+	rest := proto.Clone(sc).(*featurepb.ScheduledChange)
+	rest.Steps = rest.Steps[1:]
+
+	mut := &Mutation{Type: SetScheduleMutation, Schedule: rest}
+	if len(rest.Steps) == 0 {
+		mut = &Mutation{Type: DeleteScheduleMutation, Name: sc.Id}
+	}
+
+	// The step is made by the server on the requester's behalf, so it may
+	// become a pending change; approving it still takes an authenticated
+	// approver.
+	c := &caller{
+		actor:         sc.RequestedBy,
+		authenticated: true,
+		method:        "schedule",
+		reason:        sc.Reason,
+	}
+
+	f, err := s.scheduledFeatureLocked(sc.Name, sc.Steps[0])
+	if err != nil {
+		log.Printf("[schedule] cancelling %s to %s: %v", sc.Id, sc.Name, err)
+
+		if err := s.backend.Apply(&Mutation{Type: DeleteScheduleMutation, Name: sc.Id}); err != nil {
+			return err
+		}
+
+		delete(s.schedules, sc.Id)
+		return nil
+	}
+
+	var before *featurepb.Feature
+	if feat, ok := s.features[sc.Name]; ok {
+		before = feat.Feature
+	}
+
+	pending, err := s.commitLocked(c, []*featureChange{{name: sc.Name, before: before, after: f}}, mut)
+	if err != nil {
+		return err
+	}
+
+	if len(rest.Steps) == 0 {
+		delete(s.schedules, sc.Id)
+	} else {
+		s.schedules[sc.Id] = rest
+	}
+
+	if pending != nil {
+		log.Printf("[schedule] %s is protected; step of %s is awaiting approval as change %s", sc.Name, sc.Id, pending.Id)
+	}
+
+	return nil
+}
+
+// scheduledFeatureLocked returns the named feature as it will be after step,
+// validated against the store's current segments. Callers must hold s.m.
+func (s *Store) scheduledFeatureLocked(name string, step *featurepb.ScheduledStep) (*Feature, error) {
+	var fpb *featurepb.Feature
+
+	switch change := step.GetChange().(type) {
+	case *featurepb.ScheduledStep_Feature:
+		fpb = change.Feature
+	case *featurepb.ScheduledStep_Enabled, *featurepb.ScheduledStep_Percentage:
+		feat, ok := s.features[name]
+		if !ok {
+			return nil, fmt.Errorf("%w with name %s", ErrNoFeature, name)
+		}
+
+		fpb = proto.Clone(feat.Feature).(*featurepb.Feature)
+
+		switch change := change.(type) {
+		case *featurepb.ScheduledStep_Enabled:
+			if fpb.Type != featurepb.Feature_CONSTANT {
+				return nil, fmt.Errorf("%w: cannot enable or disable %s, which is %s, not CONSTANT", ErrInvalidSchedule, name, fpb.Type)
+			}
+
+			fpb.Enabled = change.Enabled
+		case *featurepb.ScheduledStep_Percentage:
+			if fpb.Type != featurepb.Feature_PERCENTAGE_BASED {
+				return nil, fmt.Errorf("%w: cannot set percentage of %s, which is %s, not PERCENTAGE_BASED", ErrInvalidSchedule, name, fpb.Type)
+			}
+
+			fpb.Percentage = change.Percentage
+		}
+	default:
+		return nil, fmt.Errorf("%w: step must set a feature, enabled, or a percentage", ErrInvalidSchedule)
+	}
+
+	return s.validateFeatureLocked(fpb)
+}
+
+// ScheduleFeature is part of the featurepb.FeaturesServer interface. Steps
+// that replace the feature are validated now, and again when they are made,
+// since the segments they refer to may have changed since. Enabled and
+// percentage steps are checked against the feature when they are made.
+func (s *Store) ScheduleFeature(ctx context.Context, req *featurepb.ScheduleFeatureRequest) (*featurepb.ScheduleFeatureResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if req.Name == "" {
+		return nil, rpcError(fmt.Errorf("%w: name of feature to change cannot be empty", ErrInvalidSchedule))
+	}
+
+	if len(req.Steps) == 0 {
+		return nil, rpcError(fmt.Errorf("%w: must have at least one step", ErrInvalidSchedule))
+	}
+
+	var (
+		now  = s.now()
+		last = now.UnixNano()
+		v    = s.validationLocked()
+	)
+
+	steps := make([]*featurepb.ScheduledStep, 0, len(req.Steps))
+
+	for i, step := range req.Steps {
+		step = proto.Clone(step).(*featurepb.ScheduledStep)
+
+		if step.TimeUnixNano <= last {
+			if i == 0 {
+				return nil, rpcError(fmt.Errorf("%w: step 0 is not in the future", ErrInvalidSchedule))
+			}
+
+			return nil, rpcError(fmt.Errorf("%w: step %d is not after step %d", ErrInvalidSchedule, i, i-1))
+		}
+
+		last = step.TimeUnixNano
+
+		switch change := step.GetChange().(type) {
+		case *featurepb.ScheduledStep_Feature:
+			if change.Feature == nil {
+				return nil, rpcError(fmt.Errorf("%w: step %d: feature cannot be empty", ErrInvalidSchedule, i))
+			}
+
+			if change.Feature.Name == "" {
+				change.Feature.Name = req.Name
+			}
+
+			if change.Feature.Name != req.Name {
+				return nil, rpcError(fmt.Errorf("%w: step %d changes %s, not %s", ErrInvalidSchedule, i, change.Feature.Name, req.Name))
+			}
+
+			// Versions are assigned when the step is made.
+			change.Feature.Version = 0
+			s.newFeatureLocked(v, change.Feature)
+		case *featurepb.ScheduledStep_Enabled:
+		case *featurepb.ScheduledStep_Percentage:
+			if change.Percentage > 100 {
+				return nil, rpcError(fmt.Errorf("%w: step %d: percentage must be in [0, 100] (got %d)", ErrInvalidSchedule, i, change.Percentage))
+			}
+		default:
+			return nil, rpcError(fmt.Errorf("%w: step %d must set a feature, enabled, or a percentage", ErrInvalidSchedule, i))
+		}
+
+		steps = append(steps, step)
+	}
+
+	if err := v.err(); err != nil {
+		return nil, rpcError(err)
+	}
+
+	id, err := newID(func(id string) bool {
+		_, ok := s.schedules[id]
+		return ok
+	})
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	c := callerFromContext(ctx, "ScheduleFeature")
+	sc := &featurepb.ScheduledChange{
+		Id:                 id,
+		Name:               req.Name,
+		Steps:              steps,
+		RequestedBy:        c.actor,
+		Reason:             c.reason,
+		CreateTimeUnixNano: now.UnixNano(),
+	}
+
+	if err := s.backend.Apply(&Mutation{Type: SetScheduleMutation, Schedule: sc}); err != nil {
+		return nil, rpcError(err)
+	}
+
+	s.schedules[id] = sc
+	log.Printf("[schedule] %s scheduled %s to %s in %d step(s), starting %s", c.actor, id, req.Name, len(steps), time.Unix(0, steps[0].TimeUnixNano).UTC().Format(time.RFC3339))
+
+	return &featurepb.ScheduleFeatureResponse{
+		Schedule: sc,
+	}, nil
+}
+
+// ListScheduledChanges is part of the featurepb.FeaturesServer interface.
+func (s *Store) ListScheduledChanges(ctx context.Context, req *featurepb.ListScheduledChangesRequest) (*featurepb.ListScheduledChangesResponse, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	resp := &featurepb.ListScheduledChangesResponse{
+		Schedules: make([]*featurepb.ScheduledChange, 0, len(s.schedules)),
+	}
+
+	for _, sc := range s.schedules {
+		if req.Name != "" && sc.Name != req.Name {
+			continue
+		}
+
+		resp.Schedules = append(resp.Schedules, sc)
+	}
+
+	sort.Slice(resp.Schedules, func(i, j int) bool {
+		return scheduleBefore(resp.Schedules[i], resp.Schedules[j])
+	})
+
+	return resp, nil
+}
+
+// CancelScheduledChange is part of the featurepb.FeaturesServer interface.
+// Steps that have already been made are not undone.
+func (s *Store) CancelScheduledChange(ctx context.Context, req *featurepb.CancelScheduledChangeRequest) (*featurepb.CancelScheduledChangeResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	sc, ok := s.schedules[req.Id]
+	if !ok {
+		return nil, rpcError(fmt.Errorf("%w: %s", ErrNoSchedule, req.Id))
+	}
+
+	if err := checkCanChange(ctx, sc.Name); err != nil {
+		return nil, rpcError(err)
+	}
+
+	if err := s.backend.Apply(&Mutation{Type: DeleteScheduleMutation, Name: sc.Id}); err != nil {
+		return nil, rpcError(err)
+	}
+
+	delete(s.schedules, sc.Id)
+	log.Printf("[schedule] %s cancelled %s to %s by %s", actorFromContext(ctx), sc.Id, sc.Name, sc.RequestedBy)
+
+	return &featurepb.CancelScheduledChangeResponse{
+		Schedule: sc,
+	}, nil
+}
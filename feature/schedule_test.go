@@ -0,0 +1,373 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func percentageStep(at time.Time, percentage uint32) *featurepb.ScheduledStep {
+	return &featurepb.ScheduledStep{
+		TimeUnixNano: at.UnixNano(),
+		Change:       &featurepb.ScheduledStep_Percentage{Percentage: percentage},
+	}
+}
+
+func enabledStep(at time.Time, enabled bool) *featurepb.ScheduledStep {
+	return &featurepb.ScheduledStep{
+		TimeUnixNano: at.UnixNano(),
+		Change:       &featurepb.ScheduledStep_Enabled{Enabled: enabled},
+	}
+}
+
+func featureStep(at time.Time, f *featurepb.Feature) *featurepb.ScheduledStep {
+	return &featurepb.ScheduledStep{
+		TimeUnixNano: at.UnixNano(),
+		Change:       &featurepb.ScheduledStep_Feature{Feature: f},
+	}
+}
+
+func TestScheduleFeature(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	s := NewStore()
+	s.now = func() time.Time { return now }
+
+	tests := []struct {
+		name    string
+		req     *featurepb.ScheduleFeatureRequest
+		wantErr error
+	}{
+		{
+			name: "ramp",
+			req: &featurepb.ScheduleFeatureRequest{
+				Name: "f",
+				Steps: []*featurepb.ScheduledStep{
+					percentageStep(now.Add(time.Hour), 10),
+					percentageStep(now.Add(2*time.Hour), 100),
+				},
+			},
+		},
+		{
+			name: "unnamed feature",
+			req: &featurepb.ScheduleFeatureRequest{
+				Name:  "f",
+				Steps: []*featurepb.ScheduledStep{featureStep(now.Add(time.Hour), &featurepb.Feature{Type: featurepb.Feature_CONSTANT})},
+			},
+		},
+		{
+			name:    "no name",
+			req:     &featurepb.ScheduleFeatureRequest{Steps: []*featurepb.ScheduledStep{enabledStep(now.Add(time.Hour), true)}},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name:    "no steps",
+			req:     &featurepb.ScheduleFeatureRequest{Name: "f"},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name: "past",
+			req: &featurepb.ScheduleFeatureRequest{
+				Name:  "f",
+				Steps: []*featurepb.ScheduledStep{enabledStep(now, true)},
+			},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name: "out of order",
+			req: &featurepb.ScheduleFeatureRequest{
+				Name: "f",
+				Steps: []*featurepb.ScheduledStep{
+					percentageStep(now.Add(2*time.Hour), 10),
+					percentageStep(now.Add(time.Hour), 100),
+				},
+			},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name: "other feature",
+			req: &featurepb.ScheduleFeatureRequest{
+				Name:  "f",
+				Steps: []*featurepb.ScheduledStep{featureStep(now.Add(time.Hour), &featurepb.Feature{Name: "g", Type: featurepb.Feature_CONSTANT})},
+			},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name: "percentage out of range",
+			req: &featurepb.ScheduleFeatureRequest{
+				Name:  "f",
+				Steps: []*featurepb.ScheduledStep{percentageStep(now.Add(time.Hour), 101)},
+			},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name: "empty step",
+			req: &featurepb.ScheduleFeatureRequest{
+				Name:  "f",
+				Steps: []*featurepb.ScheduledStep{{TimeUnixNano: now.Add(time.Hour).UnixNano()}},
+			},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name: "invalid feature",
+			req: &featurepb.ScheduleFeatureRequest{
+				Name:  "f",
+				Steps: []*featurepb.ScheduledStep{featureStep(now.Add(time.Hour), &featurepb.Feature{Type: featurepb.Feature_PERCENTAGE_BASED, Percentage: 150})},
+			},
+			wantErr: ErrInvalidFeature,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ScheduleFeature(as("alice"), tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ScheduleFeature() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ScheduleFeature() error = %v", err)
+			}
+
+			if sc := resp.Schedule; sc.Id == "" || sc.RequestedBy != "alice" || len(sc.Steps) != len(tt.req.Steps) {
+				t.Errorf("ScheduleFeature() = %v, want every step, requested by alice", sc)
+			}
+		})
+	}
+}
+
+func TestRunSchedules(t *testing.T) {
+	ctx := context.Background()
+
+	start := time.Unix(1000, 0)
+	now := start
+
+	s := NewStore()
+	s.now = func() time.Time { return now }
+
+	for _, f := range []*featurepb.Feature{
+		{Name: "ramp", Type: featurepb.Feature_PERCENTAGE_BASED},
+		{Name: "launch", Type: featurepb.Feature_CONSTANT},
+		{Name: "wrong_type", Type: featurepb.Feature_PERCENTAGE_BASED},
+	} {
+		if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{Feature: f}); err != nil {
+			t.Fatalf("SetFeature(%s) error = %v", f.Name, err)
+		}
+	}
+
+	schedule := func(name string, steps ...*featurepb.ScheduledStep) *featurepb.ScheduledChange {
+		t.Helper()
+
+		resp, err := s.ScheduleFeature(as("alice"), &featurepb.ScheduleFeatureRequest{Name: name, Steps: steps})
+		if err != nil {
+			t.Fatalf("ScheduleFeature(%s) error = %v", name, err)
+		}
+
+		return resp.Schedule
+	}
+
+	schedule("ramp",
+		percentageStep(start.Add(1*time.Hour), 1),
+		percentageStep(start.Add(2*time.Hour), 10),
+		percentageStep(start.Add(3*time.Hour), 50),
+		percentageStep(start.Add(4*time.Hour), 100),
+	)
+	schedule("launch", enabledStep(start.Add(2*time.Hour), true))
+	schedule("wrong_type", enabledStep(start.Add(time.Hour), true), percentageStep(start.Add(2*time.Hour), 100))
+	schedule("new", featureStep(start.Add(2*time.Hour), &featurepb.Feature{Type: featurepb.Feature_CONSTANT, Enabled: true}))
+
+	percentage := func(name string) uint32 {
+		t.Helper()
+
+		resp, err := s.GetFeature(ctx, &featurepb.GetFeatureRequest{Name: name})
+		if err != nil {
+			t.Fatalf("GetFeature(%s) error = %v", name, err)
+		}
+
+		return resp.Feature.Percentage
+	}
+
+	// Nothing is due yet.
+	s.runDueSchedules()
+	if got := percentage("ramp"); got != 0 {
+		t.Errorf("ramp percentage before any step = %d, want 0", got)
+	}
+
+	now = start.Add(time.Hour)
+	s.runDueSchedules()
+
+	if got := percentage("ramp"); got != 1 {
+		t.Errorf("ramp percentage after 1h = %d, want 1", got)
+	}
+
+	// A step that can't be made cancels the rest of its schedule.
+	if got := percentage("wrong_type"); got != 0 {
+		t.Errorf("wrong_type percentage = %d, want 0", got)
+	}
+
+	list, err := s.ListScheduledChanges(ctx, &featurepb.ListScheduledChangesRequest{Name: "wrong_type"})
+	if err != nil || len(list.Schedules) != 0 {
+		t.Errorf("ListScheduledChanges(wrong_type) = %v, %v; want none", list, err)
+	}
+
+	// Steps missed while not running are all made, in order.
+	now = start.Add(3 * time.Hour)
+	s.runDueSchedules()
+
+	if got := percentage("ramp"); got != 50 {
+		t.Errorf("ramp percentage after 3h = %d, want 50", got)
+	}
+
+	for _, name := range []string{"launch", "new"} {
+		if on, err := s.Get(name, nil); err != nil || !on {
+			t.Errorf("Get(%s) after 3h = %v, %v; want true", name, on, err)
+		}
+	}
+
+	history, err := s.ListFeatureHistory(ctx, &featurepb.ListFeatureHistoryRequest{Name: "ramp"})
+	if err != nil {
+		t.Fatalf("ListFeatureHistory error = %v", err)
+	}
+
+	if n := len(history.Revisions); n != 4 || history.Revisions[n-1].Actor != "alice" || history.Revisions[n-1].After.Percentage != 50 {
+		t.Errorf("ramp history = %v, want 4 revisions, the last by alice, at 50%%", history.Revisions)
+	}
+
+	list, err = s.ListScheduledChanges(ctx, &featurepb.ListScheduledChangesRequest{})
+	if err != nil || len(list.Schedules) != 1 || len(list.Schedules[0].Steps) != 1 {
+		t.Fatalf("ListScheduledChanges() = %v, %v; want the last step of ramp", list, err)
+	}
+
+	if _, err := s.CancelScheduledChange(ctx, &featurepb.CancelScheduledChangeRequest{Id: list.Schedules[0].Id}); err != nil {
+		t.Fatalf("CancelScheduledChange error = %v", err)
+	}
+
+	if _, err := s.CancelScheduledChange(ctx, &featurepb.CancelScheduledChangeRequest{Id: list.Schedules[0].Id}); !errors.Is(err, ErrNoSchedule) {
+		t.Errorf("CancelScheduledChange() again error = %v, want ErrNoSchedule", err)
+	}
+
+	now = start.Add(4 * time.Hour)
+	s.runDueSchedules()
+
+	if got := percentage("ramp"); got != 50 {
+		t.Errorf("ramp percentage after cancelling = %d, want 50", got)
+	}
+}
+
+func TestRunSchedulesProtected(t *testing.T) {
+	start := time.Unix(1000, 0)
+	now := start
+
+	s := NewStore()
+	s.now = func() time.Time { return now }
+
+	if _, err := s.ScheduleFeature(as("alice"), &featurepb.ScheduleFeatureRequest{
+		Name:  "f",
+		Steps: []*featurepb.ScheduledStep{featureStep(start.Add(time.Hour), &featurepb.Feature{Type: featurepb.Feature_CONSTANT, Protected: true})},
+	}); err != nil {
+		t.Fatalf("ScheduleFeature error = %v", err)
+	}
+
+	now = start.Add(time.Hour)
+	s.runDueSchedules()
+
+	// The step is made by requesting approval, as if alice had made it then.
+	list, err := s.ListPendingChanges(as("bob"), &featurepb.ListPendingChangesRequest{})
+	if err != nil || len(list.Pending) != 1 || list.Pending[0].RequestedBy != "alice" {
+		t.Fatalf("ListPendingChanges() = %v, %v; want a change requested by alice", list, err)
+	}
+
+	schedules, err := s.ListScheduledChanges(as("bob"), &featurepb.ListScheduledChangesRequest{})
+	if err != nil || len(schedules.Schedules) != 0 {
+		t.Errorf("ListScheduledChanges() = %v, %v; want none", schedules, err)
+	}
+}
+
+func TestRunSchedulesBackground(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := NewStore()
+
+	if _, err := s.ScheduleFeature(as("alice"), &featurepb.ScheduleFeatureRequest{
+		Name:  "f",
+		Steps: []*featurepb.ScheduledStep{featureStep(time.Now().Add(50*time.Millisecond), &featurepb.Feature{Type: featurepb.Feature_CONSTANT, Enabled: true})},
+	}); err != nil {
+		t.Fatalf("ScheduleFeature error = %v", err)
+	}
+
+	s.RunSchedules(ctx, 10*time.Millisecond)
+
+	eventually(t, "scheduled change", func() bool {
+		on, err := s.Get("f", nil)
+		return err == nil && on
+	})
+}
+
+func TestSchedulePersistence(t *testing.T) {
+	for _, tt := range durableBackends {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			start := time.Unix(1000, 0)
+			now := start
+
+			open := func() *Store {
+				t.Helper()
+
+				s, err := OpenStore(tt.open(t, dir))
+				if err != nil {
+					t.Fatalf("OpenStore error = %v", err)
+				}
+
+				s.now = func() time.Time { return now }
+				return s
+			}
+
+			s := open()
+
+			if _, err := s.ScheduleFeature(as("alice"), &featurepb.ScheduleFeatureRequest{
+				Name: "f",
+				Steps: []*featurepb.ScheduledStep{
+					featureStep(start.Add(time.Hour), &featurepb.Feature{Type: featurepb.Feature_PERCENTAGE_BASED, Percentage: 10}),
+					percentageStep(start.Add(2*time.Hour), 50),
+					percentageStep(start.Add(3*time.Hour), 100),
+				},
+			}); err != nil {
+				t.Fatalf("ScheduleFeature error = %v", err)
+			}
+
+			now = start.Add(time.Hour)
+			s.runDueSchedules()
+			s.Close()
+
+			// Reopening the store, as if after a restart, recovers the
+			// remaining steps, and makes those that fell due while it was
+			// down.
+			now = start.Add(2 * time.Hour)
+			s = open()
+			defer s.Close()
+
+			list, err := s.ListScheduledChanges(as("bob"), &featurepb.ListScheduledChangesRequest{})
+			if err != nil || len(list.Schedules) != 1 || len(list.Schedules[0].Steps) != 2 {
+				t.Fatalf("ListScheduledChanges() after reopen = %v, %v; want 2 remaining steps", list, err)
+			}
+
+			s.runDueSchedules()
+
+			resp, err := s.GetFeature(as("bob"), &featurepb.GetFeatureRequest{Name: "f"})
+			if err != nil || resp.Feature.Percentage != 50 || resp.Feature.Version != 2 {
+				t.Errorf("GetFeature(f) after reopen = %v, %v; want v2 at 50%%", resp, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Knetic/govaluate"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var (
+	ErrInvalidSegment = errors.New("invalid segment spec")
+	ErrNoSegment      = errors.New("no such segment")
+	ErrSegmentInUse   = errors.New("segment is in use")
+)
+
+// segmentVarPrefix is the prefix of govaluate variables that refer to segment
+// membership. Because ':' is not a valid govaluate variable character,
+// expressions must use the bracketed form, e.g. `[segment:beta_users]`.
+const segmentVarPrefix = "segment:"
+
+// Segment wraps an underlying Segment protobuf message.
+type Segment struct {
+	*featurepb.Segment
+	rules []*govaluate.EvaluableExpression
+}
+
+// Contains returns whether the entity described by the given parameters is a
+// member of the segment.
+//
+// An entity whose key value is in Excluded is never a member. Otherwise, an
+// entity whose key value is in Included is always a member. Otherwise, the
+// entity is a member if any of the segment's rules evaluate to true.
+func (s *Segment) Contains(parameters map[string]interface{}) (bool, error) {
+	if s.Key != "" {
+		if v, ok := parameters[s.Key]; ok {
+			key := keyString(v)
+
+			for _, excluded := range s.Excluded {
+				if key == excluded {
+					return false, nil
+				}
+			}
+
+			for _, included := range s.Included {
+				if key == included {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	if err := s.parseRules(); err != nil {
+		return false, err
+	}
+
+	for i, expr := range s.rules {
+		result, err := expr.Evaluate(parameters)
+		if err != nil {
+			return false, fmt.Errorf("segment %s rule %d: %w", s.Name, i, err)
+		}
+
+		matched, ok := result.(bool)
+		if !ok {
+			return false, fmt.Errorf("segment %s rule %d: expression %v did not return a bool: %v", s.Name, i, expr, result)
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Validate returns an error if the segment has no name, or if any of its rules
+// are empty, fail to parse, or themselves reference segments.
+func (s *Segment) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidSegment)
+	}
+
+	if (len(s.Included) > 0 || len(s.Excluded) > 0) && s.Key == "" {
+		return fmt.Errorf("%w: key is required when including or excluding entities", ErrInvalidSegment)
+	}
+
+	for i, rule := range s.Rules {
+		if rule == "" {
+			return fmt.Errorf("%w: rule %d: expression cannot be empty", ErrInvalidSegment, i)
+		}
+	}
+
+	if err := s.parseRules(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSegment, err)
+	}
+
+	for i, expr := range s.rules {
+		if refs := segmentRefs(expr); len(refs) > 0 {
+			return fmt.Errorf("%w: rule %d: segment rules cannot reference other segments (%s)", ErrInvalidSegment, i, strings.Join(refs, ", "))
+		}
+	}
+
+	return nil
+}
+
+// parseRules parses each of the segment's rule expressions. The parsed
+// expressions are reused, so parseRules will be a no-op on subsequent calls.
+func (s *Segment) parseRules() error {
+	if s.rules != nil {
+		return nil
+	}
+
+	rules := make([]*govaluate.EvaluableExpression, len(s.Rules))
+	for i, rule := range s.Rules {
+		expr, err := govaluate.NewEvaluableExpression(rule)
+		if err != nil {
+			return fmt.Errorf("could not parse expression %s for rule %d: %w", rule, i, err)
+		}
+
+		rules[i] = expr
+	}
+
+	s.rules = rules
+	return nil
+}
+
+// segmentSource looks up segments by name for feature evaluation.
+type segmentSource interface {
+	getSegment(name string) (*Segment, error)
+}
+
+// segmentParameters implements govaluate.Parameters, resolving variables of the
+// form `segment:name` to the membership of the given parameters in that
+// segment, and all other variables from the underlying parameters map.
+type segmentParameters struct {
+	params   map[string]interface{}
+	segments segmentSource
+}
+
+// Get is part of the govaluate.Parameters interface.
+func (p *segmentParameters) Get(name string) (interface{}, error) {
+	if strings.HasPrefix(name, segmentVarPrefix) {
+		seg, err := p.segments.getSegment(strings.TrimPrefix(name, segmentVarPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		return seg.Contains(p.params)
+	}
+
+	return govaluate.MapParameters(p.params).Get(name)
+}
+
+// segmentRefs returns the sorted, de-duplicated names of all segments
+// referenced by the given expressions.
+func segmentRefs(exprs ...*govaluate.EvaluableExpression) []string {
+	set := map[string]bool{}
+	for _, expr := range exprs {
+		if expr == nil {
+			continue
+		}
+
+		for _, v := range expr.Vars() {
+			if strings.HasPrefix(v, segmentVarPrefix) {
+				set[strings.TrimPrefix(v, segmentVarPrefix)] = true
+			}
+		}
+	}
+
+	refs := make([]string, 0, len(set))
+	for name := range set {
+		refs = append(refs, name)
+	}
+
+	sort.Strings(refs)
+	return refs
+}
+
+// SegmentRefs returns the names of all segments referenced by the feature's
+// expressions.
+func (f *Feature) SegmentRefs() ([]string, error) {
+	switch f.Type {
+	case featurepb.Feature_EXPRESSION:
+		if err := f.parseExpression(); err != nil {
+			return nil, err
+		}
+
+		return segmentRefs(f.expr), nil
+	case featurepb.Feature_RULES:
+		if err := f.parseRules(); err != nil {
+			return nil, err
+		}
+
+		return segmentRefs(f.rules...), nil
+	}
+
+	return nil, nil
+}
+
+// parameters wraps the given parameters map so that expressions may reference
+// segments.
+func (f *Feature) parameters(parameters map[string]interface{}) govaluate.Parameters {
+	var segments segmentSource = defaultStore
+	if f.segments != nil {
+		segments = f.segments
+	}
+
+	return &segmentParameters{
+		params:   parameters,
+		segments: segments,
+	}
+}
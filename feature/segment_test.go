@@ -0,0 +1,124 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestSegmentContains(t *testing.T) {
+	seg := &Segment{
+		Segment: &featurepb.Segment{
+			Name:     "beta",
+			Key:      "user_id",
+			Included: []string{"1", "2"},
+			Excluded: []string{"3"},
+			Rules:    []string{"plan == 'enterprise'"},
+		},
+	}
+
+	if err := seg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   bool
+	}{
+		{name: "included", params: map[string]interface{}{"user_id": 1, "plan": "free"}, want: true},
+		{name: "excluded beats rule", params: map[string]interface{}{"user_id": 3, "plan": "enterprise"}, want: false},
+		{name: "rule", params: map[string]interface{}{"user_id": 4, "plan": "enterprise"}, want: true},
+		{name: "no match", params: map[string]interface{}{"user_id": 4, "plan": "free"}, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seg.Contains(tt.params)
+			if err != nil || got != tt.want {
+				t.Errorf("Contains(%v) = %v, %v; want %v", tt.params, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentReferences(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+
+	_, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{
+			Name:       "beta_feature",
+			Type:       featurepb.Feature_EXPRESSION,
+			Expression: "[segment:beta] && country == 'US'",
+		},
+	})
+	if !errors.Is(err, ErrInvalidFeature) {
+		t.Fatalf("SetFeature referencing missing segment error = %v, want ErrInvalidFeature", err)
+	}
+
+	_, err = s.SetSegment(ctx, &featurepb.SetSegmentRequest{
+		Segment: &featurepb.Segment{
+			Name:     "beta",
+			Key:      "user_id",
+			Included: []string{"42"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SetSegment error = %v", err)
+	}
+
+	_, err = s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{
+			Name:       "beta_feature",
+			Type:       featurepb.Feature_EXPRESSION,
+			Expression: "[segment:beta] && country == 'US'",
+		},
+	})
+	if err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	f := s.features["beta_feature"]
+	f.segments = s
+
+	on, err := f.IsEnabledForParameters(map[string]interface{}{"user_id": 42, "country": "US"})
+	if err != nil || !on {
+		t.Errorf("IsEnabledForParameters(42, US) = %v, %v; want true", on, err)
+	}
+
+	on, err = f.IsEnabledForParameters(map[string]interface{}{"user_id": 7, "country": "US"})
+	if err != nil || on {
+		t.Errorf("IsEnabledForParameters(7, US) = %v, %v; want false", on, err)
+	}
+
+	_, err = s.DeleteSegment(ctx, &featurepb.DeleteSegmentRequest{Name: "beta"})
+	if !errors.Is(err, ErrSegmentInUse) {
+		t.Errorf("DeleteSegment(beta) error = %v, want ErrSegmentInUse", err)
+	}
+
+	if _, err := s.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "beta_feature"}); err != nil {
+		t.Fatalf("DeleteFeature error = %v", err)
+	}
+
+	resp, err := s.DeleteSegment(ctx, &featurepb.DeleteSegmentRequest{Name: "beta"})
+	if err != nil || resp.Segment == nil {
+		t.Errorf("DeleteSegment(beta) = %v, %v; want deleted segment", resp, err)
+	}
+}
+
+func TestSegmentValidate(t *testing.T) {
+	seg := &Segment{
+		Segment: &featurepb.Segment{
+			Name:  "nested",
+			Rules: []string{"[segment:other]"},
+		},
+	}
+
+	if err := seg.Validate(); !errors.Is(err, ErrInvalidSegment) {
+		t.Errorf("Validate() error = %v, want ErrInvalidSegment", err)
+	}
+}
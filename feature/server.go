@@ -2,50 +2,387 @@ package feature
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	featurepb "github.com/ajm188/go-ff/proto/feature"
 )
 
+// ErrVersionConflict is returned when a write's expected version does not
+// match the feature's current version, i.e. someone else changed the feature
+// first.
+var ErrVersionConflict = errors.New("version conflict")
+
 var (
-	// Global singleton.
-	inst = &server{
-		features: map[string]*Feature{},
-	}
+	// defaultStore backs the package-level functions.
+	defaultStore = NewStore()
 
-	_ featurepb.FeaturesServer = (*server)(nil)
+	_ featurepb.FeaturesServer = (*Store)(nil)
 )
 
-type server struct {
+// Store holds a set of features and segments. It evaluates features locally
+// (see Get), and serves them over gRPC (see Register). Stores are independent
+// of each other, so a process may hold more than one.
+//
+// Every change is written to the store's Backend before it takes effect.
+//
+// The package-level functions (Get, Init, Watch, etc.) operate on a default
+// Store.
+type Store struct {
 	m        sync.RWMutex
 	features map[string]*Feature
+	segments map[string]*Segment
+	history  map[string][]*featurepb.FeatureRevision
+	events   *eventLog
+	backend  Backend
+
+	// namePattern is the naming policy for features.
+	namePattern *regexp.Regexp
+	// audit, if set, records every change to the store's features.
+	audit *AuditLog
+
+	// pending holds the changes to protected features awaiting approval,
+	// keyed by ID.
+	pending map[string]*featurepb.PendingChange
+	// approvalTimeout is how long pending changes may be approved for.
+	approvalTimeout time.Duration
+	// schedules holds the scheduled changes still to be made, keyed by ID.
+	schedules map[string]*featurepb.ScheduledChange
+
+	// now returns the current time. It may be overridden in tests.
+	now func() time.Time
+}
+
+// NewStore returns an empty Store, backed by memory only.
+func NewStore() *Store {
+	return &Store{
+		features: map[string]*Feature{},
+		segments: map[string]*Segment{},
+		history:  map[string][]*featurepb.FeatureRevision{},
+		events:   newEventLog(),
+		backend:  NewMemoryBackend(),
+
+		namePattern:     DefaultNamePattern,
+		pending:         map[string]*featurepb.PendingChange{},
+		approvalTimeout: DefaultApprovalTimeout,
+		schedules:       map[string]*featurepb.ScheduledChange{},
+		now:             time.Now,
+	}
+}
+
+// OpenStore returns a Store with the features and segments loaded from the
+// given backend, which it will persist all subsequent changes to.
+func OpenStore(backend Backend) (*Store, error) {
+	state, err := backend.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		features: make(map[string]*Feature, len(state.Features)),
+		segments: make(map[string]*Segment, len(state.Segments)),
+		history:  state.History,
+		events:   newEventLog(),
+		backend:  backend,
+
+		namePattern:     DefaultNamePattern,
+		pending:         state.PendingChanges,
+		approvalTimeout: DefaultApprovalTimeout,
+		schedules:       state.Schedules,
+		now:             time.Now,
+	}
+
+	for name, spb := range state.Segments {
+		seg := &Segment{Segment: spb}
+		if err := seg.parseRules(); err != nil {
+			return nil, fmt.Errorf("segment %s: %w", name, err)
+		}
+
+		s.segments[name] = seg
+	}
+
+	for name, fpb := range state.Features {
+		f := &Feature{Feature: fpb, segments: s}
+		if err := f.parse(); err != nil {
+			return nil, fmt.Errorf("feature %s: %w", name, err)
+		}
+
+		s.features[name] = f
+	}
+
+	return s, nil
+}
+
+// Close closes the store's backend.
+func (s *Store) Close() error {
+	return s.backend.Close()
 }
 
 // DeleteFeature is part of the featurepb.FeaturesServer interface.
-func (s *server) DeleteFeature(ctx context.Context, req *featurepb.DeleteFeatureRequest) (*featurepb.DeleteFeatureResponse, error) {
+func (s *Store) DeleteFeature(ctx context.Context, req *featurepb.DeleteFeatureRequest) (*featurepb.DeleteFeatureResponse, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	if feat, ok := s.features[req.Name]; ok {
-		delete(s.features, req.Name)
+	if err := s.checkVersionLocked(req.Name, req.ExpectedVersion, false); err != nil {
+		return nil, rpcError(err)
+	}
+
+	before, pending, err := s.deleteFeatureLocked(callerFromContext(ctx, "DeleteFeature"), req.Name)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	return &featurepb.DeleteFeatureResponse{
+		Feature: before,
+		Pending: pending,
+	}, nil
+}
+
+// deleteFeatureLocked deletes the named feature, recording the deletion in its
+// history, and returns the deleted feature (or nil if there was no such
+// feature). If the feature is protected, the deletion is held for approval
+// instead, and returned. Callers must hold s.m.
+func (s *Store) deleteFeatureLocked(c *caller, name string) (*featurepb.Feature, *featurepb.PendingChange, error) {
+	feat, ok := s.features[name]
+	if !ok {
+		return nil, nil, nil
+	}
+
+	pending, err := s.commitLocked(c, []*featureChange{{name: name, before: feat.Feature}})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return feat.Feature, pending, nil
+}
+
+// featureChange is a validated change to a single feature, which has not yet
+// been committed. After is nil for a deletion.
+type featureChange struct {
+	name   string
+	before *featurepb.Feature
+	after  *Feature
+}
+
+// commitLocked persists the given changes, and a revision for each, with a
+// single call to the backend, and then makes them visible. Either all of the
+// changes are made, or (if the backend returns an error) none of them are.
+// Each feature may appear in at most one change. Any extra mutations are
+// persisted along with the changes.
+//
+// If any of the changes are to protected features, and c has not approved
+// them, none of them are made: they are held for approval instead (still
+// persisting the extra mutations), and the pending change is returned.
+// Callers must hold s.m.
+func (s *Store) commitLocked(c *caller, changes []*featureChange, extra ...*Mutation) (*featurepb.PendingChange, error) {
+	if c.approvedBy == "" && requiresApproval(changes) {
+		return s.requestApprovalLocked(c, changes, extra...)
+	}
+
+	var (
+		mutations = make([]*Mutation, 0, 2*len(changes))
+		revisions = make([]*featurepb.FeatureRevision, 0, len(changes))
+	)
+
+	for _, change := range changes {
+		var (
+			after *featurepb.Feature
+			mut   = &Mutation{Type: DeleteFeatureMutation, Name: change.name}
+		)
+
+		if change.after != nil {
+			after = change.after.Feature
+			mut = &Mutation{Type: SetFeatureMutation, Feature: after}
+		}
+
+		rev := s.newRevisionLocked(c.actor, change.name, change.before, after)
+		revisions = append(revisions, rev)
+		mutations = append(mutations, mut, &Mutation{Type: AppendRevisionMutation, Revision: rev})
+	}
+
+	mutations = append(mutations, extra...)
+
+	rollback, err := s.auditLocked(c, revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.backend.Apply(mutations...); err != nil {
+		rollback()
+		return nil, err
+	}
+
+	for i, change := range changes {
+		s.appendHistoryLocked(revisions[i])
+
+		if change.after == nil {
+			delete(s.features, change.name)
+			s.events.publish(featurepb.FeatureEvent_DELETE, change.before)
+			continue
+		}
+
+		s.features[change.name] = change.after
+		s.events.publish(featurepb.FeatureEvent_SET, change.after.Feature)
+	}
+
+	return nil, nil
+}
+
+// checkVersionLocked returns an ErrVersionConflict error if expected is
+// non-zero and does not match the named feature's current version (which is
+// zero if the feature does not exist), or if absent is set and the feature
+// exists. Callers must hold s.m.
+func (s *Store) checkVersionLocked(name string, expected uint64, absent bool) error {
+	if expected == 0 && !absent {
+		return nil
+	}
+
+	var current uint64
+	if feat, ok := s.features[name]; ok {
+		current = feat.Version
+	}
+
+	var err error
+	switch {
+	case absent && current != 0:
+		err = fmt.Errorf("%w: %s already exists at version %d, expected it not to exist", ErrVersionConflict, name, current)
+	case absent && expected != 0:
+		err = fmt.Errorf("%w: %s cannot both be expected at version %d and not exist", ErrVersionConflict, name, expected)
+	case current == expected:
+		return nil
+	case current == 0:
+		err = fmt.Errorf("%w: %s was deleted, expected version %d", ErrVersionConflict, name, expected)
+	default:
+		err = fmt.Errorf("%w: %s is at version %d, expected version %d", ErrVersionConflict, name, current, expected)
+	}
 
-		return &featurepb.DeleteFeatureResponse{
-			Feature: feat.Feature,
-		}, nil
+	metadata := map[string]string{
+		"feature":          name,
+		"current_version":  strconv.FormatUint(current, 10),
+		"expected_version": strconv.FormatUint(expected, 10),
+	}
+	if absent {
+		metadata["expect_absent"] = "true"
 	}
 
-	return &featurepb.DeleteFeatureResponse{}, nil
+	return &statusError{
+		code:     codes.FailedPrecondition,
+		err:      err,
+		metadata: metadata,
+	}
+}
+
+// nextVersionLocked returns the version for the next revision of the named
+// feature. Callers must hold s.m.
+func (s *Store) nextVersionLocked(name string) uint64 {
+	var version uint64
+
+	if feat, ok := s.features[name]; ok {
+		version = feat.Version
+	}
+
+	// The feature may have been deleted since its last revision, or (for
+	// stores persisted before history was recorded) have no history at all.
+	if history := s.history[name]; len(history) > 0 && history[len(history)-1].Version > version {
+		version = history[len(history)-1].Version
+	}
+
+	return version + 1
+}
+
+// newRevisionLocked returns a new revision of the named feature, with the
+// next version. If after is non-nil, its version is set to match. Callers must
+// hold s.m.
+func (s *Store) newRevisionLocked(actor string, name string, before, after *featurepb.Feature) *featurepb.FeatureRevision {
+	version := s.nextVersionLocked(name)
+	if after != nil {
+		after.Version = version
+	}
+
+	return &featurepb.FeatureRevision{
+		Name:         name,
+		Version:      version,
+		TimeUnixNano: s.now().UnixNano(),
+		Actor:        actor,
+		Before:       before,
+		After:        after,
+	}
+}
+
+// appendHistoryLocked adds a revision to the in-memory history, once it has
+// been persisted. Callers must hold s.m.
+func (s *Store) appendHistoryLocked(rev *featurepb.FeatureRevision) {
+	s.history[rev.Name] = append(s.history[rev.Name], rev)
+}
+
+// EvaluateFeature is part of the featurepb.FeaturesServer interface. Evaluation
+// errors, including a missing feature, are reported in the returned detail
+// rather than as an RPC error.
+func (s *Store) EvaluateFeature(ctx context.Context, req *featurepb.EvaluateFeatureRequest) (*featurepb.EvaluateFeatureResponse, error) {
+	return &featurepb.EvaluateFeatureResponse{
+		Detail: s.evaluate(req.Name, req.Parameters.AsMap()).Proto(),
+	}, nil
+}
+
+// EvaluateFeatures is part of the featurepb.FeaturesServer interface. Like
+// EvaluateFeature, evaluation errors are reported per-feature in the returned
+// details.
+func (s *Store) EvaluateFeatures(ctx context.Context, req *featurepb.EvaluateFeaturesRequest) (*featurepb.EvaluateFeaturesResponse, error) {
+	names := req.Names
+	if len(names) == 0 {
+		s.m.RLock()
+		names = make([]string, 0, len(s.features))
+		for name := range s.features {
+			names = append(names, name)
+		}
+		s.m.RUnlock()
+
+		sort.Strings(names)
+	}
+
+	parameters := req.Parameters.AsMap()
+	details := make([]*featurepb.EvaluationDetail, 0, len(names))
+
+	for _, name := range names {
+		details = append(details, s.evaluate(name, parameters).Proto())
+	}
+
+	return &featurepb.EvaluateFeaturesResponse{
+		Details: details,
+	}, nil
+}
+
+// evaluate backs GetDetail and the EvaluateFeature(s) RPCs.
+func (s *Store) evaluate(name string, parameters map[string]interface{}) *Detail {
+	feat, err := s.getFeature(name)
+	if err != nil {
+		return &Detail{
+			Name:      name,
+			Reason:    featurepb.EvaluationDetail_FEATURE_NOT_FOUND,
+			Bucket:    -1,
+			RuleIndex: -1,
+			Err:       err,
+		}
+	}
+
+	return feat.Detail(parameters)
 }
 
 // GetFeature is part of the featurepb.FeaturesServer interface.
-func (s *server) GetFeature(ctx context.Context, req *featurepb.GetFeatureRequest) (*featurepb.GetFeatureResponse, error) {
+func (s *Store) GetFeature(ctx context.Context, req *featurepb.GetFeatureRequest) (*featurepb.GetFeatureResponse, error) {
 	feat, err := s.getFeature(req.Name)
 	if err != nil {
-		return nil, err
+		return nil, rpcError(err)
 	}
 
 	return &featurepb.GetFeatureResponse{
@@ -54,8 +391,8 @@ func (s *server) GetFeature(ctx context.Context, req *featurepb.GetFeatureReques
 }
 
 // getFeature separates the concurrent-safe Feature lookup from the gRPC service
-// implementation so this can be reused by the package-level Get function.
-func (s *server) getFeature(name string) (*Feature, error) {
+// implementation so this can be reused by Get and friends.
+func (s *Store) getFeature(name string) (*Feature, error) {
 	s.m.RLock()
 	defer s.m.RUnlock()
 
@@ -67,7 +404,7 @@ func (s *server) getFeature(name string) (*Feature, error) {
 }
 
 // GetFeatures is part of the featurepb.FeaturesServer interface.
-func (s *server) GetFeatures(ctx context.Context, req *featurepb.GetFeaturesRequest) (*featurepb.GetFeaturesResponse, error) {
+func (s *Store) GetFeatures(ctx context.Context, req *featurepb.GetFeaturesRequest) (*featurepb.GetFeaturesResponse, error) {
 	s.m.RLock()
 	defer s.m.RUnlock()
 
@@ -95,41 +432,311 @@ func (s *server) GetFeatures(ctx context.Context, req *featurepb.GetFeaturesRequ
 }
 
 // SetFeature is part of the featurepb.FeaturesServer interface.
-func (s *server) SetFeature(ctx context.Context, req *featurepb.SetFeatureRequest) (*featurepb.SetFeatureResponse, error) {
+func (s *Store) SetFeature(ctx context.Context, req *featurepb.SetFeatureRequest) (*featurepb.SetFeatureResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if req.Feature != nil {
+		if err := s.checkVersionLocked(req.Feature.Name, req.ExpectedVersion, req.ExpectAbsent); err != nil {
+			return nil, rpcError(err)
+		}
+	}
+
+	before, after, pending, err := s.setFeatureLocked(callerFromContext(ctx, "SetFeature"), req.Feature)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	return &featurepb.SetFeatureResponse{
+		Before:  before,
+		After:   after,
+		Pending: pending,
+	}, nil
+}
+
+// setFeatureLocked validates and stores a copy of the given feature, recording
+// the change in its history, and returns the feature before and after the
+// change. If the feature is protected, the change is held for approval
+// instead, and returned. Callers must hold s.m.
+func (s *Store) setFeatureLocked(c *caller, fpb *featurepb.Feature) (before, after *featurepb.Feature, pending *featurepb.PendingChange, err error) {
+	f, err := s.validateFeatureLocked(fpb)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if feat, ok := s.features[f.Name]; ok {
+		before = feat.Feature
+	}
+
+	pending, err = s.commitLocked(c, []*featureChange{{name: f.Name, before: before, after: f}})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return before, f.Feature, pending, nil
+}
+
+// validateFeatureLocked checks that the given feature can be stored, and
+// returns a copy of it ready to store, with its expression or rules parsed.
+// Callers must hold s.m.
+func (s *Store) validateFeatureLocked(fpb *featurepb.Feature) (*Feature, error) {
+	v := s.validationLocked()
+
+	f := s.newFeatureLocked(v, fpb)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// newFeatureLocked returns a copy of the given feature to store, recording
+// any problems with it in v. Callers must hold s.m.
+func (s *Store) newFeatureLocked(v *validation, fpb *featurepb.Feature) *Feature {
+	if fpb == nil {
+		v.addf("", "", "feature cannot be empty")
+		return nil
+	}
+
+	f := &Feature{Feature: proto.Clone(fpb).(*featurepb.Feature), segments: s}
+	v.feature(f)
+
+	return f
+}
+
+// validationLocked returns a validation with the store's naming policy, that
+// checks segment references against the store's segments. Callers must hold
+// s.m.
+func (s *Store) validationLocked() *validation {
+	return &validation{
+		namePattern: s.namePattern,
+		segments:    s.segments,
+	}
+}
+
+// SetNamePattern sets the naming policy that features must follow to be
+// stored. It applies to subsequent writes only.
+func (s *Store) SetNamePattern(re *regexp.Regexp) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
+	s.namePattern = re
+}
+
+// WatchFeatures is part of the featurepb.FeaturesServer interface. It streams a
+// snapshot of all features (unless resuming from a revision of the current
+// epoch that the server still has events for), followed by every subsequent
+// change, until the client disconnects.
+func (s *Store) WatchFeatures(req *featurepb.WatchFeaturesRequest, stream featurepb.Features_WatchFeaturesServer) error {
 	var (
-		before *featurepb.Feature
-		after  = proto.Clone(req.Feature).(*featurepb.Feature)
+		epoch        = req.Epoch
+		revision     = req.FromRevision
+		needSnapshot = revision == 0
 	)
 
-	if feat, ok := s.features[req.Feature.Name]; ok {
-		before = feat.Feature
+	for {
+		lagged, err := s.watch(stream, epoch, &revision, needSnapshot)
+		if err != nil || !lagged {
+			return rpcError(err)
+		}
+
+		// We fell behind. Resubscribe from the last revision we sent, which is
+		// of the current epoch; if the event log has been truncated past it,
+		// we'll get a snapshot.
+		epoch = s.events.epoch
+		needSnapshot = false
 	}
+}
 
-	f := &Feature{Feature: after}
+// watch subscribes to feature events, sends the initial snapshot or backlog
+// of events after *revision of the given epoch, and then streams events until
+// the context is done, a send fails, or the subscriber lags (in which case
+// lagged is true). *revision is updated with every event sent.
+func (s *Store) watch(stream featurepb.Features_WatchFeaturesServer, epoch string, revision *uint64, needSnapshot bool) (lagged bool, err error) {
+	s.m.Lock()
 
-	switch f.Type {
-	case featurepb.Feature_PERCENTAGE_BASED:
-		if f.Percentage < 0 || f.Percentage > 100 {
-			return nil, fmt.Errorf("%w percentage must be in [0, 100]; have %d", ErrInvalidFeature, f.Percentage)
+	var snapshot *featurepb.FeatureSnapshot
+
+	backlog, ok := s.events.since(epoch, *revision)
+	if needSnapshot || !ok {
+		backlog = nil
+		snapshot = &featurepb.FeatureSnapshot{
+			Epoch:    s.events.epoch,
+			Revision: s.events.revision,
+			Features: make([]*featurepb.Feature, 0, len(s.features)),
 		}
-	case featurepb.Feature_EXPRESSION:
-		if err := f.parseExpression(); err != nil {
-			return nil, fmt.Errorf("could not parse expression %s: %w", f.Expression, err)
+
+		for _, feat := range s.features {
+			snapshot.Features = append(snapshot.Features, feat.Feature)
 		}
 	}
 
-	s.features[req.Feature.Name] = f
-	return &featurepb.SetFeatureResponse{
+	sub := s.events.subscribe()
+	s.m.Unlock()
+
+	defer func() {
+		s.m.Lock()
+		defer s.m.Unlock()
+
+		s.events.unsubscribe(sub)
+	}()
+
+	if snapshot != nil {
+		if err := stream.Send(&featurepb.WatchFeaturesResponse{Snapshot: snapshot}); err != nil {
+			return false, err
+		}
+
+		*revision = snapshot.Revision
+	}
+
+	for _, event := range backlog {
+		if err := stream.Send(&featurepb.WatchFeaturesResponse{Event: event}); err != nil {
+			return false, err
+		}
+
+		*revision = event.Revision
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return false, nil
+		case event, ok := <-sub.ch:
+			if !ok {
+				return true, nil
+			}
+
+			if err := stream.Send(&featurepb.WatchFeaturesResponse{Event: event}); err != nil {
+				return false, err
+			}
+
+			*revision = event.Revision
+		}
+	}
+}
+
+// DeleteSegment is part of the featurepb.FeaturesServer interface. It fails if
+// any feature references the segment.
+func (s *Store) DeleteSegment(ctx context.Context, req *featurepb.DeleteSegmentRequest) (*featurepb.DeleteSegmentResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	seg, ok := s.segments[req.Name]
+	if !ok {
+		return &featurepb.DeleteSegmentResponse{}, nil
+	}
+
+	var users []string
+	for name, feat := range s.features {
+		refs, err := feat.SegmentRefs()
+		if err != nil {
+			continue
+		}
+
+		for _, ref := range refs {
+			if ref == req.Name {
+				users = append(users, name)
+				break
+			}
+		}
+	}
+
+	if len(users) > 0 {
+		sort.Strings(users)
+		return nil, rpcError(fmt.Errorf("%w: %s is referenced by feature(s) %s", ErrSegmentInUse, req.Name, strings.Join(users, ", ")))
+	}
+
+	if err := s.backend.Apply(&Mutation{Type: DeleteSegmentMutation, Name: req.Name}); err != nil {
+		return nil, rpcError(err)
+	}
+
+	delete(s.segments, req.Name)
+
+	return &featurepb.DeleteSegmentResponse{
+		Segment: seg.Segment,
+	}, nil
+}
+
+// GetSegment is part of the featurepb.FeaturesServer interface.
+func (s *Store) GetSegment(ctx context.Context, req *featurepb.GetSegmentRequest) (*featurepb.GetSegmentResponse, error) {
+	seg, err := s.getSegment(req.Name)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	return &featurepb.GetSegmentResponse{
+		Segment: seg.Segment,
+	}, nil
+}
+
+// getSegment is the segment analogue of getFeature. It also allows the store
+// to act as a segmentSource for feature evaluation.
+func (s *Store) getSegment(name string) (*Segment, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	if seg, ok := s.segments[name]; ok {
+		return seg, nil
+	}
+
+	return nil, fmt.Errorf("%w with name %s", ErrNoSegment, name)
+}
+
+// GetSegments is part of the featurepb.FeaturesServer interface.
+func (s *Store) GetSegments(ctx context.Context, req *featurepb.GetSegmentsRequest) (*featurepb.GetSegmentsResponse, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	segments := make([]*featurepb.Segment, 0, len(s.segments))
+	for _, seg := range s.segments {
+		segments = append(segments, seg.Segment)
+	}
+
+	return &featurepb.GetSegmentsResponse{
+		Segments: segments,
+	}, nil
+}
+
+// SetSegment is part of the featurepb.FeaturesServer interface.
+func (s *Store) SetSegment(ctx context.Context, req *featurepb.SetSegmentRequest) (*featurepb.SetSegmentResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if req.Segment == nil {
+		return nil, rpcError(fmt.Errorf("%w: segment cannot be empty", ErrInvalidSegment))
+	}
+
+	var (
+		before *featurepb.Segment
+		after  = proto.Clone(req.Segment).(*featurepb.Segment)
+	)
+
+	if seg, ok := s.segments[after.Name]; ok {
+		before = seg.Segment
+	}
+
+	seg := &Segment{Segment: after}
+	if err := seg.Validate(); err != nil {
+		return nil, rpcError(err)
+	}
+
+	if err := s.backend.Apply(&Mutation{Type: SetSegmentMutation, Segment: after}); err != nil {
+		return nil, rpcError(err)
+	}
+
+	s.segments[after.Name] = seg
+	return &featurepb.SetSegmentResponse{
 		Before: before,
 		After:  after,
 	}, nil
 }
 
-// RegisterServer adds the global feature server instance to the given gRPC
-// server.
+// Register adds the store to the given gRPC server as a Features service.
+func (s *Store) Register(r grpc.ServiceRegistrar) {
+	featurepb.RegisterFeaturesService(r, s)
+}
+
+// RegisterServer adds the default store to the given gRPC server.
 func RegisterServer(s *grpc.Server) {
-	featurepb.RegisterFeaturesServer(s, inst)
+	defaultStore.Register(s)
 }
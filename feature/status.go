@@ -0,0 +1,169 @@
+package feature
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the errdetails.ErrorInfo attached to errors
+// returned by the Features service.
+const ErrorDomain = "go-ff"
+
+// rpcErrors maps the package's errors to the gRPC status code they are reported
+// with, and the reason given in the status's ErrorInfo detail, which clients
+// use to recover the error (see FromRPCError). The first match wins, so more
+// specific errors come first.
+var rpcErrors = []struct {
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{ErrNoFeature, codes.NotFound, "NO_FEATURE"},
+	{ErrNoSegment, codes.NotFound, "NO_SEGMENT"},
+	{ErrNoRevision, codes.NotFound, "NO_REVISION"},
+	{ErrNoPendingChange, codes.NotFound, "NO_PENDING_CHANGE"},
+	{ErrNoSchedule, codes.NotFound, "NO_SCHEDULE"},
+	{ErrVersionConflict, codes.FailedPrecondition, "VERSION_CONFLICT"},
+	{ErrSegmentInUse, codes.FailedPrecondition, "SEGMENT_IN_USE"},
+	{ErrNoAuditLog, codes.FailedPrecondition, "NO_AUDIT_LOG"},
+	{ErrApprovalUnauthenticated, codes.FailedPrecondition, "APPROVAL_UNAUTHENTICATED"},
+	{ErrInvalidFeature, codes.InvalidArgument, "INVALID_FEATURE"},
+	{ErrUnknownFeatureType, codes.InvalidArgument, "UNKNOWN_FEATURE_TYPE"},
+	{ErrInvalidSegment, codes.InvalidArgument, "INVALID_SEGMENT"},
+	{ErrInvalidOperation, codes.InvalidArgument, "INVALID_OPERATION"},
+	{ErrInvalidSchedule, codes.InvalidArgument, "INVALID_SCHEDULE"},
+	{ErrValueType, codes.InvalidArgument, "VALUE_TYPE"},
+	{ErrNotVariant, codes.InvalidArgument, "NOT_VARIANT"},
+	{ErrUnauthenticated, codes.Unauthenticated, "UNAUTHENTICATED"},
+	{ErrPermissionDenied, codes.PermissionDenied, "PERMISSION_DENIED"},
+	{ErrSelfApproval, codes.PermissionDenied, "SELF_APPROVAL"},
+}
+
+// statusError wraps an error with the gRPC status code it should be reported
+// with, while still allowing in-process callers to use errors.Is on it.
+type statusError struct {
+	code codes.Code
+	err  error
+	// metadata is added to the status's ErrorInfo detail.
+	metadata map[string]string
+}
+
+func withCode(code codes.Code, err error) error {
+	return &statusError{code: code, err: err}
+}
+
+func (e *statusError) Error() string { return e.err.Error() }
+func (e *statusError) Unwrap() error { return e.err }
+
+// GRPCStatus allows the gRPC server to convert the error to a status with the
+// right code. If the error is one of the package's errors, the status has an
+// ErrorInfo detail saying which, and, for a ValidationError, a BadRequest
+// detail listing every problem.
+func (e *statusError) GRPCStatus() *status.Status {
+	var details []proto.Message
+
+	for _, re := range rpcErrors {
+		if errors.Is(e.err, re.err) {
+			details = append(details, &errdetails.ErrorInfo{
+				Reason:   re.reason,
+				Domain:   ErrorDomain,
+				Metadata: e.metadata,
+			})
+
+			break
+		}
+	}
+
+	var verr *ValidationError
+	if errors.As(e.err, &verr) {
+		details = append(details, verr.badRequest())
+	}
+
+	st := status.New(e.code, e.err.Error())
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+
+	return st
+}
+
+// rpcError converts an error from the store into one that the gRPC server will
+// report with the right status code. The Features RPCs return their errors
+// through it.
+func rpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var serr *statusError
+	if errors.As(err, &serr) {
+		if serr == err {
+			return err
+		}
+
+		return &statusError{code: serr.code, err: err, metadata: serr.metadata}
+	}
+
+	for _, re := range rpcErrors {
+		if errors.Is(err, re.err) {
+			return withCode(re.code, err)
+		}
+	}
+
+	return err
+}
+
+// remoteError is an error returned by a Features RPC, converted back to the
+// package error it was caused by.
+type remoteError struct {
+	st  *status.Status
+	err error
+}
+
+func (e *remoteError) Error() string              { return e.st.Err().Error() }
+func (e *remoteError) Unwrap() error              { return e.err }
+func (e *remoteError) GRPCStatus() *status.Status { return e.st }
+
+// FromRPCError converts an error returned by a Features RPC back into an error
+// that wraps the package error (ErrNoFeature, ErrVersionConflict, etc.) that
+// caused it, so that callers can check for it with errors.Is. The result still
+// carries the gRPC status, so status.Code and friends work on it too.
+//
+// Errors that did not come from the Features service are returned unchanged.
+func FromRPCError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.OK {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != ErrorDomain {
+			continue
+		}
+
+		for _, re := range rpcErrors {
+			if re.reason == info.Reason {
+				return &remoteError{st: st, err: re.err}
+			}
+		}
+	}
+
+	return err
+}
+
+// IsError reports whether err, which may have been returned by a Features RPC,
+// is or was caused by target. It is shorthand for
+// errors.Is(FromRPCError(err), target), e.g.:
+//
+//	_, err := client.GetFeature(ctx, req)
+//	if feature.IsError(err, feature.ErrNoFeature) {
+//		...
+//	}
+func IsError(err error, target error) bool {
+	return errors.Is(FromRPCError(err), target)
+}
@@ -0,0 +1,202 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestRPCErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := NewStore()
+	s.Init(map[string]*Feature{
+		"f": {Feature: &featurepb.Feature{Type: featurepb.Feature_CONSTANT, Enabled: true}},
+	})
+
+	client := newTestClient(t, s)
+
+	if _, err := client.SetSegment(ctx, &featurepb.SetSegmentRequest{
+		Segment: &featurepb.Segment{Name: "s", Key: "user_id", Included: []string{"1"}},
+	}); err != nil {
+		t.Fatalf("SetSegment error = %v", err)
+	}
+
+	if _, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "g", Type: featurepb.Feature_EXPRESSION, Expression: "[segment:s]"},
+	}); err != nil {
+		t.Fatalf("SetFeature error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+		wantErr  error
+	}{
+		{
+			name: "missing feature",
+			call: func() error {
+				_, err := client.GetFeature(ctx, &featurepb.GetFeatureRequest{Name: "missing"})
+				return err
+			},
+			wantCode: codes.NotFound,
+			wantErr:  ErrNoFeature,
+		},
+		{
+			name: "missing segment",
+			call: func() error {
+				_, err := client.GetSegment(ctx, &featurepb.GetSegmentRequest{Name: "missing"})
+				return err
+			},
+			wantCode: codes.NotFound,
+			wantErr:  ErrNoSegment,
+		},
+		{
+			name: "missing revision",
+			call: func() error {
+				_, err := client.RollbackFeature(ctx, &featurepb.RollbackFeatureRequest{Name: "f", Version: 10})
+				return err
+			},
+			wantCode: codes.NotFound,
+			wantErr:  ErrNoRevision,
+		},
+		{
+			name: "invalid feature",
+			call: func() error {
+				_, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+					Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_PERCENTAGE_BASED, Percentage: 101},
+				})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+			wantErr:  ErrInvalidFeature,
+		},
+		{
+			name: "unparseable expression",
+			call: func() error {
+				_, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+					Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_EXPRESSION, Expression: "x >"},
+				})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+			wantErr:  ErrInvalidFeature,
+		},
+		{
+			name: "invalid operation",
+			call: func() error {
+				_, err := client.ApplyFeatures(ctx, &featurepb.ApplyFeaturesRequest{
+					Operations: []*featurepb.FeatureOperation{{}},
+				})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+			wantErr:  ErrInvalidOperation,
+		},
+		{
+			name: "version conflict",
+			call: func() error {
+				_, err := client.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "f", ExpectedVersion: 5})
+				return err
+			},
+			wantCode: codes.FailedPrecondition,
+			wantErr:  ErrVersionConflict,
+		},
+		{
+			name: "version conflict in batch",
+			call: func() error {
+				op := setOp("f", false)
+				op.ExpectedVersion = 5
+
+				_, err := client.ApplyFeatures(ctx, &featurepb.ApplyFeaturesRequest{
+					Operations: []*featurepb.FeatureOperation{op},
+				})
+				return err
+			},
+			wantCode: codes.FailedPrecondition,
+			wantErr:  ErrVersionConflict,
+		},
+		{
+			name: "segment in use",
+			call: func() error {
+				_, err := client.DeleteSegment(ctx, &featurepb.DeleteSegmentRequest{Name: "s"})
+				return err
+			},
+			wantCode: codes.FailedPrecondition,
+			wantErr:  ErrSegmentInUse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %v, want %v (error = %v)", code, tt.wantCode, err)
+			}
+
+			if !IsError(err, tt.wantErr) {
+				t.Errorf("IsError(%v, %v) = false, want true", err, tt.wantErr)
+			}
+
+			for _, rpcErr := range rpcErrors {
+				if rpcErr.err != tt.wantErr && IsError(err, rpcErr.err) {
+					t.Errorf("IsError(%v, %v) = true, want false", err, rpcErr.err)
+				}
+			}
+
+			// The converted error keeps its status.
+			if code := status.Code(FromRPCError(err)); code != tt.wantCode {
+				t.Errorf("FromRPCError code = %v, want %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRPCErrorDetails(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := NewStore()
+	client := newTestClient(t, s)
+
+	_, err := client.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "f", ExpectedVersion: 3})
+
+	var info *errdetails.ErrorInfo
+	for _, detail := range status.Convert(err).Details() {
+		if i, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = i
+		}
+	}
+
+	if info == nil {
+		t.Fatalf("status of %v has no ErrorInfo", err)
+	}
+
+	if info.Domain != ErrorDomain || info.Reason != "VERSION_CONFLICT" {
+		t.Errorf("ErrorInfo = %v, want reason VERSION_CONFLICT in domain %s", info, ErrorDomain)
+	}
+
+	if md := info.Metadata; md["feature"] != "f" || md["current_version"] != "0" || md["expected_version"] != "3" {
+		t.Errorf("ErrorInfo metadata = %v, want feature f at version 0, expected 3", md)
+	}
+
+	// Errors that don't come from the service are left alone.
+	other := errors.New("boom")
+	if got := FromRPCError(other); got != other {
+		t.Errorf("FromRPCError(%v) = %v, want it unchanged", other, got)
+	}
+
+	if FromRPCError(nil) != nil {
+		t.Error("FromRPCError(nil) != nil")
+	}
+}
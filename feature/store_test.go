@@ -0,0 +1,173 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestStoreIsolation(t *testing.T) {
+	ctx := context.Background()
+
+	a, b := NewStore(), NewStore()
+
+	for _, s := range []*Store{a, b} {
+		if _, err := s.SetSegment(ctx, &featurepb.SetSegmentRequest{
+			Segment: &featurepb.Segment{Name: "staff", Key: "user_id"},
+		}); err != nil {
+			t.Fatalf("SetSegment error = %v", err)
+		}
+	}
+
+	// Only a's segment includes user 1.
+	if _, err := a.SetSegment(ctx, &featurepb.SetSegmentRequest{
+		Segment: &featurepb.Segment{Name: "staff", Key: "user_id", Included: []string{"1"}},
+	}); err != nil {
+		t.Fatalf("SetSegment error = %v", err)
+	}
+
+	staffOnly := &Feature{Feature: &featurepb.Feature{
+		Name:       "staff_only",
+		Type:       featurepb.Feature_EXPRESSION,
+		Expression: "[segment:staff]",
+	}}
+
+	a.Init(map[string]*Feature{"staff_only": staffOnly})
+	b.Init(map[string]*Feature{
+		"staff_only": staffOnly,
+		"b_only":     {Feature: &featurepb.Feature{Name: "b_only", Type: featurepb.Feature_CONSTANT, Enabled: true}},
+	})
+
+	params := map[string]interface{}{"user_id": 1}
+
+	if on, err := a.Get("staff_only", params); err != nil || !on {
+		t.Errorf("a.Get(staff_only) = %v, %v; want true", on, err)
+	}
+
+	if on, err := b.Get("staff_only", params); err != nil || on {
+		t.Errorf("b.Get(staff_only) = %v, %v; want false", on, err)
+	}
+
+	if _, err := a.Get("b_only", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("a.Get(b_only) error = %v, want ErrNoFeature", err)
+	}
+
+	if _, err := Get("b_only", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(b_only) on the default store error = %v, want ErrNoFeature", err)
+	}
+}
+
+func TestInitEmptyFeatures(t *testing.T) {
+	s := NewStore()
+	s.Init(map[string]*Feature{
+		"a":     {Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT, Enabled: true}},
+		"nil":   nil,
+		"empty": {},
+	})
+
+	if on, err := s.Get("a", nil); err != nil || !on {
+		t.Errorf("Get(a) = %v, %v; want true", on, err)
+	}
+
+	for _, name := range []string{"nil", "empty"} {
+		if _, err := s.Get(name, nil); !errors.Is(err, ErrNoFeature) {
+			t.Errorf("Get(%s) error = %v, want ErrNoFeature", name, err)
+		}
+	}
+}
+
+func TestStoreRegister(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := NewStore()
+	s.Init(map[string]*Feature{
+		"a": {Feature: &featurepb.Feature{Name: "a", Type: featurepb.Feature_CONSTANT, Enabled: true}},
+	})
+
+	client := newTestClient(t, s)
+
+	resp, err := client.GetFeature(ctx, &featurepb.GetFeatureRequest{Name: "a"})
+	if err != nil || !resp.Feature.Enabled {
+		t.Errorf("GetFeature(a) = %v, %v; want enabled feature", resp, err)
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := NewStore()
+	client := newTestClient(t, s)
+
+	set := func(enabled bool, expected uint64) error {
+		_, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+			Feature:         &featurepb.Feature{Name: "f", Type: featurepb.Feature_CONSTANT, Enabled: enabled},
+			ExpectedVersion: expected,
+		})
+		return err
+	}
+
+	if err := set(true, 0); err != nil {
+		t.Fatalf("unconditional SetFeature error = %v", err)
+	}
+
+	if err := set(false, 1); err != nil {
+		t.Fatalf("SetFeature at current version error = %v", err)
+	}
+
+	// A second writer that read version 1 loses.
+	if err := set(true, 1); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("stale SetFeature error = %v, want FailedPrecondition", err)
+	}
+
+	if on, _ := s.Get("f", nil); on {
+		t.Error("stale SetFeature was applied")
+	}
+
+	if _, err := client.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "f", ExpectedVersion: 1}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("stale DeleteFeature error = %v, want FailedPrecondition", err)
+	}
+
+	// In-process callers can check for the conflict with errors.Is.
+	if _, err := s.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "f", ExpectedVersion: 1}); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("stale DeleteFeature error = %v, want ErrVersionConflict", err)
+	}
+
+	if _, err := client.DeleteFeature(ctx, &featurepb.DeleteFeatureRequest{Name: "f", ExpectedVersion: 2}); err != nil {
+		t.Fatalf("DeleteFeature at current version error = %v", err)
+	}
+
+	// Writes expecting the deleted feature fail too.
+	if err := set(true, 2); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("SetFeature of deleted feature error = %v, want FailedPrecondition", err)
+	}
+
+	create := func(enabled bool) error {
+		_, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+			Feature:      &featurepb.Feature{Name: "f", Type: featurepb.Feature_CONSTANT, Enabled: enabled},
+			ExpectAbsent: true,
+		})
+		return err
+	}
+
+	if err := create(true); err != nil {
+		t.Fatalf("SetFeature expecting absent error = %v", err)
+	}
+
+	// A second operator creating the same feature loses, rather than
+	// overwriting the first.
+	if err := create(false); !IsError(err, ErrVersionConflict) {
+		t.Errorf("second create error = %v, want ErrVersionConflict", err)
+	}
+
+	if on, _ := s.Get("f", nil); !on {
+		t.Error("second create was applied")
+	}
+}
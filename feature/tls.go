@@ -0,0 +1,214 @@
+package feature
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrInvalidTLSConfig is returned for TLS files that cannot be used, e.g. a CA
+// file without any certificates in it.
+var ErrInvalidTLSConfig = errors.New("invalid TLS config")
+
+// ServerTLSConfig returns a TLS config for a Features server, serving the
+// certificate and key in certFile and keyFile. If caFile is set, clients must
+// present a certificate signed by one of the CAs in it.
+//
+// The files are checked for changes on each handshake, and reloaded if they
+// have changed, so certificates can be rotated without restarting the server.
+// If the new files cannot be loaded (e.g. the certificate has been replaced
+// but not yet its key), the previous ones are used until they can be.
+func ServerTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("%w: both a certificate and a key are required", ErrInvalidTLSConfig)
+	}
+
+	r := &tlsReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+
+	if _, err := r.get(); err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.get()
+		},
+	}, nil
+}
+
+// ClientTLSConfig returns a TLS config for connecting to a Features server.
+// The server's certificate is verified against the CAs in caFile, or the
+// system's if caFile is empty. If certFile and keyFile are set, the client
+// presents that certificate, for servers that require one. serverName, if
+// set, overrides the name the server's certificate is verified against.
+func ClientTLSConfig(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+
+	if caFile != "" {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = pool
+	}
+
+	switch {
+	case certFile != "" && keyFile != "":
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	case certFile != "" || keyFile != "":
+		return nil, fmt.Errorf("%w: a client certificate requires both a certificate and a key", ErrInvalidTLSConfig)
+	}
+
+	return config, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("%w: no certificates in %s", ErrInvalidTLSConfig, path)
+	}
+
+	return pool, nil
+}
+
+// tlsReloader builds a server TLS config from files, rebuilding it when they
+// change.
+type tlsReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	m sync.Mutex
+	// modTimes are the modification times of the files config was loaded
+	// from.
+	modTimes []time.Time
+	config   *tls.Config
+	// failedModTimes are the modification times of the files the last time
+	// they could not be loaded, so that they are not retried (and the
+	// failure logged) on every handshake until they change again.
+	failedModTimes []time.Time
+}
+
+// get returns the TLS config for the current contents of the files. If they
+// cannot be loaded, it returns the last config that could be, if any.
+func (r *tlsReloader) get() (*tls.Config, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	modTimes, err := r.modTimesLocked()
+	if err == nil && r.config != nil && (sameTimes(modTimes, r.modTimes) || sameTimes(modTimes, r.failedModTimes)) {
+		return r.config, nil
+	}
+
+	if err == nil {
+		var config *tls.Config
+		if config, err = r.loadLocked(); err == nil {
+			if r.config != nil {
+				log.Printf("[tls] reloaded certificate from %s", r.certFile)
+			}
+
+			r.config = config
+			r.modTimes = modTimes
+			return config, nil
+		}
+	}
+
+	if r.config == nil {
+		return nil, err
+	}
+
+	r.failedModTimes = modTimes
+	log.Printf("[tls] failed to reload certificate, using the previous one: %v", err)
+	return r.config, nil
+}
+
+func (r *tlsReloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.caFile != "" {
+		files = append(files, r.caFile)
+	}
+
+	return files
+}
+
+func (r *tlsReloader) modTimesLocked() ([]time.Time, error) {
+	files := r.files()
+	modTimes := make([]time.Time, len(files))
+
+	for i, path := range files {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		modTimes[i] = fi.ModTime()
+	}
+
+	return modTimes, nil
+}
+
+func (r *tlsReloader) loadLocked() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// This config replaces the server's for each handshake, so it must
+	// advertise HTTP/2 itself; gRPC clients reject connections without it.
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2"},
+	}
+
+	if r.caFile != "" {
+		pool, err := loadCertPool(r.caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+func sameTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
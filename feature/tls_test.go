@@ -0,0 +1,357 @@
+package feature
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/test/bufconn"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// testCA issues certificates for TLS tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	// file is the path of the CA certificate.
+	file string
+}
+
+var testSerial int64
+
+func newTestCA(t *testing.T, dir string, name string) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey error = %v", err)
+	}
+
+	testSerial++
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(testSerial),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate error = %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate error = %v", err)
+	}
+
+	ca := &testCA{cert: cert, key: key, file: filepath.Join(dir, name+".pem")}
+	writePEM(t, ca.file, "CERTIFICATE", der)
+
+	return ca
+}
+
+// issue writes a certificate for localhost signed by the CA, and its key, to
+// dir, and returns their paths.
+func (ca *testCA) issue(t *testing.T, dir string, name string, usage x509.ExtKeyUsage) (certFile string, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey error = %v", err)
+	}
+
+	testSerial++
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(testSerial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("CreateCertificate error = %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey error = %v", err)
+	}
+
+	certFile = filepath.Join(dir, name+".pem")
+	keyFile = filepath.Join(dir, name+"-key.pem")
+
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, path string, typ string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+}
+
+// touch moves the file's modification time forward, so that a rewrite is
+// noticed even on filesystems with coarse timestamps.
+func touch(t *testing.T, path string, d time.Duration) {
+	t.Helper()
+
+	when := time.Now().Add(d)
+	if err := os.Chtimes(path, when, when); err != nil {
+		t.Fatalf("Chtimes error = %v", err)
+	}
+}
+
+// newTLSTestServer serves a Store over bufconn with the given TLS config, and
+// returns a function that makes an RPC to it with the given client config.
+func newTLSTestServer(t *testing.T, config *tls.Config) func(client *tls.Config) error {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	NewStore().Register(gs)
+
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	return func(client *tls.Config) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		cc, err := grpc.DialContext(ctx, "localhost",
+			grpc.WithTransportCredentials(credentials.NewTLS(client)),
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.Dial()
+			}),
+		)
+		if err != nil {
+			return err
+		}
+		defer cc.Close()
+
+		_, err = featurepb.NewFeaturesClient(cc).GetFeatures(ctx, &featurepb.GetFeaturesRequest{})
+		return err
+	}
+}
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newTestCA(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, dir, "client", x509.ExtKeyUsageClientAuth)
+
+	otherCA := newTestCA(t, dir, "other-ca")
+	otherCert, otherKey := otherCA.issue(t, dir, "other-client", x509.ExtKeyUsageClientAuth)
+
+	clientConfig := func(caFile, certFile, keyFile string) *tls.Config {
+		t.Helper()
+
+		config, err := ClientTLSConfig(caFile, certFile, keyFile, "")
+		if err != nil {
+			t.Fatalf("ClientTLSConfig error = %v", err)
+		}
+
+		return config
+	}
+
+	t.Run("tls", func(t *testing.T) {
+		config, err := ServerTLSConfig(serverCert, serverKey, "")
+		if err != nil {
+			t.Fatalf("ServerTLSConfig error = %v", err)
+		}
+
+		call := newTLSTestServer(t, config)
+
+		if err := call(clientConfig(ca.file, "", "")); err != nil {
+			t.Errorf("call error = %v", err)
+		}
+
+		if err := call(clientConfig(otherCA.file, "", "")); err == nil {
+			t.Error("call trusting another CA succeeded, want an error")
+		}
+	})
+
+	t.Run("mtls", func(t *testing.T) {
+		config, err := ServerTLSConfig(serverCert, serverKey, ca.file)
+		if err != nil {
+			t.Fatalf("ServerTLSConfig error = %v", err)
+		}
+
+		call := newTLSTestServer(t, config)
+
+		if err := call(clientConfig(ca.file, clientCert, clientKey)); err != nil {
+			t.Errorf("call with client certificate error = %v", err)
+		}
+
+		if err := call(clientConfig(ca.file, "", "")); err == nil {
+			t.Error("call without client certificate succeeded, want an error")
+		}
+
+		if err := call(clientConfig(ca.file, otherCert, otherKey)); err == nil {
+			t.Error("call with untrusted client certificate succeeded, want an error")
+		}
+	})
+}
+
+func TestTLSNegotiatesHTTP2(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newTestCA(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	config, err := ServerTLSConfig(serverCert, serverKey, "")
+	if err != nil {
+		t.Fatalf("ServerTLSConfig error = %v", err)
+	}
+
+	client, err := ClientTLSConfig(ca.file, "", "", "localhost")
+	if err != nil {
+		t.Fatalf("ClientTLSConfig error = %v", err)
+	}
+
+	// Like other gRPC implementations, offer only h2.
+	client.NextProtos = []string{"h2"}
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		_, _, err := credentials.NewTLS(config).ServerHandshake(serverConn)
+		errs <- err
+	}()
+
+	conn := tls.Client(clientConn, client)
+	if err := conn.Handshake(); err != nil {
+		t.Fatalf("Handshake error = %v", err)
+	}
+
+	if err := <-errs; err != nil {
+		t.Fatalf("ServerHandshake error = %v", err)
+	}
+
+	if proto := conn.ConnectionState().NegotiatedProtocol; proto != "h2" {
+		t.Errorf("NegotiatedProtocol = %q, want h2", proto)
+	}
+}
+
+func TestTLSReload(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newTestCA(t, dir, "ca")
+	certFile, keyFile := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	config, err := ServerTLSConfig(certFile, keyFile, "")
+	if err != nil {
+		t.Fatalf("ServerTLSConfig error = %v", err)
+	}
+
+	call := newTLSTestServer(t, config)
+
+	trusting := func(ca *testCA) *tls.Config {
+		config, err := ClientTLSConfig(ca.file, "", "", "")
+		if err != nil {
+			t.Fatalf("ClientTLSConfig error = %v", err)
+		}
+
+		return config
+	}
+
+	if err := call(trusting(ca)); err != nil {
+		t.Fatalf("call error = %v", err)
+	}
+
+	// Rotate to a certificate from a new CA, starting with the certificate
+	// alone. Until the key is rotated too, the old pair is still served.
+	newCA := newTestCA(t, dir, "new-ca")
+	newCert, newKey := newCA.issue(t, t.TempDir(), "server", x509.ExtKeyUsageServerAuth)
+
+	data, err := ioutil.ReadFile(newCert)
+	if err != nil {
+		t.Fatalf("ReadFile error = %v", err)
+	}
+
+	if err := ioutil.WriteFile(certFile, data, 0600); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+	touch(t, certFile, time.Second)
+
+	if err := call(trusting(ca)); err != nil {
+		t.Errorf("call during rotation error = %v", err)
+	}
+
+	if data, err = ioutil.ReadFile(newKey); err != nil {
+		t.Fatalf("ReadFile error = %v", err)
+	}
+
+	if err := ioutil.WriteFile(keyFile, data, 0600); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+	touch(t, keyFile, 2*time.Second)
+
+	if err := call(trusting(newCA)); err != nil {
+		t.Errorf("call after rotation error = %v", err)
+	}
+
+	if err := call(trusting(ca)); err == nil {
+		t.Error("call trusting the old CA after rotation succeeded, want an error")
+	}
+}
+
+func TestTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newTestCA(t, dir, "ca")
+	certFile, keyFile := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	notPEM := filepath.Join(dir, "not-pem")
+	if err := ioutil.WriteFile(notPEM, []byte("hello"), 0600); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+
+	if _, err := ServerTLSConfig(certFile, "", ""); !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Errorf("ServerTLSConfig without key error = %v, want ErrInvalidTLSConfig", err)
+	}
+
+	if _, err := ServerTLSConfig(certFile, keyFile, notPEM); !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Errorf("ServerTLSConfig with bad CA error = %v, want ErrInvalidTLSConfig", err)
+	}
+
+	if _, err := ServerTLSConfig(certFile, filepath.Join(dir, "missing"), ""); err == nil {
+		t.Error("ServerTLSConfig with missing key succeeded, want an error")
+	}
+
+	if _, err := ClientTLSConfig(ca.file, certFile, "", ""); !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Errorf("ClientTLSConfig with certificate but no key error = %v, want ErrInvalidTLSConfig", err)
+	}
+}
@@ -0,0 +1,354 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Knetic/govaluate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+// DefaultNamePattern is the naming policy for features, unless a store is
+// given another with SetNamePattern: names start with a letter, digit, or
+// underscore, followed by letters, digits, and any of "_.-/".
+var DefaultNamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_./-]*$`)
+
+// MaxNameLength is the longest a feature name may be.
+const MaxNameLength = 128
+
+// FieldError is a single problem with a feature spec.
+type FieldError struct {
+	// Feature is the name of the feature with the problem.
+	Feature string
+	// Field is the path to the field with the problem, e.g. "percentage" or
+	// "rules[2].expression", or empty if the problem is with the feature as a
+	// whole.
+	Field string
+	// Err describes the problem. It wraps ErrInvalidFeature, or, for a
+	// feature without a known type, ErrUnknownFeatureType.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	if path := e.path(); path != "" {
+		return path + ": " + e.message()
+	}
+
+	return e.message()
+}
+
+// path returns the feature name and field path, e.g. "foo.rules[2].outcome".
+func (e *FieldError) path() string {
+	switch {
+	case e.Feature == "":
+		return e.Field
+	case e.Field == "":
+		return e.Feature
+	}
+
+	return e.Feature + "." + e.Field
+}
+
+// message returns the description of the problem. The wrapped error says that
+// the spec is invalid, which is redundant in a list of problems, so that is
+// left out.
+func (e *FieldError) message() string {
+	return strings.TrimPrefix(e.Err.Error(), ErrInvalidFeature.Error()+": ")
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// ValidationError is returned when one or more feature specs are invalid. It
+// lists every problem found, rather than just the first, and matches
+// ErrInvalidFeature with errors.Is.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", ErrInvalidFeature, strings.Join(msgs, "; "))
+}
+
+// badRequest returns the problems as a BadRequest error detail, for gRPC
+// clients.
+func (e *ValidationError) badRequest() *errdetails.BadRequest {
+	br := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(e.Errors)),
+	}
+
+	for i, fe := range e.Errors {
+		br.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       fe.path(),
+			Description: fe.message(),
+		}
+	}
+
+	return br
+}
+
+// Is allows errors.Is to match a ValidationError against ErrInvalidFeature,
+// and against the errors of the problems it contains.
+func (e *ValidationError) Is(target error) bool {
+	if target == ErrInvalidFeature {
+		return true
+	}
+
+	for _, fe := range e.Errors {
+		if errors.Is(fe, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validation is the single pipeline that every feature spec goes through
+// before it is stored, whether it comes from a config file, an RPC, or the
+// client CLI.
+type validation struct {
+	namePattern *regexp.Regexp
+	// segments, if non-nil, are the segments that features may reference.
+	// If nil, segment references are not checked.
+	segments map[string]*Segment
+
+	errs []*FieldError
+}
+
+func (v *validation) addf(name string, field string, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{
+		Feature: name,
+		Field:   field,
+		Err:     fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidFeature}, args...)...),
+	})
+}
+
+// err returns the problems found so far as a ValidationError, or nil if there
+// are none.
+func (v *validation) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: v.errs}
+}
+
+// feature checks a single feature, recording any problems. If the feature is
+// valid, its expression or rules are parsed and ready to evaluate.
+func (v *validation) feature(f *Feature) {
+	if f == nil || f.Feature == nil {
+		v.addf("", "", "feature cannot be empty")
+		return
+	}
+
+	n := len(v.errs)
+
+	v.name(f.Name)
+
+	if err := validateValue(f.Value); err != nil {
+		v.errs = append(v.errs, &FieldError{Feature: f.Name, Field: "value", Err: err})
+	}
+
+	switch f.Type {
+	case featurepb.Feature_CONSTANT:
+	case featurepb.Feature_PERCENTAGE_BASED:
+		if f.Percentage > 100 {
+			v.addf(f.Name, "percentage", "must be in [0, 100] (got %d)", f.Percentage)
+		}
+	case featurepb.Feature_EXPRESSION:
+		v.expression(f)
+	case featurepb.Feature_VARIANT:
+		v.variants(f)
+	case featurepb.Feature_RULES:
+		v.rules(f)
+	default:
+		v.errs = append(v.errs, &FieldError{
+			Feature: f.Name,
+			Field:   "type",
+			Err:     fmt.Errorf("%w %v", ErrUnknownFeatureType, f.Type),
+		})
+	}
+
+	// Only check segment references once everything parses.
+	if v.segments == nil || len(v.errs) > n {
+		return
+	}
+
+	refs, err := f.SegmentRefs()
+	if err != nil {
+		return
+	}
+
+	for _, ref := range refs {
+		if _, ok := v.segments[ref]; !ok {
+			v.addf(f.Name, "", "references unknown segment %s", ref)
+		}
+	}
+}
+
+func (v *validation) name(name string) {
+	switch {
+	case name == "":
+		v.addf(name, "name", "cannot be empty")
+	case len(name) > MaxNameLength:
+		v.addf(name, "name", "must be at most %d characters", MaxNameLength)
+	case !v.namePattern.MatchString(name):
+		v.addf(name, "name", "must match %s", v.namePattern)
+	}
+}
+
+func (v *validation) expression(f *Feature) {
+	if f.Expression == "" {
+		v.addf(f.Name, "expression", "cannot be empty")
+		return
+	}
+
+	if err := f.parseExpression(); err != nil {
+		v.addf(f.Name, "expression", "could not parse %s: %v", f.Expression, err)
+	}
+}
+
+// variants checks that a VARIANT feature has at least one variant, that every
+// variant has a unique, non-empty name, and that the weights sum to exactly
+// 100.
+func (v *validation) variants(f *Feature) {
+	if len(f.Variants) == 0 {
+		v.addf(f.Name, "variants", "VARIANT features must have at least one variant")
+		return
+	}
+
+	var (
+		total uint32
+		names = make(map[string]bool, len(f.Variants))
+	)
+
+	for i, variant := range f.Variants {
+		field := fmt.Sprintf("variants[%d].name", i)
+
+		switch {
+		case variant.Name == "":
+			v.addf(f.Name, field, "cannot be empty")
+		case names[variant.Name]:
+			v.addf(f.Name, field, "duplicate variant %s", variant.Name)
+		}
+
+		names[variant.Name] = true
+		total += variant.Weight
+	}
+
+	if total != 100 {
+		v.addf(f.Name, "variants", "weights must sum to 100 (got %d)", total)
+	}
+}
+
+// rules checks that every rule of a RULES feature has a non-empty, parseable
+// expression and a valid outcome, and that the fallthrough outcome (if any) is
+// valid.
+func (v *validation) rules(f *Feature) {
+	var (
+		n     = len(v.errs)
+		exprs = make([]*govaluate.EvaluableExpression, len(f.Rules))
+	)
+
+	for i, rule := range f.Rules {
+		field := fmt.Sprintf("rules[%d]", i)
+
+		if rule.Expression == "" {
+			v.addf(f.Name, field+".expression", "cannot be empty")
+		} else if expr, err := govaluate.NewEvaluableExpression(rule.Expression); err != nil {
+			v.addf(f.Name, field+".expression", "could not parse %s: %v", rule.Expression, err)
+		} else {
+			exprs[i] = expr
+		}
+
+		if rule.Outcome == nil {
+			v.addf(f.Name, field+".outcome", "cannot be empty")
+		} else {
+			v.outcome(f, field+".outcome", rule.Outcome)
+		}
+	}
+
+	if f.Fallthrough != nil {
+		v.outcome(f, "fallthrough", f.Fallthrough)
+	}
+
+	if len(v.errs) == n {
+		f.rules = exprs
+	}
+}
+
+func (v *validation) outcome(f *Feature, field string, o *featurepb.Outcome) {
+	switch kind := o.Kind.(type) {
+	case nil:
+		v.addf(f.Name, field, "must be one of enabled, percentage, or variant")
+	case *featurepb.Outcome_Percentage:
+		if kind.Percentage > 100 {
+			v.addf(f.Name, field+".percentage", "must be in [0, 100] (got %d)", kind.Percentage)
+		}
+	case *featurepb.Outcome_Variant:
+		if kind.Variant == "" {
+			v.addf(f.Name, field+".variant", "cannot be empty")
+		} else if len(f.Variants) > 0 && !f.hasVariant(kind.Variant) {
+			v.addf(f.Name, field+".variant", "no such variant %s", kind.Variant)
+		}
+	}
+
+	if err := validateValue(o.Value); err != nil {
+		v.errs = append(v.errs, &FieldError{Feature: f.Name, Field: field + ".value", Err: err})
+	}
+}
+
+// Validate checks that the feature is valid, with the default naming policy:
+// that it has a valid name and a known type, and that its type-specific
+// fields are valid, including that its expressions parse. If it is not valid,
+// the error is a *ValidationError listing every problem.
+func (f *Feature) Validate() (bool, error) {
+	v := &validation{namePattern: DefaultNamePattern}
+	v.feature(f)
+
+	if err := v.err(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ValidateFeatures validates every feature in m, which is keyed by name as in
+// a config file, with the default naming policy. As when loading a config
+// file, each feature's name is set to its key. If any are invalid, the error is
+// a *ValidationError listing every problem with every feature.
+func ValidateFeatures(m map[string]*Feature) error {
+	return validateFeatures(&validation{namePattern: DefaultNamePattern}, m)
+}
+
+// validateFeatures validates every feature in m with v, as ValidateFeatures
+// does.
+func validateFeatures(v *validation, m map[string]*Feature) error {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if m[name] == nil || m[name].Feature == nil {
+			v.addf(name, "", "feature cannot be empty")
+			continue
+		}
+
+		m[name].Name = name
+		v.feature(m[name])
+	}
+
+	return v.err()
+}
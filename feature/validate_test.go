@@ -0,0 +1,280 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestValidate(t *testing.T) {
+	enabled := &featurepb.Outcome{Kind: &featurepb.Outcome_Enabled{Enabled: true}}
+
+	tests := []struct {
+		name    string
+		feature *featurepb.Feature
+		// wantFields are the fields with problems, in order.
+		wantFields []string
+		wantIs     error
+	}{
+		{
+			name:    "valid",
+			feature: &featurepb.Feature{Name: "team/new-search_v2.1", Type: featurepb.Feature_CONSTANT},
+		},
+		{
+			name:       "empty name",
+			feature:    &featurepb.Feature{Type: featurepb.Feature_CONSTANT},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "name with spaces",
+			feature:    &featurepb.Feature{Name: "new search", Type: featurepb.Feature_CONSTANT},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "long name",
+			feature:    &featurepb.Feature{Name: strings.Repeat("a", MaxNameLength+1), Type: featurepb.Feature_CONSTANT},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "unknown type",
+			feature:    &featurepb.Feature{Name: "f"},
+			wantFields: []string{"type"},
+			wantIs:     ErrUnknownFeatureType,
+		},
+		{
+			name:       "unparseable expression",
+			feature:    &featurepb.Feature{Name: "f", Type: featurepb.Feature_EXPRESSION, Expression: "x >"},
+			wantFields: []string{"expression"},
+		},
+		{
+			name:       "empty expression",
+			feature:    &featurepb.Feature{Name: "f", Type: featurepb.Feature_EXPRESSION},
+			wantFields: []string{"expression"},
+		},
+		{
+			name: "every problem is reported",
+			feature: &featurepb.Feature{
+				Name: "bad name",
+				Type: featurepb.Feature_RULES,
+				Rules: []*featurepb.Rule{
+					{Expression: "((", Outcome: enabled},
+					{Expression: "1 > 0"},
+					{Expression: "1 > 0", Outcome: &featurepb.Outcome{Kind: &featurepb.Outcome_Percentage{Percentage: 101}}},
+				},
+				Fallthrough: &featurepb.Outcome{},
+				Value:       &featurepb.Value{Kind: &featurepb.Value_JsonValue{JsonValue: "{"}},
+			},
+			wantFields: []string{
+				"name",
+				"value",
+				"rules[0].expression",
+				"rules[1].outcome",
+				"rules[2].outcome.percentage",
+				"fallthrough",
+			},
+		},
+		{
+			name: "variants",
+			feature: &featurepb.Feature{
+				Name:     "f",
+				Type:     featurepb.Feature_VARIANT,
+				Variants: []*featurepb.Feature_Variant{{Weight: 10}, {Name: "a", Weight: 10}, {Name: "a", Weight: 10}},
+			},
+			wantFields: []string{"variants[0].name", "variants[2].name", "variants"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := (&Feature{Feature: tt.feature}).Validate()
+			if ok != (len(tt.wantFields) == 0) {
+				t.Fatalf("Validate() = %v, %v; want valid = %v", ok, err, len(tt.wantFields) == 0)
+			}
+
+			if ok {
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidFeature) {
+				t.Errorf("Validate() error = %v, want ErrInvalidFeature", err)
+			}
+
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantIs)
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+			}
+
+			var fields []string
+			for _, fe := range verr.Errors {
+				fields = append(fields, fe.Field)
+			}
+
+			if strings.Join(fields, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("Validate() problems with %v, want %v (error = %v)", fields, tt.wantFields, err)
+			}
+		})
+	}
+}
+
+func TestValidateParses(t *testing.T) {
+	f := &Feature{Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_EXPRESSION, Expression: "x > 1"}}
+	if ok, err := f.Validate(); !ok {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	// The expression was parsed by Validate, so the feature is ready to use.
+	if on, err := f.IsEnabledForParameters(map[string]interface{}{"x": 2}); err != nil || !on {
+		t.Errorf("IsEnabledForParameters(x=2) = %v, %v; want true", on, err)
+	}
+}
+
+func TestStoreValidation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := NewStore()
+	client := newTestClient(t, s)
+
+	// Every write path goes through the same checks, and reports every
+	// problem at once.
+	_, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "bad name", Type: featurepb.Feature_PERCENTAGE_BASED, Percentage: 101},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("SetFeature error = %v, want InvalidArgument", err)
+	}
+
+	var violations []string
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				violations = append(violations, v.Field)
+			}
+		}
+	}
+
+	if want := "bad name.name,bad name.percentage"; strings.Join(violations, ",") != want {
+		t.Errorf("SetFeature violations = %v, want %s", violations, want)
+	}
+
+	if _, err := client.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "f"},
+	}); !IsError(err, ErrInvalidFeature) {
+		t.Errorf("SetFeature(UNKNOWN type) error = %v, want ErrInvalidFeature", err)
+	}
+
+	_, err = s.ApplyFeatures(ctx, &featurepb.ApplyFeaturesRequest{
+		Operations: []*featurepb.FeatureOperation{
+			{Op: &featurepb.FeatureOperation_Set{Set: &featurepb.Feature{Type: featurepb.Feature_CONSTANT}}},
+			{Op: &featurepb.FeatureOperation_Set{Set: &featurepb.Feature{Name: "e", Type: featurepb.Feature_EXPRESSION, Expression: "[segment:missing]"}}},
+			setOp("ok", true),
+		},
+	})
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Errors) != 2 {
+		t.Errorf("ApplyFeatures error = %v, want a *ValidationError with 2 problems", err)
+	}
+
+	if _, err := s.Get("ok", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(ok) error = %v, want ErrNoFeature", err)
+	}
+
+	// A stricter naming policy applies to subsequent writes.
+	s.SetNamePattern(regexp.MustCompile(`^team_[a-z]+$`))
+
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "f", Type: featurepb.Feature_CONSTANT},
+	}); !errors.Is(err, ErrInvalidFeature) {
+		t.Errorf("SetFeature(f) error = %v, want ErrInvalidFeature", err)
+	}
+
+	if _, err := s.SetFeature(ctx, &featurepb.SetFeatureRequest{
+		Feature: &featurepb.Feature{Name: "team_f", Type: featurepb.Feature_CONSTANT},
+	}); err != nil {
+		t.Errorf("SetFeature(team_f) error = %v", err)
+	}
+}
+
+func TestInitFromFileValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.json")
+
+	config := `{
+  "a": {"type": "EXPRESSION", "expression": "x >"},
+  "b": {"type": "PERCENTAGE_BASED", "percentage": 101},
+  "c": {"enabled": true}
+}`
+
+	if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+
+	s := NewStore()
+
+	err := s.InitFromFile(path)
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("InitFromFile error = %v, want a *ValidationError", err)
+	}
+
+	var features []string
+	for _, fe := range verr.Errors {
+		features = append(features, fe.Feature)
+	}
+
+	if strings.Join(features, ",") != "a,b,c" {
+		t.Errorf("InitFromFile problems with %v, want a, b, and c", features)
+	}
+}
+
+func TestInitFromFileSegments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.json")
+
+	config := `{"beta_only": {"type": "EXPRESSION", "expression": "[segment:beta]"}}`
+	if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+
+	s := NewStore()
+
+	// Config files are held to the same rules as the API, so features can't
+	// refer to segments that don't exist.
+	if err := s.InitFromFile(path); !errors.Is(err, ErrInvalidFeature) {
+		t.Fatalf("InitFromFile referencing missing segment error = %v, want ErrInvalidFeature", err)
+	}
+
+	if _, err := s.Get("beta_only", nil); !errors.Is(err, ErrNoFeature) {
+		t.Errorf("Get(beta_only) error = %v, want ErrNoFeature", err)
+	}
+
+	if _, err := s.SetSegment(context.Background(), &featurepb.SetSegmentRequest{
+		Segment: &featurepb.Segment{Name: "beta", Key: "user_id", Included: []string{"42"}},
+	}); err != nil {
+		t.Fatalf("SetSegment error = %v", err)
+	}
+
+	if err := s.InitFromFile(path); err != nil {
+		t.Fatalf("InitFromFile error = %v", err)
+	}
+
+	if on, err := s.Get("beta_only", map[string]interface{}{"user_id": 42}); err != nil || !on {
+		t.Errorf("Get(beta_only, 42) = %v, %v; want true", on, err)
+	}
+}
@@ -0,0 +1,177 @@
+package feature
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var ErrValueType = errors.New("feature value has wrong type")
+
+// ValueForParameters returns the typed value of the feature for the given
+// parameters. If ok is false, the feature has no value for these parameters,
+// and callers should use their own default.
+//
+// For EXPRESSION features whose expression evaluates to something other than a
+// bool (e.g. `region == "eu" ? "eu.example.com" : "us.example.com"`), the
+// result of the expression is the value. For RULES features, the value of the
+// matching outcome takes precedence over the feature's Value. For all other
+// cases, the feature's Value is returned if the feature is enabled for the
+// given parameters.
+//
+// Values are returned as one of string, int64, float64, or json.RawMessage.
+func (f *Feature) ValueForParameters(parameters map[string]interface{}) (value interface{}, ok bool, err error) {
+	d := f.Detail(parameters)
+	if d.Err != nil {
+		return nil, false, d.Err
+	}
+
+	return d.Value, d.Value != nil, nil
+}
+
+// valueOf converts a Value message into its native Go representation.
+func valueOf(v *featurepb.Value) interface{} {
+	switch kind := v.Kind.(type) {
+	case *featurepb.Value_StringValue:
+		return kind.StringValue
+	case *featurepb.Value_IntValue:
+		return kind.IntValue
+	case *featurepb.Value_FloatValue:
+		return kind.FloatValue
+	case *featurepb.Value_JsonValue:
+		return json.RawMessage(kind.JsonValue)
+	}
+
+	return nil
+}
+
+func validateValue(v *featurepb.Value) error {
+	if v == nil {
+		return nil
+	}
+
+	if kind, ok := v.Kind.(*featurepb.Value_JsonValue); ok && !json.Valid([]byte(kind.JsonValue)) {
+		return fmt.Errorf("%w: json_value is not valid JSON", ErrInvalidFeature)
+	}
+
+	return nil
+}
+
+// getValue looks up the named feature and returns its value for the given
+// parameters.
+func (s *Store) getValue(name string, parameters map[string]interface{}) (interface{}, bool, error) {
+	feat, err := s.getFeature(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return feat.ValueForParameters(parameters)
+}
+
+// GetString returns the string value of the named feature, or def if the
+// feature has no value for the given parameters. If the feature does not
+// exist, or has a non-string value, def is returned along with an error.
+func GetString(name string, parameters map[string]interface{}, def string) (string, error) {
+	return defaultStore.GetString(name, parameters, def)
+}
+
+// GetString is like the package-level GetString, but reads a feature from the store.
+func (s *Store) GetString(name string, parameters map[string]interface{}, def string) (string, error) {
+	v, ok, err := s.getValue(name, parameters)
+	if err != nil || !ok {
+		return def, err
+	}
+
+	str, ok := v.(string)
+	if !ok {
+		return def, fmt.Errorf("%w: %s has value %v (%T), want string", ErrValueType, name, v, v)
+	}
+
+	return str, nil
+}
+
+// GetInt returns the integer value of the named feature, or def if the feature
+// has no value for the given parameters. Float values (including the results
+// of numeric expressions) are accepted if they are whole numbers. If the
+// feature does not exist, or has a non-integer value, def is returned along
+// with an error.
+func GetInt(name string, parameters map[string]interface{}, def int64) (int64, error) {
+	return defaultStore.GetInt(name, parameters, def)
+}
+
+// GetInt is like the package-level GetInt, but reads a feature from the store.
+func (s *Store) GetInt(name string, parameters map[string]interface{}, def int64) (int64, error) {
+	v, ok, err := s.getValue(name, parameters)
+	if err != nil || !ok {
+		return def, err
+	}
+
+	switch n := v.(type) {
+	case int64:
+		return n, nil
+	case float64:
+		if n == math.Trunc(n) {
+			return int64(n), nil
+		}
+	}
+
+	return def, fmt.Errorf("%w: %s has value %v (%T), want int", ErrValueType, name, v, v)
+}
+
+// GetFloat returns the float value of the named feature, or def if the feature
+// has no value for the given parameters. Integer values are converted. If the
+// feature does not exist, or has a non-numeric value, def is returned along
+// with an error.
+func GetFloat(name string, parameters map[string]interface{}, def float64) (float64, error) {
+	return defaultStore.GetFloat(name, parameters, def)
+}
+
+// GetFloat is like the package-level GetFloat, but reads a feature from the store.
+func (s *Store) GetFloat(name string, parameters map[string]interface{}, def float64) (float64, error) {
+	v, ok, err := s.getValue(name, parameters)
+	if err != nil || !ok {
+		return def, err
+	}
+
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case int64:
+		return float64(n), nil
+	}
+
+	return def, fmt.Errorf("%w: %s has value %v (%T), want float", ErrValueType, name, v, v)
+}
+
+// GetJSON unmarshals the value of the named feature into out. If the feature
+// has no value for the given parameters, out is left untouched, so callers can
+// populate it with defaults beforehand. Non-JSON values are unmarshaled from
+// their JSON encoding, so e.g. a string value may be read into a *string.
+func GetJSON(name string, parameters map[string]interface{}, out interface{}) error {
+	return defaultStore.GetJSON(name, parameters, out)
+}
+
+// GetJSON is like the package-level GetJSON, but reads a feature from the store.
+func (s *Store) GetJSON(name string, parameters map[string]interface{}, out interface{}) error {
+	v, ok, err := s.getValue(name, parameters)
+	if err != nil || !ok {
+		return err
+	}
+
+	data, ok := v.(json.RawMessage)
+	if !ok {
+		data, err = json.Marshal(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrValueType, name, err)
+	}
+
+	return nil
+}
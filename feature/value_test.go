@@ -0,0 +1,114 @@
+package feature
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const valueConfig = `{
+  "timeout_ms": {
+    "type": "CONSTANT",
+    "enabled": true,
+    "value": {"intValue": "250"}
+  },
+  "endpoint": {
+    "type": "EXPRESSION",
+    "expression": "region == 'eu' ? 'eu.example.com' : 'us.example.com'"
+  },
+  "batch": {
+    "type": "EXPRESSION",
+    "expression": "size > 10",
+    "value": {"jsonValue": "{\"size\": 100, \"parallel\": true}"}
+  },
+  "disabled": {
+    "type": "CONSTANT",
+    "enabled": false,
+    "value": {"stringValue": "unused"}
+  }
+}`
+
+func TestTypedValues(t *testing.T) {
+	var m map[string]*Feature
+	if err := json.Unmarshal([]byte(valueConfig), &m); err != nil {
+		t.Fatalf("json.Unmarshal error = %v", err)
+	}
+
+	for name, f := range m {
+		f.Name = name
+		if ok, err := f.Validate(); !ok {
+			t.Fatalf("%s.Validate() = %v", name, err)
+		}
+	}
+
+	Init(m)
+
+	timeout, err := GetInt("timeout_ms", nil, 100)
+	if err != nil || timeout != 250 {
+		t.Errorf("GetInt(timeout_ms) = %d, %v; want 250, nil", timeout, err)
+	}
+
+	if _, err := GetString("timeout_ms", nil, ""); !errors.Is(err, ErrValueType) {
+		t.Errorf("GetString(timeout_ms) error = %v, want ErrValueType", err)
+	}
+
+	timeoutF, err := GetFloat("timeout_ms", nil, 0)
+	if err != nil || timeoutF != 250 {
+		t.Errorf("GetFloat(timeout_ms) = %v, %v; want 250, nil", timeoutF, err)
+	}
+
+	endpoint, err := GetString("endpoint", map[string]interface{}{"region": "eu"}, "default")
+	if err != nil || endpoint != "eu.example.com" {
+		t.Errorf("GetString(endpoint, eu) = %s, %v; want eu.example.com, nil", endpoint, err)
+	}
+
+	endpoint, err = GetString("endpoint", map[string]interface{}{"region": "ap"}, "default")
+	if err != nil || endpoint != "us.example.com" {
+		t.Errorf("GetString(endpoint, ap) = %s, %v; want us.example.com, nil", endpoint, err)
+	}
+
+	type batchConfig struct {
+		Size     int  `json:"size"`
+		Parallel bool `json:"parallel"`
+	}
+
+	cfg := batchConfig{Size: 1}
+	if err := GetJSON("batch", map[string]interface{}{"size": 5}, &cfg); err != nil || cfg.Size != 1 {
+		t.Errorf("GetJSON(batch, 5) = %+v, %v; want default", cfg, err)
+	}
+
+	if err := GetJSON("batch", map[string]interface{}{"size": 50}, &cfg); err != nil || cfg.Size != 100 || !cfg.Parallel {
+		t.Errorf("GetJSON(batch, 50) = %+v, %v; want {100 true}", cfg, err)
+	}
+
+	s, err := GetString("disabled", nil, "default")
+	if err != nil || s != "default" {
+		t.Errorf("GetString(disabled) = %s, %v; want default, nil", s, err)
+	}
+
+	n, err := GetInt("missing", nil, 7)
+	if !errors.Is(err, ErrNoFeature) || n != 7 {
+		t.Errorf("GetInt(missing) = %d, %v; want 7, ErrNoFeature", n, err)
+	}
+}
+
+func TestValueRoundTrip(t *testing.T) {
+	var m map[string]*Feature
+	if err := json.Unmarshal([]byte(valueConfig), &m); err != nil {
+		t.Fatalf("json.Unmarshal error = %v", err)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal error = %v", err)
+	}
+
+	var m2 map[string]*Feature
+	if err := json.Unmarshal(data, &m2); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", data, err)
+	}
+
+	if got := valueOf(m2["timeout_ms"].Value); got != int64(250) {
+		t.Errorf("round-tripped timeout_ms value = %v, want 250", got)
+	}
+}
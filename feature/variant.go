@@ -0,0 +1,64 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+var ErrNotVariant = errors.New("feature does not have variants")
+
+// Variant returns the name of the variant assigned for the given parameters.
+// It returns an error if the feature is not a VARIANT or RULES feature. For
+// RULES features, the variant is the one named by the matching outcome, if
+// any.
+//
+// Entities are bucketed using the feature's BucketingKey and Salt, exactly as
+// for PERCENTAGE_BASED features, and then assigned the variant whose
+// cumulative weight range contains their bucket. If the feature has a
+// BucketingKey that is not present in the parameters, Variant returns the
+// empty string.
+func (f *Feature) Variant(parameters map[string]interface{}) (string, error) {
+	switch f.Type {
+	case featurepb.Feature_VARIANT, featurepb.Feature_RULES:
+	default:
+		return "", fmt.Errorf("%w: %s has type %v", ErrNotVariant, f.Name, f.Type)
+	}
+
+	d := f.Detail(parameters)
+	return d.Variant, d.Err
+}
+
+// variantForBucket returns the name of the variant whose cumulative weight range
+// contains the given bucket.
+func (f *Feature) variantForBucket(n uint32) string {
+	var cumulative uint32
+	for _, v := range f.Variants {
+		cumulative += v.Weight
+		if n < cumulative {
+			return v.Name
+		}
+	}
+
+	// Only reachable if the weights do not sum to 100, which validateVariants
+	// prevents.
+	return ""
+}
+
+// GetVariant returns the name of the variant assigned for the given
+// parameters, from the default store.
+func GetVariant(name string, parameters map[string]interface{}) (string, error) {
+	return defaultStore.GetVariant(name, parameters)
+}
+
+// GetVariant returns the name of the variant assigned for the given
+// parameters.
+func (s *Store) GetVariant(name string, parameters map[string]interface{}) (string, error) {
+	feat, err := s.getFeature(name)
+	if err != nil {
+		return "", err
+	}
+
+	return feat.Variant(parameters)
+}
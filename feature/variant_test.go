@@ -0,0 +1,112 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	featurepb "github.com/ajm188/go-ff/proto/feature"
+)
+
+func TestVariant(t *testing.T) {
+	f := &Feature{
+		Feature: &featurepb.Feature{
+			Name:         "experiment",
+			Type:         featurepb.Feature_VARIANT,
+			BucketingKey: "user_id",
+			Variants: []*featurepb.Feature_Variant{
+				{Name: "control", Weight: 50},
+				{Name: "a", Weight: 25},
+				{Name: "b", Weight: 25},
+			},
+		},
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		params := map[string]interface{}{"user_id": fmt.Sprintf("user-%d", i)}
+
+		v, err := f.Variant(params)
+		if err != nil {
+			t.Fatalf("Variant(%v) error = %v", params, err)
+		}
+
+		again, _ := f.Variant(params)
+		if again != v {
+			t.Fatalf("Variant(%v) not sticky: got %s then %s", params, v, again)
+		}
+
+		counts[v]++
+	}
+
+	want := map[string]int{"control": 5000, "a": 2500, "b": 2500}
+	for name, n := range want {
+		if diff := counts[name] - n; diff < -300 || diff > 300 {
+			t.Errorf("variant %s assigned %d times, want about %d", name, counts[name], n)
+		}
+	}
+
+	if v, _ := f.Variant(nil); v != "" {
+		t.Errorf("Variant(nil) = %s, want no variant", v)
+	}
+}
+
+func TestValidateVariants(t *testing.T) {
+	tests := []struct {
+		name     string
+		variants []*featurepb.Feature_Variant
+		valid    bool
+	}{
+		{
+			name:     "valid",
+			variants: []*featurepb.Feature_Variant{{Name: "a", Weight: 60}, {Name: "b", Weight: 40}},
+			valid:    true,
+		},
+		{
+			name:  "no variants",
+			valid: false,
+		},
+		{
+			name:     "under 100",
+			variants: []*featurepb.Feature_Variant{{Name: "a", Weight: 60}, {Name: "b", Weight: 30}},
+			valid:    false,
+		},
+		{
+			name:     "over 100",
+			variants: []*featurepb.Feature_Variant{{Name: "a", Weight: 60}, {Name: "b", Weight: 50}},
+			valid:    false,
+		},
+		{
+			name:     "duplicate names",
+			variants: []*featurepb.Feature_Variant{{Name: "a", Weight: 50}, {Name: "a", Weight: 50}},
+			valid:    false,
+		},
+		{
+			name:     "empty name",
+			variants: []*featurepb.Feature_Variant{{Weight: 100}},
+			valid:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Feature{
+				Feature: &featurepb.Feature{
+					Name:     tt.name,
+					Type:     featurepb.Feature_VARIANT,
+					Variants: tt.variants,
+				},
+			}
+
+			ok, err := f.Validate()
+			if ok != tt.valid {
+				t.Errorf("Validate() = %v, %v; want valid = %v", ok, err, tt.valid)
+			}
+
+			if !tt.valid && !errors.Is(err, ErrInvalidFeature) {
+				t.Errorf("Validate() error = %v, want ErrInvalidFeature", err)
+			}
+		})
+	}
+}
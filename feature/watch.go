@@ -3,14 +3,20 @@ package feature
 import (
 	"context"
 	"errors"
+	"io/ioutil"
 	"log"
 	"path/filepath"
 
 	"github.com/fsnotify/fsnotify"
 )
 
-// Watch watches the given path for changes and reloads the global feature
-// config.
+// Watch watches the given path for changes and reloads the default store's
+// features. See (*Store).Watch.
+func Watch(ctx context.Context, path string) error {
+	return defaultStore.Watch(ctx, path)
+}
+
+// Watch watches the given path for changes and reloads the store's features.
 //
 // In reality, Watch watches the directory of the given path, rather than the
 // actual filepath, to handle cases where the file is deleted. Filesystem events
@@ -18,11 +24,12 @@ import (
 //
 // When the config path is modified, Watch uses InitFromFile to read the file,
 // ensure it is non-empty, unmarshal it from json, and validate the feature
-// specs before swapping in the config.
+// specs before swapping in the config. Modifications made by the store's own
+// backend (see NewConfigFileBackend) are ignored.
 //
 // The watch continues until the watcher closes either the Events or Errors
 // channels, or until the context is cancelled or expired.
-func Watch(ctx context.Context, path string) error {
+func (s *Store) Watch(ctx context.Context, path string) error {
 	path, err := filepath.Abs(path)
 	if err != nil {
 		return err
@@ -65,9 +72,14 @@ func Watch(ctx context.Context, path string) error {
 					continue
 				}
 
+				if s.isOwnWrite(event.Name) {
+					log.Print("[watch] config written by the store's backend, ignoring")
+					continue
+				}
+
 				log.Print("[watch] detected config change. reloading ...")
 
-				if err := InitFromFile(event.Name); err != nil {
+				if err := s.initFromFile(event.Name, "watch"); err != nil {
 					// Purely for logging considerations, distinguish between
 					// errors that already contain the pathname vs those that
 					// don't.
@@ -92,3 +104,19 @@ func Watch(ctx context.Context, path string) error {
 
 	return nil
 }
+
+// isOwnWrite returns whether the file at path currently holds exactly what the
+// store's backend last wrote to it.
+func (s *Store) isOwnWrite(path string) bool {
+	sw, ok := s.backend.(selfWriter)
+	if !ok {
+		return false
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	return sw.wrote(path, data)
+}
@@ -5,11 +5,14 @@ package feature
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -33,6 +36,8 @@ const (
 	Feature_CONSTANT         Feature_Type = 1
 	Feature_PERCENTAGE_BASED Feature_Type = 2
 	Feature_EXPRESSION       Feature_Type = 3
+	Feature_VARIANT          Feature_Type = 4
+	Feature_RULES            Feature_Type = 5
 )
 
 var Feature_Type_name = map[int32]string{
@@ -40,6 +45,8 @@ var Feature_Type_name = map[int32]string{
 	1: "CONSTANT",
 	2: "PERCENTAGE_BASED",
 	3: "EXPRESSION",
+	4: "VARIANT",
+	5: "RULES",
 }
 
 var Feature_Type_value = map[string]int32{
@@ -47,6 +54,8 @@ var Feature_Type_value = map[string]int32{
 	"CONSTANT":         1,
 	"PERCENTAGE_BASED": 2,
 	"EXPRESSION":       3,
+	"VARIANT":          4,
+	"RULES":            5,
 }
 
 func (x Feature_Type) String() string {
@@ -57,6 +66,91 @@ func (Feature_Type) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_7767543e194ebda6, []int{0, 0}
 }
 
+type EvaluationDetail_Reason int32
+
+const (
+	EvaluationDetail_UNKNOWN EvaluationDetail_Reason = 0
+	// CONSTANT features resolve to their Enabled state.
+	EvaluationDetail_CONSTANT EvaluationDetail_Reason = 1
+	// PERCENTAGE features resolve based on the entity's bucket.
+	EvaluationDetail_PERCENTAGE EvaluationDetail_Reason = 2
+	// EXPRESSION features resolve to the result of their expression.
+	EvaluationDetail_EXPRESSION EvaluationDetail_Reason = 3
+	// VARIANT features resolve to the variant containing the entity's
+	// bucket.
+	EvaluationDetail_VARIANT EvaluationDetail_Reason = 4
+	// RULE_MATCH means a RULES feature resolved to the outcome of the
+	// rule at RuleIndex.
+	EvaluationDetail_RULE_MATCH EvaluationDetail_Reason = 5
+	// FALLTHROUGH means no rule of a RULES feature matched.
+	EvaluationDetail_FALLTHROUGH EvaluationDetail_Reason = 6
+	// ERROR means evaluation failed, and the feature resolved to the
+	// default (disabled, with no variant or value).
+	EvaluationDetail_ERROR EvaluationDetail_Reason = 7
+	// FEATURE_NOT_FOUND means there is no feature with the given name.
+	EvaluationDetail_FEATURE_NOT_FOUND EvaluationDetail_Reason = 8
+)
+
+var EvaluationDetail_Reason_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "CONSTANT",
+	2: "PERCENTAGE",
+	3: "EXPRESSION",
+	4: "VARIANT",
+	5: "RULE_MATCH",
+	6: "FALLTHROUGH",
+	7: "ERROR",
+	8: "FEATURE_NOT_FOUND",
+}
+
+var EvaluationDetail_Reason_value = map[string]int32{
+	"UNKNOWN":           0,
+	"CONSTANT":          1,
+	"PERCENTAGE":        2,
+	"EXPRESSION":        3,
+	"VARIANT":           4,
+	"RULE_MATCH":        5,
+	"FALLTHROUGH":       6,
+	"ERROR":             7,
+	"FEATURE_NOT_FOUND": 8,
+}
+
+func (x EvaluationDetail_Reason) String() string {
+	return proto.EnumName(EvaluationDetail_Reason_name, int32(x))
+}
+
+func (EvaluationDetail_Reason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{5, 0}
+}
+
+type FeatureEvent_Type int32
+
+const (
+	FeatureEvent_UNKNOWN FeatureEvent_Type = 0
+	FeatureEvent_SET     FeatureEvent_Type = 1
+	FeatureEvent_DELETE  FeatureEvent_Type = 2
+)
+
+var FeatureEvent_Type_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "SET",
+	2: "DELETE",
+}
+
+var FeatureEvent_Type_value = map[string]int32{
+	"UNKNOWN": 0,
+	"SET":     1,
+	"DELETE":  2,
+}
+
+func (x FeatureEvent_Type) String() string {
+	return proto.EnumName(FeatureEvent_Type_name, int32(x))
+}
+
+func (FeatureEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{16, 0}
+}
+
 type Feature struct {
 	Name string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Type Feature_Type `protobuf:"varint,2,opt,name=type,proto3,enum=feature.Feature_Type" json:"type,omitempty"`
@@ -72,7 +166,37 @@ type Feature struct {
 	Expression string `protobuf:"bytes,5,opt,name=expression,proto3" json:"expression,omitempty"`
 	// Description is a human-readable description of what this feature flag
 	// is for.
-	Description          string   `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	// BucketingKey is the name of the parameter used to deterministically
+	// assign an entity (e.g. "user_id") to a bucket for PERCENTAGE_BASED and
+	// VARIANT features. If empty, each evaluation is randomly bucketed.
+	BucketingKey string `protobuf:"bytes,7,opt,name=bucketing_key,json=bucketingKey,proto3" json:"bucketing_key,omitempty"`
+	// Salt is mixed into the hash of the bucketing key so that different
+	// features do not bucket the same entities identically. If empty, the
+	// feature name is used.
+	Salt string `protobuf:"bytes,8,opt,name=salt,proto3" json:"salt,omitempty"`
+	// Variants is the list of variants an entity may be assigned for VARIANT
+	// type features. Assignment uses the same bucketing key and salt as
+	// PERCENTAGE_BASED features.
+	Variants []*Feature_Variant `protobuf:"bytes,9,rep,name=variants,proto3" json:"variants,omitempty"`
+	// Value is a typed value (e.g. a timeout, batch size, or endpoint name)
+	// associated with this feature. It is returned by the typed accessors
+	// (feature.GetString, etc) whenever the feature is enabled.
+	Value *Value `protobuf:"bytes,10,opt,name=value,proto3" json:"value,omitempty"`
+	// Rules is an ordered list of targeting rules for RULES type features.
+	// The outcome of the first rule whose condition evaluates to true is used.
+	Rules []*Rule `protobuf:"bytes,11,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Fallthrough is the outcome used for RULES type features when no rule
+	// matches. If unset, the feature is disabled.
+	Fallthrough *Outcome `protobuf:"bytes,12,opt,name=fallthrough,proto3" json:"fallthrough,omitempty"`
+	// Version is incremented by the server every time the feature is set or
+	// deleted. Versions keep increasing if a deleted feature is re-created,
+	// so each version identifies a single revision in the feature's history.
+	Version uint64 `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	// Protected features cannot be changed by one person alone. Changes to
+	// them (including to or from being protected) are held as a
+	// PendingChange until someone other than the requester approves them.
+	Protected            bool     `protobuf:"varint,14,opt,name=protected,proto3" json:"protected,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -153,25 +277,86 @@ func (m *Feature) GetDescription() string {
 	return ""
 }
 
-type DeleteFeatureRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+func (m *Feature) GetBucketingKey() string {
+	if m != nil {
+		return m.BucketingKey
+	}
+	return ""
+}
+
+func (m *Feature) GetSalt() string {
+	if m != nil {
+		return m.Salt
+	}
+	return ""
+}
+
+func (m *Feature) GetVariants() []*Feature_Variant {
+	if m != nil {
+		return m.Variants
+	}
+	return nil
+}
+
+func (m *Feature) GetValue() *Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Feature) GetRules() []*Rule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *Feature) GetFallthrough() *Outcome {
+	if m != nil {
+		return m.Fallthrough
+	}
+	return nil
+}
+
+func (m *Feature) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Feature) GetProtected() bool {
+	if m != nil {
+		return m.Protected
+	}
+	return false
+}
+
+// Variant is a named arm of a VARIANT feature, e.g. for A/B/n experiments.
+type Feature_Variant struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Weight is the percentage [0, 100] of entities that are assigned
+	// this variant. The weights of all of a feature's variants must sum
+	// to 100.
+	Weight               uint32   `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteFeatureRequest) Reset()         { *m = DeleteFeatureRequest{} }
-func (m *DeleteFeatureRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteFeatureRequest) ProtoMessage()    {}
-func (*DeleteFeatureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7767543e194ebda6, []int{1}
+func (m *Feature_Variant) Reset()         { *m = Feature_Variant{} }
+func (m *Feature_Variant) String() string { return proto.CompactTextString(m) }
+func (*Feature_Variant) ProtoMessage()    {}
+func (*Feature_Variant) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{0, 0}
 }
-func (m *DeleteFeatureRequest) XXX_Unmarshal(b []byte) error {
+func (m *Feature_Variant) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Feature_Variant) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteFeatureRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Feature_Variant.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -181,45 +366,55 @@ func (m *DeleteFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *DeleteFeatureRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteFeatureRequest.Merge(m, src)
+func (m *Feature_Variant) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Feature_Variant.Merge(m, src)
 }
-func (m *DeleteFeatureRequest) XXX_Size() int {
+func (m *Feature_Variant) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteFeatureRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteFeatureRequest.DiscardUnknown(m)
+func (m *Feature_Variant) XXX_DiscardUnknown() {
+	xxx_messageInfo_Feature_Variant.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteFeatureRequest proto.InternalMessageInfo
+var xxx_messageInfo_Feature_Variant proto.InternalMessageInfo
 
-func (m *DeleteFeatureRequest) GetName() string {
+func (m *Feature_Variant) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-type DeleteFeatureResponse struct {
-	// Feature is the deleted feature, or nil if there was no such feature.
-	Feature              *Feature `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
+func (m *Feature_Variant) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+// Rule is a single targeting rule of a RULES feature.
+type Rule struct {
+	// Expression is a govaluate expression that must evaluate to a bool. If
+	// it evaluates to true, the rule matches.
+	Expression           string   `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	Outcome              *Outcome `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteFeatureResponse) Reset()         { *m = DeleteFeatureResponse{} }
-func (m *DeleteFeatureResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteFeatureResponse) ProtoMessage()    {}
-func (*DeleteFeatureResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7767543e194ebda6, []int{2}
+func (m *Rule) Reset()         { *m = Rule{} }
+func (m *Rule) String() string { return proto.CompactTextString(m) }
+func (*Rule) ProtoMessage()    {}
+func (*Rule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{1}
 }
-func (m *DeleteFeatureResponse) XXX_Unmarshal(b []byte) error {
+func (m *Rule) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Rule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteFeatureResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Rule.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -229,44 +424,59 @@ func (m *DeleteFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *DeleteFeatureResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteFeatureResponse.Merge(m, src)
+func (m *Rule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rule.Merge(m, src)
 }
-func (m *DeleteFeatureResponse) XXX_Size() int {
+func (m *Rule) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteFeatureResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteFeatureResponse.DiscardUnknown(m)
+func (m *Rule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rule.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteFeatureResponse proto.InternalMessageInfo
+var xxx_messageInfo_Rule proto.InternalMessageInfo
 
-func (m *DeleteFeatureResponse) GetFeature() *Feature {
+func (m *Rule) GetExpression() string {
 	if m != nil {
-		return m.Feature
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *Rule) GetOutcome() *Outcome {
+	if m != nil {
+		return m.Outcome
 	}
 	return nil
 }
 
-type GetFeatureRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+// Outcome is the result of a matching Rule (or of a RULES feature's
+// fallthrough).
+type Outcome struct {
+	// Types that are valid to be assigned to Kind:
+	//	*Outcome_Enabled
+	//	*Outcome_Percentage
+	//	*Outcome_Variant
+	Kind isOutcome_Kind `protobuf_oneof:"kind"`
+	// Value overrides the feature's Value when this outcome is enabled.
+	Value                *Value   `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetFeatureRequest) Reset()         { *m = GetFeatureRequest{} }
-func (m *GetFeatureRequest) String() string { return proto.CompactTextString(m) }
-func (*GetFeatureRequest) ProtoMessage()    {}
-func (*GetFeatureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7767543e194ebda6, []int{3}
+func (m *Outcome) Reset()         { *m = Outcome{} }
+func (m *Outcome) String() string { return proto.CompactTextString(m) }
+func (*Outcome) ProtoMessage()    {}
+func (*Outcome) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{2}
 }
-func (m *GetFeatureRequest) XXX_Unmarshal(b []byte) error {
+func (m *Outcome) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Outcome) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetFeatureRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Outcome.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -276,44 +486,107 @@ func (m *GetFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *GetFeatureRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFeatureRequest.Merge(m, src)
+func (m *Outcome) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Outcome.Merge(m, src)
 }
-func (m *GetFeatureRequest) XXX_Size() int {
+func (m *Outcome) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetFeatureRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFeatureRequest.DiscardUnknown(m)
+func (m *Outcome) XXX_DiscardUnknown() {
+	xxx_messageInfo_Outcome.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFeatureRequest proto.InternalMessageInfo
+var xxx_messageInfo_Outcome proto.InternalMessageInfo
 
-func (m *GetFeatureRequest) GetName() string {
+type isOutcome_Kind interface {
+	isOutcome_Kind()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type Outcome_Enabled struct {
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+}
+type Outcome_Percentage struct {
+	Percentage uint32 `protobuf:"varint,2,opt,name=percentage,proto3,oneof" json:"percentage,omitempty"`
+}
+type Outcome_Variant struct {
+	Variant string `protobuf:"bytes,3,opt,name=variant,proto3,oneof" json:"variant,omitempty"`
+}
+
+func (*Outcome_Enabled) isOutcome_Kind()    {}
+func (*Outcome_Percentage) isOutcome_Kind() {}
+func (*Outcome_Variant) isOutcome_Kind()    {}
+
+func (m *Outcome) GetKind() isOutcome_Kind {
 	if m != nil {
-		return m.Name
+		return m.Kind
+	}
+	return nil
+}
+
+func (m *Outcome) GetEnabled() bool {
+	if x, ok := m.GetKind().(*Outcome_Enabled); ok {
+		return x.Enabled
+	}
+	return false
+}
+
+func (m *Outcome) GetPercentage() uint32 {
+	if x, ok := m.GetKind().(*Outcome_Percentage); ok {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (m *Outcome) GetVariant() string {
+	if x, ok := m.GetKind().(*Outcome_Variant); ok {
+		return x.Variant
 	}
 	return ""
 }
 
-type GetFeatureResponse struct {
-	Feature              *Feature `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Outcome) GetValue() *Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
 }
 
-func (m *GetFeatureResponse) Reset()         { *m = GetFeatureResponse{} }
-func (m *GetFeatureResponse) String() string { return proto.CompactTextString(m) }
-func (*GetFeatureResponse) ProtoMessage()    {}
-func (*GetFeatureResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7767543e194ebda6, []int{4}
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Outcome) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Outcome_Enabled)(nil),
+		(*Outcome_Percentage)(nil),
+		(*Outcome_Variant)(nil),
+	}
 }
-func (m *GetFeatureResponse) XXX_Unmarshal(b []byte) error {
+
+// Value is a typed feature value.
+type Value struct {
+	// Types that are valid to be assigned to Kind:
+	//	*Value_StringValue
+	//	*Value_IntValue
+	//	*Value_FloatValue
+	//	*Value_JsonValue
+	Kind                 isValue_Kind `protobuf_oneof:"kind"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Value) Reset()         { *m = Value{} }
+func (m *Value) String() string { return proto.CompactTextString(m) }
+func (*Value) ProtoMessage()    {}
+func (*Value) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{3}
+}
+func (m *Value) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Value) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetFeatureResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Value.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -323,44 +596,123 @@ func (m *GetFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *GetFeatureResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFeatureResponse.Merge(m, src)
+func (m *Value) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Value.Merge(m, src)
 }
-func (m *GetFeatureResponse) XXX_Size() int {
+func (m *Value) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetFeatureResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFeatureResponse.DiscardUnknown(m)
+func (m *Value) XXX_DiscardUnknown() {
+	xxx_messageInfo_Value.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFeatureResponse proto.InternalMessageInfo
+var xxx_messageInfo_Value proto.InternalMessageInfo
 
-func (m *GetFeatureResponse) GetFeature() *Feature {
+type isValue_Kind interface {
+	isValue_Kind()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type Value_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof" json:"string_value,omitempty"`
+}
+type Value_IntValue struct {
+	IntValue int64 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof" json:"int_value,omitempty"`
+}
+type Value_FloatValue struct {
+	FloatValue float64 `protobuf:"fixed64,3,opt,name=float_value,json=floatValue,proto3,oneof" json:"float_value,omitempty"`
+}
+type Value_JsonValue struct {
+	JsonValue string `protobuf:"bytes,4,opt,name=json_value,json=jsonValue,proto3,oneof" json:"json_value,omitempty"`
+}
+
+func (*Value_StringValue) isValue_Kind() {}
+func (*Value_IntValue) isValue_Kind()    {}
+func (*Value_FloatValue) isValue_Kind()  {}
+func (*Value_JsonValue) isValue_Kind()   {}
+
+func (m *Value) GetKind() isValue_Kind {
 	if m != nil {
-		return m.Feature
+		return m.Kind
 	}
 	return nil
 }
 
-type GetFeaturesRequest struct {
-	NamesOnly            bool     `protobuf:"varint,1,opt,name=names_only,json=namesOnly,proto3" json:"names_only,omitempty"`
+func (m *Value) GetStringValue() string {
+	if x, ok := m.GetKind().(*Value_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (m *Value) GetIntValue() int64 {
+	if x, ok := m.GetKind().(*Value_IntValue); ok {
+		return x.IntValue
+	}
+	return 0
+}
+
+func (m *Value) GetFloatValue() float64 {
+	if x, ok := m.GetKind().(*Value_FloatValue); ok {
+		return x.FloatValue
+	}
+	return 0
+}
+
+func (m *Value) GetJsonValue() string {
+	if x, ok := m.GetKind().(*Value_JsonValue); ok {
+		return x.JsonValue
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Value) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Value_StringValue)(nil),
+		(*Value_IntValue)(nil),
+		(*Value_FloatValue)(nil),
+		(*Value_JsonValue)(nil),
+	}
+}
+
+// Segment is a reusable, named set of entities that features may reference in
+// their expressions as `[segment:name]`.
+type Segment struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Key is the name of the parameter (e.g. "user_id") whose value is
+	// matched against the Included and Excluded lists.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Included is a list of key values that are always in the segment, unless
+	// also excluded.
+	Included []string `protobuf:"bytes,3,rep,name=included,proto3" json:"included,omitempty"`
+	// Excluded is a list of key values that are never in the segment. This
+	// takes precedence over both Included and Rules.
+	Excluded []string `protobuf:"bytes,4,rep,name=excluded,proto3" json:"excluded,omitempty"`
+	// Rules is a list of govaluate expressions. An entity that is not
+	// explicitly included or excluded is in the segment if any rule evaluates
+	// to true.
+	Rules []string `protobuf:"bytes,5,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Description is a human-readable description of the segment.
+	Description          string   `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetFeaturesRequest) Reset()         { *m = GetFeaturesRequest{} }
-func (m *GetFeaturesRequest) String() string { return proto.CompactTextString(m) }
-func (*GetFeaturesRequest) ProtoMessage()    {}
-func (*GetFeaturesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7767543e194ebda6, []int{5}
+func (m *Segment) Reset()         { *m = Segment{} }
+func (m *Segment) String() string { return proto.CompactTextString(m) }
+func (*Segment) ProtoMessage()    {}
+func (*Segment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{4}
 }
-func (m *GetFeaturesRequest) XXX_Unmarshal(b []byte) error {
+func (m *Segment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetFeaturesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Segment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetFeaturesRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Segment.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -370,45 +722,94 @@ func (m *GetFeaturesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *GetFeaturesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFeaturesRequest.Merge(m, src)
+func (m *Segment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Segment.Merge(m, src)
 }
-func (m *GetFeaturesRequest) XXX_Size() int {
+func (m *Segment) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetFeaturesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFeaturesRequest.DiscardUnknown(m)
+func (m *Segment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Segment.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFeaturesRequest proto.InternalMessageInfo
+var xxx_messageInfo_Segment proto.InternalMessageInfo
 
-func (m *GetFeaturesRequest) GetNamesOnly() bool {
+func (m *Segment) GetName() string {
 	if m != nil {
-		return m.NamesOnly
+		return m.Name
 	}
-	return false
+	return ""
 }
 
-type GetFeaturesResponse struct {
-	Features             []*Feature `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
-	Names                []string   `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *Segment) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
 }
 
-func (m *GetFeaturesResponse) Reset()         { *m = GetFeaturesResponse{} }
-func (m *GetFeaturesResponse) String() string { return proto.CompactTextString(m) }
-func (*GetFeaturesResponse) ProtoMessage()    {}
-func (*GetFeaturesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7767543e194ebda6, []int{6}
-}
-func (m *GetFeaturesResponse) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+func (m *Segment) GetIncluded() []string {
+	if m != nil {
+		return m.Included
+	}
+	return nil
 }
-func (m *GetFeaturesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+
+func (m *Segment) GetExcluded() []string {
+	if m != nil {
+		return m.Excluded
+	}
+	return nil
+}
+
+func (m *Segment) GetRules() []string {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *Segment) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+// EvaluationDetail describes the result of evaluating a feature, and why it
+// resolved the way it did.
+type EvaluationDetail struct {
+	Name    string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled bool                    `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Variant string                  `protobuf:"bytes,3,opt,name=variant,proto3" json:"variant,omitempty"`
+	Value   *Value                  `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Reason  EvaluationDetail_Reason `protobuf:"varint,5,opt,name=reason,proto3,enum=feature.EvaluationDetail_Reason" json:"reason,omitempty"`
+	// Bucket is the entity's bucket in [0, 100), or -1 if the evaluation did
+	// not bucket the entity.
+	Bucket int32 `protobuf:"varint,6,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// RuleIndex is the index of the matching rule for RULE_MATCH, or -1.
+	RuleIndex int32 `protobuf:"varint,7,opt,name=rule_index,json=ruleIndex,proto3" json:"rule_index,omitempty"`
+	// Error is the evaluation error for ERROR and FEATURE_NOT_FOUND.
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// Version is the version of the feature that was evaluated.
+	Version              uint64   `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvaluationDetail) Reset()         { *m = EvaluationDetail{} }
+func (m *EvaluationDetail) String() string { return proto.CompactTextString(m) }
+func (*EvaluationDetail) ProtoMessage()    {}
+func (*EvaluationDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{5}
+}
+func (m *EvaluationDetail) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EvaluationDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetFeaturesResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_EvaluationDetail.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -418,51 +819,111 @@ func (m *GetFeaturesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *GetFeaturesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFeaturesResponse.Merge(m, src)
+func (m *EvaluationDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluationDetail.Merge(m, src)
 }
-func (m *GetFeaturesResponse) XXX_Size() int {
+func (m *EvaluationDetail) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetFeaturesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFeaturesResponse.DiscardUnknown(m)
+func (m *EvaluationDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluationDetail.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFeaturesResponse proto.InternalMessageInfo
+var xxx_messageInfo_EvaluationDetail proto.InternalMessageInfo
 
-func (m *GetFeaturesResponse) GetFeatures() []*Feature {
+func (m *EvaluationDetail) GetName() string {
 	if m != nil {
-		return m.Features
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-func (m *GetFeaturesResponse) GetNames() []string {
+func (m *EvaluationDetail) GetEnabled() bool {
 	if m != nil {
-		return m.Names
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *EvaluationDetail) GetVariant() string {
+	if m != nil {
+		return m.Variant
+	}
+	return ""
+}
+
+func (m *EvaluationDetail) GetValue() *Value {
+	if m != nil {
+		return m.Value
 	}
 	return nil
 }
 
-type SetFeatureRequest struct {
-	Feature              *Feature `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
+func (m *EvaluationDetail) GetReason() EvaluationDetail_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return EvaluationDetail_UNKNOWN
+}
+
+func (m *EvaluationDetail) GetBucket() int32 {
+	if m != nil {
+		return m.Bucket
+	}
+	return 0
+}
+
+func (m *EvaluationDetail) GetRuleIndex() int32 {
+	if m != nil {
+		return m.RuleIndex
+	}
+	return 0
+}
+
+func (m *EvaluationDetail) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *EvaluationDetail) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// FeatureOperation is a single set or delete of a feature, as part of an
+// ApplyFeatures batch.
+type FeatureOperation struct {
+	// Types that are valid to be assigned to Op:
+	//	*FeatureOperation_Set
+	//	*FeatureOperation_Delete
+	Op isFeatureOperation_Op `protobuf_oneof:"op"`
+	// ExpectedVersion, if non-zero, makes the whole batch conditional on the
+	// feature being at that version. See SetFeatureRequest.
+	ExpectedVersion uint64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// ExpectAbsent makes the whole batch conditional on the feature not
+	// existing. See SetFeatureRequest.
+	ExpectAbsent         bool     `protobuf:"varint,4,opt,name=expect_absent,json=expectAbsent,proto3" json:"expect_absent,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetFeatureRequest) Reset()         { *m = SetFeatureRequest{} }
-func (m *SetFeatureRequest) String() string { return proto.CompactTextString(m) }
-func (*SetFeatureRequest) ProtoMessage()    {}
-func (*SetFeatureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7767543e194ebda6, []int{7}
+func (m *FeatureOperation) Reset()         { *m = FeatureOperation{} }
+func (m *FeatureOperation) String() string { return proto.CompactTextString(m) }
+func (*FeatureOperation) ProtoMessage()    {}
+func (*FeatureOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{6}
 }
-func (m *SetFeatureRequest) XXX_Unmarshal(b []byte) error {
+func (m *FeatureOperation) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SetFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FeatureOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SetFeatureRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FeatureOperation.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -472,45 +933,101 @@ func (m *SetFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *SetFeatureRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetFeatureRequest.Merge(m, src)
+func (m *FeatureOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeatureOperation.Merge(m, src)
 }
-func (m *SetFeatureRequest) XXX_Size() int {
+func (m *FeatureOperation) XXX_Size() int {
 	return m.Size()
 }
-func (m *SetFeatureRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetFeatureRequest.DiscardUnknown(m)
+func (m *FeatureOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeatureOperation.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetFeatureRequest proto.InternalMessageInfo
+var xxx_messageInfo_FeatureOperation proto.InternalMessageInfo
 
-func (m *SetFeatureRequest) GetFeature() *Feature {
+type isFeatureOperation_Op interface {
+	isFeatureOperation_Op()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type FeatureOperation_Set struct {
+	Set *Feature `protobuf:"bytes,1,opt,name=set,proto3,oneof" json:"set,omitempty"`
+}
+type FeatureOperation_Delete struct {
+	Delete string `protobuf:"bytes,2,opt,name=delete,proto3,oneof" json:"delete,omitempty"`
+}
+
+func (*FeatureOperation_Set) isFeatureOperation_Op()    {}
+func (*FeatureOperation_Delete) isFeatureOperation_Op() {}
+
+func (m *FeatureOperation) GetOp() isFeatureOperation_Op {
 	if m != nil {
-		return m.Feature
+		return m.Op
 	}
 	return nil
 }
 
-type SetFeatureResponse struct {
-	Before               *Feature `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
-	After                *Feature `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+func (m *FeatureOperation) GetSet() *Feature {
+	if x, ok := m.GetOp().(*FeatureOperation_Set); ok {
+		return x.Set
+	}
+	return nil
+}
+
+func (m *FeatureOperation) GetDelete() string {
+	if x, ok := m.GetOp().(*FeatureOperation_Delete); ok {
+		return x.Delete
+	}
+	return ""
+}
+
+func (m *FeatureOperation) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+func (m *FeatureOperation) GetExpectAbsent() bool {
+	if m != nil {
+		return m.ExpectAbsent
+	}
+	return false
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*FeatureOperation) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*FeatureOperation_Set)(nil),
+		(*FeatureOperation_Delete)(nil),
+	}
+}
+
+// FeatureChange is the effect of a FeatureOperation.
+type FeatureChange struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Before is the feature prior to the change, or nil if it was created.
+	Before *Feature `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// After is the feature after the change, or nil if it was deleted.
+	After                *Feature `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetFeatureResponse) Reset()         { *m = SetFeatureResponse{} }
-func (m *SetFeatureResponse) String() string { return proto.CompactTextString(m) }
-func (*SetFeatureResponse) ProtoMessage()    {}
-func (*SetFeatureResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7767543e194ebda6, []int{8}
+func (m *FeatureChange) Reset()         { *m = FeatureChange{} }
+func (m *FeatureChange) String() string { return proto.CompactTextString(m) }
+func (*FeatureChange) ProtoMessage()    {}
+func (*FeatureChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{7}
 }
-func (m *SetFeatureResponse) XXX_Unmarshal(b []byte) error {
+func (m *FeatureChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SetFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FeatureChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SetFeatureResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FeatureChange.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -520,852 +1037,14523 @@ func (m *SetFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *SetFeatureResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetFeatureResponse.Merge(m, src)
+func (m *FeatureChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeatureChange.Merge(m, src)
 }
-func (m *SetFeatureResponse) XXX_Size() int {
+func (m *FeatureChange) XXX_Size() int {
 	return m.Size()
 }
-func (m *SetFeatureResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetFeatureResponse.DiscardUnknown(m)
+func (m *FeatureChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeatureChange.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetFeatureResponse proto.InternalMessageInfo
+var xxx_messageInfo_FeatureChange proto.InternalMessageInfo
 
-func (m *SetFeatureResponse) GetBefore() *Feature {
+func (m *FeatureChange) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FeatureChange) GetBefore() *Feature {
 	if m != nil {
 		return m.Before
 	}
 	return nil
 }
 
-func (m *SetFeatureResponse) GetAfter() *Feature {
+func (m *FeatureChange) GetAfter() *Feature {
 	if m != nil {
 		return m.After
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("feature.Feature_Type", Feature_Type_name, Feature_Type_value)
-	proto.RegisterType((*Feature)(nil), "feature.Feature")
-	proto.RegisterType((*DeleteFeatureRequest)(nil), "feature.DeleteFeatureRequest")
-	proto.RegisterType((*DeleteFeatureResponse)(nil), "feature.DeleteFeatureResponse")
-	proto.RegisterType((*GetFeatureRequest)(nil), "feature.GetFeatureRequest")
-	proto.RegisterType((*GetFeatureResponse)(nil), "feature.GetFeatureResponse")
-	proto.RegisterType((*GetFeaturesRequest)(nil), "feature.GetFeaturesRequest")
-	proto.RegisterType((*GetFeaturesResponse)(nil), "feature.GetFeaturesResponse")
-	proto.RegisterType((*SetFeatureRequest)(nil), "feature.SetFeatureRequest")
-	proto.RegisterType((*SetFeatureResponse)(nil), "feature.SetFeatureResponse")
+type ApplyFeaturesRequest struct {
+	// Operations are applied together: either all of them succeed, or none
+	// of them are applied. Each feature may appear in at most one operation.
+	Operations []*FeatureOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// DryRun validates the operations and returns the changes they would
+	// make, without applying them.
+	DryRun               bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("proto/feature.proto", fileDescriptor_7767543e194ebda6) }
-
-var fileDescriptor_7767543e194ebda6 = []byte{
-	// 508 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x5b, 0x6e, 0xd3, 0x50,
-	0x10, 0xcd, 0x75, 0x5e, 0xce, 0x84, 0x16, 0x77, 0x9a, 0x4a, 0x56, 0xa0, 0x96, 0xf1, 0x07, 0x98,
-	0x0a, 0xa5, 0x52, 0xba, 0x00, 0x48, 0x53, 0x13, 0x01, 0x92, 0x13, 0x5d, 0x07, 0xf1, 0xf8, 0xa9,
-	0x9c, 0x76, 0x82, 0x2a, 0x05, 0xdb, 0xd8, 0xae, 0x44, 0x16, 0xc1, 0x3f, 0x6b, 0x61, 0x05, 0x7c,
-	0xb2, 0x04, 0x14, 0x36, 0x82, 0x72, 0x63, 0x1b, 0xa7, 0x31, 0x11, 0xf4, 0xcf, 0x33, 0x73, 0xee,
-	0x39, 0xe7, 0x9e, 0xb9, 0x32, 0xec, 0x07, 0xa1, 0x1f, 0xfb, 0xc7, 0x53, 0x72, 0xe3, 0xeb, 0x90,
-	0x3a, 0xa2, 0xc2, 0x7a, 0x52, 0x1a, 0x5f, 0x24, 0xa8, 0x3f, 0x5f, 0x7d, 0x23, 0x42, 0xc5, 0x73,
-	0x3f, 0x92, 0xca, 0x74, 0x66, 0x36, 0xb8, 0xf8, 0xc6, 0xc7, 0x50, 0x89, 0xe7, 0x01, 0xa9, 0x92,
-	0xce, 0xcc, 0xdd, 0xee, 0x41, 0x27, 0xa5, 0x49, 0xce, 0x74, 0xc6, 0xf3, 0x80, 0xb8, 0x80, 0xa0,
-	0x0a, 0x75, 0xf2, 0xdc, 0xc9, 0x8c, 0x2e, 0xd5, 0xb2, 0xce, 0x4c, 0x99, 0xa7, 0x25, 0x6a, 0x00,
-	0x01, 0x85, 0x17, 0xe4, 0xc5, 0xee, 0x07, 0x52, 0x2b, 0x3a, 0x33, 0x77, 0x78, 0xae, 0xb3, 0x9c,
-	0xd3, 0xe7, 0x20, 0xa4, 0x28, 0xba, 0xf2, 0x3d, 0xb5, 0x2a, 0xe4, 0x73, 0x1d, 0xd4, 0xa1, 0x79,
-	0x49, 0xd1, 0x45, 0x78, 0x15, 0xc4, 0x4b, 0x40, 0x4d, 0x00, 0xf2, 0x2d, 0x63, 0x00, 0x95, 0xa5,
-	0x13, 0x6c, 0x42, 0xfd, 0xb5, 0xfd, 0xca, 0x1e, 0xbe, 0xb1, 0x95, 0x12, 0xde, 0x01, 0xb9, 0x3f,
-	0xb4, 0x9d, 0x71, 0xcf, 0x1e, 0x2b, 0x0c, 0x5b, 0xa0, 0x8c, 0x2c, 0xde, 0xb7, 0xec, 0x71, 0x6f,
-	0x60, 0x9d, 0x9f, 0xf6, 0x1c, 0xeb, 0x4c, 0x91, 0x70, 0x17, 0xc0, 0x7a, 0x3b, 0xe2, 0x96, 0xe3,
-	0xbc, 0x18, 0xda, 0x4a, 0xd9, 0x38, 0x82, 0xd6, 0x19, 0xcd, 0x28, 0xa6, 0xe4, 0x82, 0x9c, 0x3e,
-	0x5d, 0x53, 0x14, 0x17, 0x65, 0x63, 0xf4, 0xe1, 0xe0, 0x06, 0x36, 0x0a, 0x7c, 0x2f, 0x22, 0x3c,
-	0x82, 0x34, 0x5f, 0x81, 0x6f, 0x76, 0x95, 0x9b, 0xb9, 0xf1, 0x6c, 0x01, 0x8f, 0x60, 0x6f, 0x40,
-	0xf1, 0x3f, 0xa8, 0x3d, 0x03, 0xcc, 0x03, 0x6f, 0x21, 0x75, 0x92, 0x67, 0x88, 0x52, 0xad, 0x43,
-	0x80, 0x25, 0x7f, 0x74, 0xee, 0x7b, 0xb3, 0xb9, 0x20, 0x91, 0x79, 0x43, 0x74, 0x86, 0xde, 0x6c,
-	0x6e, 0xbc, 0x83, 0xfd, 0xb5, 0x43, 0x89, 0xee, 0x13, 0x90, 0x13, 0xda, 0x48, 0x65, 0x7a, 0xb9,
-	0x50, 0x38, 0x43, 0x60, 0x0b, 0xaa, 0x82, 0x51, 0x95, 0xf4, 0xb2, 0xd9, 0xe0, 0xab, 0xc2, 0x78,
-	0x0a, 0x7b, 0xce, 0xc6, 0xd5, 0xff, 0xe7, 0x42, 0x53, 0x40, 0x67, 0x33, 0x12, 0x13, 0x6a, 0x13,
-	0x9a, 0xfa, 0x5b, 0x08, 0x92, 0x39, 0x3e, 0x84, 0xaa, 0x3b, 0x8d, 0x29, 0x14, 0xaf, 0xbb, 0x08,
-	0xb8, 0x1a, 0x77, 0xbf, 0x49, 0x20, 0xa7, 0x09, 0xe0, 0x08, 0x76, 0xd6, 0xb6, 0x8e, 0x87, 0xd9,
-	0xb1, 0xa2, 0x97, 0xd3, 0xd6, 0xfe, 0x36, 0x5e, 0xd9, 0x35, 0x4a, 0x38, 0x00, 0xf8, 0x13, 0x31,
-	0xb6, 0x33, 0xfc, 0xc6, 0xbb, 0x68, 0xdf, 0x2b, 0x9c, 0x65, 0x44, 0x2f, 0xa1, 0x99, 0xdb, 0x15,
-	0x16, 0xa1, 0xd3, 0xb5, 0xb7, 0xef, 0x17, 0x0f, 0xf3, 0xa6, 0x9c, 0x22, 0x53, 0xce, 0x16, 0x53,
-	0x4e, 0x81, 0xa9, 0xd3, 0x07, 0xdf, 0x17, 0x1a, 0xfb, 0xb1, 0xd0, 0xd8, 0xcf, 0x85, 0xc6, 0xbe,
-	0xfe, 0xd2, 0x4a, 0xef, 0xef, 0x76, 0x8e, 0xd7, 0xfe, 0x49, 0x93, 0x9a, 0x28, 0x4f, 0x7e, 0x07,
-	0x00, 0x00, 0xff, 0xff, 0x2c, 0x34, 0x8c, 0x70, 0xab, 0x04, 0x00, 0x00,
+func (m *ApplyFeaturesRequest) Reset()         { *m = ApplyFeaturesRequest{} }
+func (m *ApplyFeaturesRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyFeaturesRequest) ProtoMessage()    {}
+func (*ApplyFeaturesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{8}
+}
+func (m *ApplyFeaturesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApplyFeaturesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplyFeaturesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ApplyFeaturesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyFeaturesRequest.Merge(m, src)
+}
+func (m *ApplyFeaturesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApplyFeaturesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyFeaturesRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_ApplyFeaturesRequest proto.InternalMessageInfo
 
-// FeaturesClient is the client API for Features service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type FeaturesClient interface {
-	DeleteFeature(ctx context.Context, in *DeleteFeatureRequest, opts ...grpc.CallOption) (*DeleteFeatureResponse, error)
-	GetFeature(ctx context.Context, in *GetFeatureRequest, opts ...grpc.CallOption) (*GetFeatureResponse, error)
-	GetFeatures(ctx context.Context, in *GetFeaturesRequest, opts ...grpc.CallOption) (*GetFeaturesResponse, error)
-	SetFeature(ctx context.Context, in *SetFeatureRequest, opts ...grpc.CallOption) (*SetFeatureResponse, error)
+func (m *ApplyFeaturesRequest) GetOperations() []*FeatureOperation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
 }
 
-type featuresClient struct {
-	cc *grpc.ClientConn
+func (m *ApplyFeaturesRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
 }
 
-func NewFeaturesClient(cc *grpc.ClientConn) FeaturesClient {
-	return &featuresClient{cc}
+type ApplyFeaturesResponse struct {
+	// Changes are the changes made (or that would be made, for a dry run),
+	// in the order of the operations. Operations that would not change
+	// anything (e.g. setting a feature to its current spec, or deleting a
+	// feature that does not exist) are omitted.
+	Changes []*FeatureChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Pending is set if any of the changes are to protected features, in
+	// which case none of them have been made yet: they are held together
+	// for approval.
+	Pending              *PendingChange `protobuf:"bytes,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (c *featuresClient) DeleteFeature(ctx context.Context, in *DeleteFeatureRequest, opts ...grpc.CallOption) (*DeleteFeatureResponse, error) {
-	out := new(DeleteFeatureResponse)
-	err := c.cc.Invoke(ctx, "/feature.Features/DeleteFeature", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ApplyFeaturesResponse) Reset()         { *m = ApplyFeaturesResponse{} }
+func (m *ApplyFeaturesResponse) String() string { return proto.CompactTextString(m) }
+func (*ApplyFeaturesResponse) ProtoMessage()    {}
+func (*ApplyFeaturesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{9}
+}
+func (m *ApplyFeaturesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApplyFeaturesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplyFeaturesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *ApplyFeaturesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyFeaturesResponse.Merge(m, src)
+}
+func (m *ApplyFeaturesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApplyFeaturesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyFeaturesResponse.DiscardUnknown(m)
 }
 
-func (c *featuresClient) GetFeature(ctx context.Context, in *GetFeatureRequest, opts ...grpc.CallOption) (*GetFeatureResponse, error) {
-	out := new(GetFeatureResponse)
-	err := c.cc.Invoke(ctx, "/feature.Features/GetFeature", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ApplyFeaturesResponse proto.InternalMessageInfo
+
+func (m *ApplyFeaturesResponse) GetChanges() []*FeatureChange {
+	if m != nil {
+		return m.Changes
 	}
-	return out, nil
+	return nil
 }
 
-func (c *featuresClient) GetFeatures(ctx context.Context, in *GetFeaturesRequest, opts ...grpc.CallOption) (*GetFeaturesResponse, error) {
-	out := new(GetFeaturesResponse)
-	err := c.cc.Invoke(ctx, "/feature.Features/GetFeatures", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ApplyFeaturesResponse) GetPending() *PendingChange {
+	if m != nil {
+		return m.Pending
 	}
-	return out, nil
+	return nil
 }
 
-func (c *featuresClient) SetFeature(ctx context.Context, in *SetFeatureRequest, opts ...grpc.CallOption) (*SetFeatureResponse, error) {
-	out := new(SetFeatureResponse)
-	err := c.cc.Invoke(ctx, "/feature.Features/SetFeature", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type DeleteFeatureRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// ExpectedVersion, if non-zero, makes the delete conditional on the
+	// feature being at that version. If it is not, the delete fails with
+	// FailedPrecondition.
+	ExpectedVersion      uint64   `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// FeaturesServer is the server API for Features service.
-type FeaturesServer interface {
-	DeleteFeature(context.Context, *DeleteFeatureRequest) (*DeleteFeatureResponse, error)
-	GetFeature(context.Context, *GetFeatureRequest) (*GetFeatureResponse, error)
-	GetFeatures(context.Context, *GetFeaturesRequest) (*GetFeaturesResponse, error)
-	SetFeature(context.Context, *SetFeatureRequest) (*SetFeatureResponse, error)
+func (m *DeleteFeatureRequest) Reset()         { *m = DeleteFeatureRequest{} }
+func (m *DeleteFeatureRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFeatureRequest) ProtoMessage()    {}
+func (*DeleteFeatureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{10}
 }
-
-// UnimplementedFeaturesServer can be embedded to have forward compatible implementations.
-type UnimplementedFeaturesServer struct {
+func (m *DeleteFeatureRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (*UnimplementedFeaturesServer) DeleteFeature(ctx context.Context, req *DeleteFeatureRequest) (*DeleteFeatureResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteFeature not implemented")
+func (m *DeleteFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteFeatureRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedFeaturesServer) GetFeature(ctx context.Context, req *GetFeatureRequest) (*GetFeatureResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFeature not implemented")
+func (m *DeleteFeatureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFeatureRequest.Merge(m, src)
 }
-func (*UnimplementedFeaturesServer) GetFeatures(ctx context.Context, req *GetFeaturesRequest) (*GetFeaturesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFeatures not implemented")
+func (m *DeleteFeatureRequest) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedFeaturesServer) SetFeature(ctx context.Context, req *SetFeatureRequest) (*SetFeatureResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetFeature not implemented")
+func (m *DeleteFeatureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFeatureRequest.DiscardUnknown(m)
 }
 
-func RegisterFeaturesServer(s *grpc.Server, srv FeaturesServer) {
-	s.RegisterService(&_Features_serviceDesc, srv)
-}
+var xxx_messageInfo_DeleteFeatureRequest proto.InternalMessageInfo
 
-func _Features_DeleteFeature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteFeatureRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(FeaturesServer).DeleteFeature(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/feature.Features/DeleteFeature",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FeaturesServer).DeleteFeature(ctx, req.(*DeleteFeatureRequest))
+func (m *DeleteFeatureRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Features_GetFeature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFeatureRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(FeaturesServer).GetFeature(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/feature.Features/GetFeature",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FeaturesServer).GetFeature(ctx, req.(*GetFeatureRequest))
+func (m *DeleteFeatureRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Features_GetFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFeaturesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(FeaturesServer).GetFeatures(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/feature.Features/GetFeatures",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FeaturesServer).GetFeatures(ctx, req.(*GetFeaturesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type DeleteFeatureResponse struct {
+	// Feature is the deleted feature, or nil if there was no such feature.
+	Feature *Feature `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
+	// Pending is set if the feature is protected, in which case it has not
+	// been deleted yet, but is awaiting approval.
+	Pending              *PendingChange `protobuf:"bytes,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func _Features_SetFeature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetFeatureRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(FeaturesServer).SetFeature(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/feature.Features/SetFeature",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FeaturesServer).SetFeature(ctx, req.(*SetFeatureRequest))
+func (m *DeleteFeatureResponse) Reset()         { *m = DeleteFeatureResponse{} }
+func (m *DeleteFeatureResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteFeatureResponse) ProtoMessage()    {}
+func (*DeleteFeatureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{11}
+}
+func (m *DeleteFeatureResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteFeatureResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *DeleteFeatureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFeatureResponse.Merge(m, src)
+}
+func (m *DeleteFeatureResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteFeatureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFeatureResponse.DiscardUnknown(m)
 }
 
-var _Features_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "feature.Features",
-	HandlerType: (*FeaturesServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "DeleteFeature",
-			Handler:    _Features_DeleteFeature_Handler,
-		},
-		{
-			MethodName: "GetFeature",
-			Handler:    _Features_GetFeature_Handler,
-		},
-		{
-			MethodName: "GetFeatures",
-			Handler:    _Features_GetFeatures_Handler,
-		},
-		{
-			MethodName: "SetFeature",
-			Handler:    _Features_SetFeature_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/feature.proto",
+var xxx_messageInfo_DeleteFeatureResponse proto.InternalMessageInfo
+
+func (m *DeleteFeatureResponse) GetFeature() *Feature {
+	if m != nil {
+		return m.Feature
+	}
+	return nil
 }
 
-func (m *Feature) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *DeleteFeatureResponse) GetPending() *PendingChange {
+	if m != nil {
+		return m.Pending
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Feature) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type EvaluateFeatureRequest struct {
+	Name                 string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Parameters           *structpb.Struct `protobuf:"bytes,2,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *Feature) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Description) > 0 {
-		i -= len(m.Description)
-		copy(dAtA[i:], m.Description)
-		i = encodeVarintFeature(dAtA, i, uint64(len(m.Description)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if len(m.Expression) > 0 {
-		i -= len(m.Expression)
-		copy(dAtA[i:], m.Expression)
-		i = encodeVarintFeature(dAtA, i, uint64(len(m.Expression)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if m.Percentage != 0 {
-		i = encodeVarintFeature(dAtA, i, uint64(m.Percentage))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Enabled {
-		i--
-		if m.Enabled {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Type != 0 {
-		i = encodeVarintFeature(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+func (m *EvaluateFeatureRequest) Reset()         { *m = EvaluateFeatureRequest{} }
+func (m *EvaluateFeatureRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateFeatureRequest) ProtoMessage()    {}
+func (*EvaluateFeatureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{12}
+}
+func (m *EvaluateFeatureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateFeatureRequest.Unmarshal(m, b)
+}
+func (m *EvaluateFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateFeatureRequest.Marshal(b, m, deterministic)
+}
+func (m *EvaluateFeatureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateFeatureRequest.Merge(m, src)
+}
+func (m *EvaluateFeatureRequest) XXX_Size() int {
+	return xxx_messageInfo_EvaluateFeatureRequest.Size(m)
+}
+func (m *EvaluateFeatureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateFeatureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateFeatureRequest proto.InternalMessageInfo
+
+func (m *EvaluateFeatureRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *DeleteFeatureRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *EvaluateFeatureRequest) GetParameters() *structpb.Struct {
+	if m != nil {
+		return m.Parameters
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *DeleteFeatureRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type EvaluateFeatureResponse struct {
+	Detail               *EvaluationDetail `protobuf:"bytes,1,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *DeleteFeatureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+func (m *EvaluateFeatureResponse) Reset()         { *m = EvaluateFeatureResponse{} }
+func (m *EvaluateFeatureResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateFeatureResponse) ProtoMessage()    {}
+func (*EvaluateFeatureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{13}
+}
+func (m *EvaluateFeatureResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EvaluateFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EvaluateFeatureResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *EvaluateFeatureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateFeatureResponse.Merge(m, src)
+}
+func (m *EvaluateFeatureResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *EvaluateFeatureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateFeatureResponse.DiscardUnknown(m)
 }
 
-func (m *DeleteFeatureResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_EvaluateFeatureResponse proto.InternalMessageInfo
+
+func (m *EvaluateFeatureResponse) GetDetail() *EvaluationDetail {
+	if m != nil {
+		return m.Detail
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *DeleteFeatureResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type EvaluateFeaturesRequest struct {
+	// Names is the list of features to evaluate. If empty, all features are
+	// evaluated.
+	Names                []string         `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Parameters           *structpb.Struct `protobuf:"bytes,2,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *DeleteFeatureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Feature != nil {
-		{
-			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintFeature(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (m *EvaluateFeaturesRequest) Reset()         { *m = EvaluateFeaturesRequest{} }
+func (m *EvaluateFeaturesRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateFeaturesRequest) ProtoMessage()    {}
+func (*EvaluateFeaturesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{14}
+}
+func (m *EvaluateFeaturesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateFeaturesRequest.Unmarshal(m, b)
+}
+func (m *EvaluateFeaturesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateFeaturesRequest.Marshal(b, m, deterministic)
+}
+func (m *EvaluateFeaturesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateFeaturesRequest.Merge(m, src)
+}
+func (m *EvaluateFeaturesRequest) XXX_Size() int {
+	return xxx_messageInfo_EvaluateFeaturesRequest.Size(m)
+}
+func (m *EvaluateFeaturesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateFeaturesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateFeaturesRequest proto.InternalMessageInfo
+
+func (m *EvaluateFeaturesRequest) GetNames() []string {
+	if m != nil {
+		return m.Names
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetFeatureRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *EvaluateFeaturesRequest) GetParameters() *structpb.Struct {
+	if m != nil {
+		return m.Parameters
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetFeatureRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type EvaluateFeaturesResponse struct {
+	// Details contains one result per requested feature, in the order they
+	// were requested (or sorted by name, if no names were requested).
+	Details              []*EvaluationDetail `protobuf:"bytes,1,rep,name=details,proto3" json:"details,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
-func (m *GetFeatureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+func (m *EvaluateFeaturesResponse) Reset()         { *m = EvaluateFeaturesResponse{} }
+func (m *EvaluateFeaturesResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateFeaturesResponse) ProtoMessage()    {}
+func (*EvaluateFeaturesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{15}
+}
+func (m *EvaluateFeaturesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EvaluateFeaturesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EvaluateFeaturesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *EvaluateFeaturesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateFeaturesResponse.Merge(m, src)
+}
+func (m *EvaluateFeaturesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *EvaluateFeaturesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateFeaturesResponse.DiscardUnknown(m)
 }
 
-func (m *GetFeatureResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_EvaluateFeaturesResponse proto.InternalMessageInfo
+
+func (m *EvaluateFeaturesResponse) GetDetails() []*EvaluationDetail {
+	if m != nil {
+		return m.Details
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetFeatureResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// FeatureEvent is a single change to the set of features.
+type FeatureEvent struct {
+	Type FeatureEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=feature.FeatureEvent_Type" json:"type,omitempty"`
+	// Revision is the server-wide revision of this change. Revisions are
+	// monotonically increasing.
+	Revision uint64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Feature is the new state of the feature for SET events, and the deleted
+	// feature for DELETE events.
+	Feature              *Feature `protobuf:"bytes,3,opt,name=feature,proto3" json:"feature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetFeatureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Feature != nil {
-		{
-			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintFeature(dAtA, i, uint64(size))
+func (m *FeatureEvent) Reset()         { *m = FeatureEvent{} }
+func (m *FeatureEvent) String() string { return proto.CompactTextString(m) }
+func (*FeatureEvent) ProtoMessage()    {}
+func (*FeatureEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{16}
+}
+func (m *FeatureEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FeatureEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeatureEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *FeatureEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeatureEvent.Merge(m, src)
+}
+func (m *FeatureEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *FeatureEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeatureEvent.DiscardUnknown(m)
 }
 
-func (m *GetFeaturesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_FeatureEvent proto.InternalMessageInfo
+
+func (m *FeatureEvent) GetType() FeatureEvent_Type {
+	if m != nil {
+		return m.Type
 	}
-	return dAtA[:n], nil
+	return FeatureEvent_UNKNOWN
 }
 
-func (m *GetFeaturesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *FeatureEvent) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
 }
 
-func (m *GetFeaturesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *FeatureEvent) GetFeature() *Feature {
+	if m != nil {
+		return m.Feature
 	}
-	if m.NamesOnly {
-		i--
-		if m.NamesOnly {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	return nil
+}
+
+// FeatureSnapshot is the full set of features as of a given revision.
+type FeatureSnapshot struct {
+	Revision uint64     `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Features []*Feature `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+	// Epoch identifies the server's event history. Revisions restart when
+	// the server does, so they are only comparable within an epoch.
+	Epoch                string   `protobuf:"bytes,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FeatureSnapshot) Reset()         { *m = FeatureSnapshot{} }
+func (m *FeatureSnapshot) String() string { return proto.CompactTextString(m) }
+func (*FeatureSnapshot) ProtoMessage()    {}
+func (*FeatureSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{17}
+}
+func (m *FeatureSnapshot) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FeatureSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeatureSnapshot.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x8
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *FeatureSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeatureSnapshot.Merge(m, src)
+}
+func (m *FeatureSnapshot) XXX_Size() int {
+	return m.Size()
+}
+func (m *FeatureSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeatureSnapshot.DiscardUnknown(m)
 }
 
-func (m *GetFeaturesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_FeatureSnapshot proto.InternalMessageInfo
+
+func (m *FeatureSnapshot) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *GetFeaturesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *FeatureSnapshot) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
 }
 
-func (m *GetFeaturesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *FeatureSnapshot) GetEpoch() string {
+	if m != nil {
+		return m.Epoch
 	}
-	if len(m.Names) > 0 {
-		for iNdEx := len(m.Names) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Names[iNdEx])
-			copy(dAtA[i:], m.Names[iNdEx])
-			i = encodeVarintFeature(dAtA, i, uint64(len(m.Names[iNdEx])))
-			i--
-			dAtA[i] = 0x12
+	return ""
+}
+
+// FeatureRevision is a single change to a feature, as recorded in its
+// history.
+type FeatureRevision struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Version is the version of the feature after this change.
+	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// TimeUnixNano is when the change was made, in nanoseconds since the Unix
+	// epoch.
+	TimeUnixNano int64 `protobuf:"varint,3,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	// Actor identifies who made the change.
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Before is the feature prior to the change, or nil if it was created.
+	Before *Feature `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	// After is the feature after the change, or nil if it was deleted.
+	After                *Feature `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FeatureRevision) Reset()         { *m = FeatureRevision{} }
+func (m *FeatureRevision) String() string { return proto.CompactTextString(m) }
+func (*FeatureRevision) ProtoMessage()    {}
+func (*FeatureRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{18}
+}
+func (m *FeatureRevision) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FeatureRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeatureRevision.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Features) > 0 {
-		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Features[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintFeature(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+}
+func (m *FeatureRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeatureRevision.Merge(m, src)
+}
+func (m *FeatureRevision) XXX_Size() int {
+	return m.Size()
+}
+func (m *FeatureRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeatureRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeatureRevision proto.InternalMessageInfo
+
+func (m *FeatureRevision) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *SetFeatureRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *FeatureRevision) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SetFeatureRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *FeatureRevision) GetTimeUnixNano() int64 {
+	if m != nil {
+		return m.TimeUnixNano
+	}
+	return 0
 }
 
-func (m *SetFeatureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *FeatureRevision) GetActor() string {
+	if m != nil {
+		return m.Actor
 	}
-	if m.Feature != nil {
-		{
-			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintFeature(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	return ""
+}
+
+func (m *FeatureRevision) GetBefore() *Feature {
+	if m != nil {
+		return m.Before
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *SetFeatureResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *FeatureRevision) GetAfter() *Feature {
+	if m != nil {
+		return m.After
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *SetFeatureResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// AuditEntry is a single change to a feature, as recorded in the audit log.
+type AuditEntry struct {
+	// TimeUnixNano is when the change was made, in nanoseconds since the Unix
+	// epoch.
+	TimeUnixNano int64 `protobuf:"varint,1,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	// Action is "create", "update" or "delete".
+	Action  string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Feature string `protobuf:"bytes,3,opt,name=feature,proto3" json:"feature,omitempty"`
+	// Version is the version of the feature after this change.
+	Version uint64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Actor identifies who made the change, as in the feature history.
+	Actor string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Peer is the address the change was requested from, for changes made
+	// through the API.
+	Peer string `protobuf:"bytes,6,opt,name=peer,proto3" json:"peer,omitempty"`
+	// Method is how the change was made: the RPC (e.g. "SetFeature"), or
+	// "init" or "watch" for changes loaded from a config file.
+	Method string `protobuf:"bytes,7,opt,name=method,proto3" json:"method,omitempty"`
+	// Reason is why the change was made, as given by the caller.
+	Reason string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Before is the feature prior to the change, or nil if it was created.
+	Before *Feature `protobuf:"bytes,9,opt,name=before,proto3" json:"before,omitempty"`
+	// After is the feature after the change, or nil if it was deleted.
+	After *Feature `protobuf:"bytes,10,opt,name=after,proto3" json:"after,omitempty"`
+	// PrevHash is the hash of the previous entry, if the log is hash-chained.
+	PrevHash string `protobuf:"bytes,11,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	// Hash is the hex-encoded SHA-256 hash of PrevHash and the rest of the
+	// entry, if the log is hash-chained.
+	Hash string `protobuf:"bytes,12,opt,name=hash,proto3" json:"hash,omitempty"`
+	// ApprovedBy identifies who approved the change, for changes to
+	// protected features.
+	ApprovedBy           string   `protobuf:"bytes,13,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetFeatureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.After != nil {
-		{
-			size, err := m.After.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintFeature(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Before != nil {
-		{
-			size, err := m.Before.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintFeature(dAtA, i, uint64(size))
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{19}
+}
+func (m *AuditEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AuditEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *AuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEntry.Merge(m, src)
+}
+func (m *AuditEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *AuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEntry.DiscardUnknown(m)
 }
 
-func encodeVarintFeature(dAtA []byte, offset int, v uint64) int {
-	offset -= sovFeature(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_AuditEntry proto.InternalMessageInfo
+
+func (m *AuditEntry) GetTimeUnixNano() int64 {
+	if m != nil {
+		return m.TimeUnixNano
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return 0
 }
-func (m *Feature) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovFeature(uint64(l))
-	}
-	if m.Type != 0 {
-		n += 1 + sovFeature(uint64(m.Type))
-	}
-	if m.Enabled {
-		n += 2
+
+func (m *AuditEntry) GetAction() string {
+	if m != nil {
+		return m.Action
 	}
-	if m.Percentage != 0 {
-		n += 1 + sovFeature(uint64(m.Percentage))
+	return ""
+}
+
+func (m *AuditEntry) GetFeature() string {
+	if m != nil {
+		return m.Feature
 	}
-	l = len(m.Expression)
-	if l > 0 {
-		n += 1 + l + sovFeature(uint64(l))
+	return ""
+}
+
+func (m *AuditEntry) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
 	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 1 + l + sovFeature(uint64(l))
+	return 0
+}
+
+func (m *AuditEntry) GetActor() string {
+	if m != nil {
+		return m.Actor
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return ""
+}
+
+func (m *AuditEntry) GetPeer() string {
+	if m != nil {
+		return m.Peer
 	}
-	return n
+	return ""
 }
 
-func (m *DeleteFeatureRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AuditEntry) GetMethod() string {
+	if m != nil {
+		return m.Method
 	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovFeature(uint64(l))
+	return ""
+}
+
+func (m *AuditEntry) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return ""
+}
+
+func (m *AuditEntry) GetBefore() *Feature {
+	if m != nil {
+		return m.Before
 	}
-	return n
+	return nil
 }
 
-func (m *DeleteFeatureResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AuditEntry) GetAfter() *Feature {
+	if m != nil {
+		return m.After
 	}
-	var l int
-	_ = l
-	if m.Feature != nil {
-		l = m.Feature.Size()
-		n += 1 + l + sovFeature(uint64(l))
+	return nil
+}
+
+func (m *AuditEntry) GetPrevHash() string {
+	if m != nil {
+		return m.PrevHash
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return ""
+}
+
+func (m *AuditEntry) GetHash() string {
+	if m != nil {
+		return m.Hash
 	}
-	return n
+	return ""
 }
 
-func (m *GetFeatureRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AuditEntry) GetApprovedBy() string {
+	if m != nil {
+		return m.ApprovedBy
 	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovFeature(uint64(l))
+	return ""
+}
+
+// PendingChange is a change to one or more protected features, which is
+// applied once someone other than its requester approves it.
+type PendingChange struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Changes are the changes to make. Each Before is the feature as it was
+	// when the change was requested; if the feature has changed since, the
+	// change can no longer be approved.
+	Changes []*FeatureChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	// RequestedBy identifies who requested the change, as in the feature
+	// history.
+	RequestedBy string `protobuf:"bytes,3,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	// Reason is why the change was requested, as given by the requester.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// CreateTimeUnixNano and ExpireTimeUnixNano are when the change was
+	// requested, and after which it can no longer be approved, in
+	// nanoseconds since the Unix epoch.
+	CreateTimeUnixNano   int64    `protobuf:"varint,5,opt,name=create_time_unix_nano,json=createTimeUnixNano,proto3" json:"create_time_unix_nano,omitempty"`
+	ExpireTimeUnixNano   int64    `protobuf:"varint,6,opt,name=expire_time_unix_nano,json=expireTimeUnixNano,proto3" json:"expire_time_unix_nano,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PendingChange) Reset()         { *m = PendingChange{} }
+func (m *PendingChange) String() string { return proto.CompactTextString(m) }
+func (*PendingChange) ProtoMessage()    {}
+func (*PendingChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{20}
+}
+func (m *PendingChange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PendingChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PendingChange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *PendingChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingChange.Merge(m, src)
+}
+func (m *PendingChange) XXX_Size() int {
+	return m.Size()
+}
+func (m *PendingChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingChange proto.InternalMessageInfo
+
+func (m *PendingChange) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return n
+	return ""
 }
 
-func (m *GetFeatureResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PendingChange) GetChanges() []*FeatureChange {
+	if m != nil {
+		return m.Changes
 	}
-	var l int
-	_ = l
-	if m.Feature != nil {
-		l = m.Feature.Size()
-		n += 1 + l + sovFeature(uint64(l))
+	return nil
+}
+
+func (m *PendingChange) GetRequestedBy() string {
+	if m != nil {
+		return m.RequestedBy
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return ""
+}
+
+func (m *PendingChange) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	return n
+	return ""
 }
 
-func (m *GetFeaturesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PendingChange) GetCreateTimeUnixNano() int64 {
+	if m != nil {
+		return m.CreateTimeUnixNano
 	}
-	var l int
-	_ = l
-	if m.NamesOnly {
-		n += 2
+	return 0
+}
+
+func (m *PendingChange) GetExpireTimeUnixNano() int64 {
+	if m != nil {
+		return m.ExpireTimeUnixNano
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return 0
+}
+
+type ApproveChangeRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveChangeRequest) Reset()         { *m = ApproveChangeRequest{} }
+func (m *ApproveChangeRequest) String() string { return proto.CompactTextString(m) }
+func (*ApproveChangeRequest) ProtoMessage()    {}
+func (*ApproveChangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{21}
+}
+func (m *ApproveChangeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApproveChangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApproveChangeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *ApproveChangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChangeRequest.Merge(m, src)
+}
+func (m *ApproveChangeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApproveChangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChangeRequest.DiscardUnknown(m)
 }
 
-func (m *GetFeaturesResponse) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_ApproveChangeRequest proto.InternalMessageInfo
+
+func (m *ApproveChangeRequest) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	var l int
-	_ = l
-	if len(m.Features) > 0 {
-		for _, e := range m.Features {
-			l = e.Size()
-			n += 1 + l + sovFeature(uint64(l))
+	return ""
+}
+
+type ApproveChangeResponse struct {
+	// Changes are the changes made.
+	Changes              []*FeatureChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ApproveChangeResponse) Reset()         { *m = ApproveChangeResponse{} }
+func (m *ApproveChangeResponse) String() string { return proto.CompactTextString(m) }
+func (*ApproveChangeResponse) ProtoMessage()    {}
+func (*ApproveChangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{22}
+}
+func (m *ApproveChangeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApproveChangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApproveChangeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Names) > 0 {
-		for _, s := range m.Names {
-			l = len(s)
-			n += 1 + l + sovFeature(uint64(l))
-		}
+}
+func (m *ApproveChangeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChangeResponse.Merge(m, src)
+}
+func (m *ApproveChangeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApproveChangeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChangeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChangeResponse proto.InternalMessageInfo
+
+func (m *ApproveChangeResponse) GetChanges() []*FeatureChange {
+	if m != nil {
+		return m.Changes
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+type ListPendingChangesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPendingChangesRequest) Reset()         { *m = ListPendingChangesRequest{} }
+func (m *ListPendingChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPendingChangesRequest) ProtoMessage()    {}
+func (*ListPendingChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{23}
+}
+func (m *ListPendingChangesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListPendingChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListPendingChangesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *ListPendingChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingChangesRequest.Merge(m, src)
+}
+func (m *ListPendingChangesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListPendingChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingChangesRequest.DiscardUnknown(m)
 }
 
-func (m *SetFeatureRequest) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_ListPendingChangesRequest proto.InternalMessageInfo
+
+type ListPendingChangesResponse struct {
+	// Pending are the changes awaiting approval, oldest first.
+	Pending              []*PendingChange `protobuf:"bytes,1,rep,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListPendingChangesResponse) Reset()         { *m = ListPendingChangesResponse{} }
+func (m *ListPendingChangesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPendingChangesResponse) ProtoMessage()    {}
+func (*ListPendingChangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{24}
+}
+func (m *ListPendingChangesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListPendingChangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListPendingChangesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	if m.Feature != nil {
-		l = m.Feature.Size()
-		n += 1 + l + sovFeature(uint64(l))
+}
+func (m *ListPendingChangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingChangesResponse.Merge(m, src)
+}
+func (m *ListPendingChangesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListPendingChangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingChangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingChangesResponse proto.InternalMessageInfo
+
+func (m *ListPendingChangesResponse) GetPending() []*PendingChange {
+	if m != nil {
+		return m.Pending
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+type RejectChangeRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectChangeRequest) Reset()         { *m = RejectChangeRequest{} }
+func (m *RejectChangeRequest) String() string { return proto.CompactTextString(m) }
+func (*RejectChangeRequest) ProtoMessage()    {}
+func (*RejectChangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{25}
+}
+func (m *RejectChangeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RejectChangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RejectChangeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *RejectChangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectChangeRequest.Merge(m, src)
+}
+func (m *RejectChangeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RejectChangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectChangeRequest.DiscardUnknown(m)
 }
 
-func (m *SetFeatureResponse) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_RejectChangeRequest proto.InternalMessageInfo
+
+func (m *RejectChangeRequest) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	var l int
-	_ = l
-	if m.Before != nil {
-		l = m.Before.Size()
-		n += 1 + l + sovFeature(uint64(l))
+	return ""
+}
+
+type RejectChangeResponse struct {
+	// Pending is the rejected change.
+	Pending              *PendingChange `protobuf:"bytes,1,opt,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RejectChangeResponse) Reset()         { *m = RejectChangeResponse{} }
+func (m *RejectChangeResponse) String() string { return proto.CompactTextString(m) }
+func (*RejectChangeResponse) ProtoMessage()    {}
+func (*RejectChangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{26}
+}
+func (m *RejectChangeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RejectChangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RejectChangeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.After != nil {
-		l = m.After.Size()
-		n += 1 + l + sovFeature(uint64(l))
+}
+func (m *RejectChangeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectChangeResponse.Merge(m, src)
+}
+func (m *RejectChangeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RejectChangeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectChangeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectChangeResponse proto.InternalMessageInfo
+
+func (m *RejectChangeResponse) GetPending() *PendingChange {
+	if m != nil {
+		return m.Pending
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+// ScheduledChange is a sequence of changes to a feature that the server makes
+// on its own at given times, e.g. to ramp a PERCENTAGE_BASED feature up. Steps
+// are removed as they are made; once none remain, the schedule is removed.
+type ScheduledChange struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name is the feature the schedule changes.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Steps are the changes still to be made, in order.
+	Steps []*ScheduledStep `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	// RequestedBy identifies who scheduled the change, as in the feature
+	// history. Each step is recorded as made by them.
+	RequestedBy string `protobuf:"bytes,4,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	// Reason is why the change was scheduled, as given by the requester.
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	// CreateTimeUnixNano is when the change was scheduled, in nanoseconds
+	// since the Unix epoch.
+	CreateTimeUnixNano   int64    `protobuf:"varint,6,opt,name=create_time_unix_nano,json=createTimeUnixNano,proto3" json:"create_time_unix_nano,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScheduledChange) Reset()         { *m = ScheduledChange{} }
+func (m *ScheduledChange) String() string { return proto.CompactTextString(m) }
+func (*ScheduledChange) ProtoMessage()    {}
+func (*ScheduledChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{27}
+}
+func (m *ScheduledChange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScheduledChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScheduledChange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScheduledChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduledChange.Merge(m, src)
+}
+func (m *ScheduledChange) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScheduledChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduledChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduledChange proto.InternalMessageInfo
+
+func (m *ScheduledChange) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ScheduledChange) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ScheduledChange) GetSteps() []*ScheduledStep {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+func (m *ScheduledChange) GetRequestedBy() string {
+	if m != nil {
+		return m.RequestedBy
+	}
+	return ""
+}
+
+func (m *ScheduledChange) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ScheduledChange) GetCreateTimeUnixNano() int64 {
+	if m != nil {
+		return m.CreateTimeUnixNano
+	}
+	return 0
+}
+
+type ScheduledStep struct {
+	// TimeUnixNano is when to make the step, in nanoseconds since the Unix
+	// epoch.
+	TimeUnixNano int64 `protobuf:"varint,1,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	// Types that are valid to be assigned to Change:
+	//	*ScheduledStep_Feature
+	//	*ScheduledStep_Enabled
+	//	*ScheduledStep_Percentage
+	Change               isScheduledStep_Change `protobuf_oneof:"change"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ScheduledStep) Reset()         { *m = ScheduledStep{} }
+func (m *ScheduledStep) String() string { return proto.CompactTextString(m) }
+func (*ScheduledStep) ProtoMessage()    {}
+func (*ScheduledStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{28}
+}
+func (m *ScheduledStep) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScheduledStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScheduledStep.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScheduledStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduledStep.Merge(m, src)
+}
+func (m *ScheduledStep) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScheduledStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduledStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduledStep proto.InternalMessageInfo
+
+type isScheduledStep_Change interface {
+	isScheduledStep_Change()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type ScheduledStep_Feature struct {
+	Feature *Feature `protobuf:"bytes,2,opt,name=feature,proto3,oneof" json:"feature,omitempty"`
+}
+type ScheduledStep_Enabled struct {
+	Enabled bool `protobuf:"varint,3,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+}
+type ScheduledStep_Percentage struct {
+	Percentage uint32 `protobuf:"varint,4,opt,name=percentage,proto3,oneof" json:"percentage,omitempty"`
+}
+
+func (*ScheduledStep_Feature) isScheduledStep_Change()    {}
+func (*ScheduledStep_Enabled) isScheduledStep_Change()    {}
+func (*ScheduledStep_Percentage) isScheduledStep_Change() {}
+
+func (m *ScheduledStep) GetChange() isScheduledStep_Change {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+func (m *ScheduledStep) GetTimeUnixNano() int64 {
+	if m != nil {
+		return m.TimeUnixNano
+	}
+	return 0
+}
+
+func (m *ScheduledStep) GetFeature() *Feature {
+	if x, ok := m.GetChange().(*ScheduledStep_Feature); ok {
+		return x.Feature
+	}
+	return nil
+}
+
+func (m *ScheduledStep) GetEnabled() bool {
+	if x, ok := m.GetChange().(*ScheduledStep_Enabled); ok {
+		return x.Enabled
+	}
+	return false
+}
+
+func (m *ScheduledStep) GetPercentage() uint32 {
+	if x, ok := m.GetChange().(*ScheduledStep_Percentage); ok {
+		return x.Percentage
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ScheduledStep) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ScheduledStep_Feature)(nil),
+		(*ScheduledStep_Enabled)(nil),
+		(*ScheduledStep_Percentage)(nil),
+	}
+}
+
+type ScheduleFeatureRequest struct {
+	// Name is the feature to change.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Steps are the changes to make, which must be in the future, and in
+	// order.
+	Steps                []*ScheduledStep `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ScheduleFeatureRequest) Reset()         { *m = ScheduleFeatureRequest{} }
+func (m *ScheduleFeatureRequest) String() string { return proto.CompactTextString(m) }
+func (*ScheduleFeatureRequest) ProtoMessage()    {}
+func (*ScheduleFeatureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{29}
+}
+func (m *ScheduleFeatureRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScheduleFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScheduleFeatureRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScheduleFeatureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleFeatureRequest.Merge(m, src)
+}
+func (m *ScheduleFeatureRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScheduleFeatureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleFeatureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleFeatureRequest proto.InternalMessageInfo
+
+func (m *ScheduleFeatureRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ScheduleFeatureRequest) GetSteps() []*ScheduledStep {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type ScheduleFeatureResponse struct {
+	Schedule             *ScheduledChange `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ScheduleFeatureResponse) Reset()         { *m = ScheduleFeatureResponse{} }
+func (m *ScheduleFeatureResponse) String() string { return proto.CompactTextString(m) }
+func (*ScheduleFeatureResponse) ProtoMessage()    {}
+func (*ScheduleFeatureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{30}
+}
+func (m *ScheduleFeatureResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScheduleFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScheduleFeatureResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScheduleFeatureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleFeatureResponse.Merge(m, src)
+}
+func (m *ScheduleFeatureResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScheduleFeatureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleFeatureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleFeatureResponse proto.InternalMessageInfo
+
+func (m *ScheduleFeatureResponse) GetSchedule() *ScheduledChange {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type ListScheduledChangesRequest struct {
+	// Name, if set, limits the schedules to those changing this feature.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListScheduledChangesRequest) Reset()         { *m = ListScheduledChangesRequest{} }
+func (m *ListScheduledChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListScheduledChangesRequest) ProtoMessage()    {}
+func (*ListScheduledChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{31}
+}
+func (m *ListScheduledChangesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListScheduledChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListScheduledChangesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListScheduledChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListScheduledChangesRequest.Merge(m, src)
+}
+func (m *ListScheduledChangesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListScheduledChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListScheduledChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListScheduledChangesRequest proto.InternalMessageInfo
+
+func (m *ListScheduledChangesRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListScheduledChangesResponse struct {
+	// Schedules are ordered by when their next step is made.
+	Schedules            []*ScheduledChange `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListScheduledChangesResponse) Reset()         { *m = ListScheduledChangesResponse{} }
+func (m *ListScheduledChangesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListScheduledChangesResponse) ProtoMessage()    {}
+func (*ListScheduledChangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{32}
+}
+func (m *ListScheduledChangesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListScheduledChangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListScheduledChangesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListScheduledChangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListScheduledChangesResponse.Merge(m, src)
+}
+func (m *ListScheduledChangesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListScheduledChangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListScheduledChangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListScheduledChangesResponse proto.InternalMessageInfo
+
+func (m *ListScheduledChangesResponse) GetSchedules() []*ScheduledChange {
+	if m != nil {
+		return m.Schedules
+	}
+	return nil
+}
+
+type CancelScheduledChangeRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelScheduledChangeRequest) Reset()         { *m = CancelScheduledChangeRequest{} }
+func (m *CancelScheduledChangeRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelScheduledChangeRequest) ProtoMessage()    {}
+func (*CancelScheduledChangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{33}
+}
+func (m *CancelScheduledChangeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CancelScheduledChangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CancelScheduledChangeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CancelScheduledChangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelScheduledChangeRequest.Merge(m, src)
+}
+func (m *CancelScheduledChangeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CancelScheduledChangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelScheduledChangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelScheduledChangeRequest proto.InternalMessageInfo
+
+func (m *CancelScheduledChangeRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CancelScheduledChangeResponse struct {
+	// Schedule is the cancelled schedule, with the steps that will no longer
+	// be made.
+	Schedule             *ScheduledChange `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CancelScheduledChangeResponse) Reset()         { *m = CancelScheduledChangeResponse{} }
+func (m *CancelScheduledChangeResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelScheduledChangeResponse) ProtoMessage()    {}
+func (*CancelScheduledChangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{34}
+}
+func (m *CancelScheduledChangeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CancelScheduledChangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CancelScheduledChangeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CancelScheduledChangeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelScheduledChangeResponse.Merge(m, src)
+}
+func (m *CancelScheduledChangeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CancelScheduledChangeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelScheduledChangeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelScheduledChangeResponse proto.InternalMessageInfo
+
+func (m *CancelScheduledChangeResponse) GetSchedule() *ScheduledChange {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type GetFeatureRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFeatureRequest) Reset()         { *m = GetFeatureRequest{} }
+func (m *GetFeatureRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFeatureRequest) ProtoMessage()    {}
+func (*GetFeatureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{35}
+}
+func (m *GetFeatureRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetFeatureRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetFeatureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFeatureRequest.Merge(m, src)
+}
+func (m *GetFeatureRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetFeatureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFeatureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFeatureRequest proto.InternalMessageInfo
+
+func (m *GetFeatureRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GetFeatureResponse struct {
+	Feature              *Feature `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFeatureResponse) Reset()         { *m = GetFeatureResponse{} }
+func (m *GetFeatureResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFeatureResponse) ProtoMessage()    {}
+func (*GetFeatureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{36}
+}
+func (m *GetFeatureResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetFeatureResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetFeatureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFeatureResponse.Merge(m, src)
+}
+func (m *GetFeatureResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetFeatureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFeatureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFeatureResponse proto.InternalMessageInfo
+
+func (m *GetFeatureResponse) GetFeature() *Feature {
+	if m != nil {
+		return m.Feature
+	}
+	return nil
+}
+
+type GetFeaturesRequest struct {
+	NamesOnly            bool     `protobuf:"varint,1,opt,name=names_only,json=namesOnly,proto3" json:"names_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFeaturesRequest) Reset()         { *m = GetFeaturesRequest{} }
+func (m *GetFeaturesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFeaturesRequest) ProtoMessage()    {}
+func (*GetFeaturesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{37}
+}
+func (m *GetFeaturesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetFeaturesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetFeaturesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetFeaturesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFeaturesRequest.Merge(m, src)
+}
+func (m *GetFeaturesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetFeaturesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFeaturesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFeaturesRequest proto.InternalMessageInfo
+
+func (m *GetFeaturesRequest) GetNamesOnly() bool {
+	if m != nil {
+		return m.NamesOnly
+	}
+	return false
+}
+
+type GetFeaturesResponse struct {
+	Features             []*Feature `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
+	Names                []string   `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetFeaturesResponse) Reset()         { *m = GetFeaturesResponse{} }
+func (m *GetFeaturesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFeaturesResponse) ProtoMessage()    {}
+func (*GetFeaturesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{38}
+}
+func (m *GetFeaturesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetFeaturesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetFeaturesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetFeaturesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFeaturesResponse.Merge(m, src)
+}
+func (m *GetFeaturesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetFeaturesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFeaturesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFeaturesResponse proto.InternalMessageInfo
+
+func (m *GetFeaturesResponse) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *GetFeaturesResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type ListAuditEntriesRequest struct {
+	// Feature, if set, returns only changes to the named feature.
+	Feature string `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
+	// Actor, if set, returns only changes made by that actor.
+	Actor string `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	// SinceUnixNano and UntilUnixNano, if non-zero, return only changes made
+	// at or after, and before, those times, in nanoseconds since the Unix
+	// epoch.
+	SinceUnixNano int64 `protobuf:"varint,3,opt,name=since_unix_nano,json=sinceUnixNano,proto3" json:"since_unix_nano,omitempty"`
+	UntilUnixNano int64 `protobuf:"varint,4,opt,name=until_unix_nano,json=untilUnixNano,proto3" json:"until_unix_nano,omitempty"`
+	// Limit, if non-zero, returns only the most recent matching changes.
+	Limit                uint32   `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEntriesRequest) Reset()         { *m = ListAuditEntriesRequest{} }
+func (m *ListAuditEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEntriesRequest) ProtoMessage()    {}
+func (*ListAuditEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{39}
+}
+func (m *ListAuditEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAuditEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListAuditEntriesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListAuditEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEntriesRequest.Merge(m, src)
+}
+func (m *ListAuditEntriesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAuditEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEntriesRequest proto.InternalMessageInfo
+
+func (m *ListAuditEntriesRequest) GetFeature() string {
+	if m != nil {
+		return m.Feature
+	}
+	return ""
+}
+
+func (m *ListAuditEntriesRequest) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *ListAuditEntriesRequest) GetSinceUnixNano() int64 {
+	if m != nil {
+		return m.SinceUnixNano
+	}
+	return 0
+}
+
+func (m *ListAuditEntriesRequest) GetUntilUnixNano() int64 {
+	if m != nil {
+		return m.UntilUnixNano
+	}
+	return 0
+}
+
+func (m *ListAuditEntriesRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListAuditEntriesResponse struct {
+	// Entries are the matching audit entries, oldest first.
+	Entries              []*AuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListAuditEntriesResponse) Reset()         { *m = ListAuditEntriesResponse{} }
+func (m *ListAuditEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEntriesResponse) ProtoMessage()    {}
+func (*ListAuditEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{40}
+}
+func (m *ListAuditEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAuditEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListAuditEntriesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListAuditEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEntriesResponse.Merge(m, src)
+}
+func (m *ListAuditEntriesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAuditEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEntriesResponse proto.InternalMessageInfo
+
+func (m *ListAuditEntriesResponse) GetEntries() []*AuditEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type ListFeatureHistoryRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFeatureHistoryRequest) Reset()         { *m = ListFeatureHistoryRequest{} }
+func (m *ListFeatureHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFeatureHistoryRequest) ProtoMessage()    {}
+func (*ListFeatureHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{41}
+}
+func (m *ListFeatureHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListFeatureHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListFeatureHistoryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListFeatureHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFeatureHistoryRequest.Merge(m, src)
+}
+func (m *ListFeatureHistoryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListFeatureHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFeatureHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFeatureHistoryRequest proto.InternalMessageInfo
+
+func (m *ListFeatureHistoryRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListFeatureHistoryResponse struct {
+	// Revisions is every revision of the feature, oldest first.
+	Revisions            []*FeatureRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListFeatureHistoryResponse) Reset()         { *m = ListFeatureHistoryResponse{} }
+func (m *ListFeatureHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFeatureHistoryResponse) ProtoMessage()    {}
+func (*ListFeatureHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{42}
+}
+func (m *ListFeatureHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListFeatureHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListFeatureHistoryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListFeatureHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFeatureHistoryResponse.Merge(m, src)
+}
+func (m *ListFeatureHistoryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListFeatureHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFeatureHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFeatureHistoryResponse proto.InternalMessageInfo
+
+func (m *ListFeatureHistoryResponse) GetRevisions() []*FeatureRevision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type RollbackFeatureRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Version is the revision to roll back to. The feature is set to its
+	// state as of that revision (or deleted, if that revision deleted it), as
+	// a new revision.
+	Version              uint64   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackFeatureRequest) Reset()         { *m = RollbackFeatureRequest{} }
+func (m *RollbackFeatureRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackFeatureRequest) ProtoMessage()    {}
+func (*RollbackFeatureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{43}
+}
+func (m *RollbackFeatureRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RollbackFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RollbackFeatureRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RollbackFeatureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackFeatureRequest.Merge(m, src)
+}
+func (m *RollbackFeatureRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RollbackFeatureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackFeatureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackFeatureRequest proto.InternalMessageInfo
+
+func (m *RollbackFeatureRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RollbackFeatureRequest) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type RollbackFeatureResponse struct {
+	Before *Feature `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	// After is the restored feature, or nil if the rollback deleted it.
+	After *Feature `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	// Pending is set if the feature is protected, in which case the
+	// rollback has not been made yet, but is awaiting approval.
+	Pending              *PendingChange `protobuf:"bytes,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RollbackFeatureResponse) Reset()         { *m = RollbackFeatureResponse{} }
+func (m *RollbackFeatureResponse) String() string { return proto.CompactTextString(m) }
+func (*RollbackFeatureResponse) ProtoMessage()    {}
+func (*RollbackFeatureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{44}
+}
+func (m *RollbackFeatureResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RollbackFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RollbackFeatureResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RollbackFeatureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackFeatureResponse.Merge(m, src)
+}
+func (m *RollbackFeatureResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RollbackFeatureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackFeatureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackFeatureResponse proto.InternalMessageInfo
+
+func (m *RollbackFeatureResponse) GetBefore() *Feature {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *RollbackFeatureResponse) GetAfter() *Feature {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *RollbackFeatureResponse) GetPending() *PendingChange {
+	if m != nil {
+		return m.Pending
+	}
+	return nil
+}
+
+type WatchFeaturesRequest struct {
+	// FromRevision is the last revision the client has seen. If set, and the
+	// server still has all events after that revision, the stream resumes
+	// from there. Otherwise (or if zero), the stream begins with a snapshot.
+	FromRevision uint64 `protobuf:"varint,1,opt,name=from_revision,json=fromRevision,proto3" json:"from_revision,omitempty"`
+	// Epoch is the epoch of the snapshot FromRevision follows. If it is not
+	// the server's current epoch (e.g. because the server has restarted
+	// since), the stream begins with a snapshot.
+	Epoch                string   `protobuf:"bytes,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchFeaturesRequest) Reset()         { *m = WatchFeaturesRequest{} }
+func (m *WatchFeaturesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchFeaturesRequest) ProtoMessage()    {}
+func (*WatchFeaturesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{45}
+}
+func (m *WatchFeaturesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchFeaturesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchFeaturesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchFeaturesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchFeaturesRequest.Merge(m, src)
+}
+func (m *WatchFeaturesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchFeaturesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchFeaturesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchFeaturesRequest proto.InternalMessageInfo
+
+func (m *WatchFeaturesRequest) GetFromRevision() uint64 {
+	if m != nil {
+		return m.FromRevision
+	}
+	return 0
+}
+
+func (m *WatchFeaturesRequest) GetEpoch() string {
+	if m != nil {
+		return m.Epoch
+	}
+	return ""
+}
+
+type WatchFeaturesResponse struct {
+	// Snapshot, if set, is the complete set of features. Clients should
+	// replace all of their local state with it. A snapshot is sent at the
+	// start of a stream, unless resuming, and whenever the client falls too
+	// far behind.
+	Snapshot *FeatureSnapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// Event, if set, is a single change to apply on top of the client's
+	// local state.
+	Event                *FeatureEvent `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WatchFeaturesResponse) Reset()         { *m = WatchFeaturesResponse{} }
+func (m *WatchFeaturesResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchFeaturesResponse) ProtoMessage()    {}
+func (*WatchFeaturesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{46}
+}
+func (m *WatchFeaturesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchFeaturesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchFeaturesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchFeaturesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchFeaturesResponse.Merge(m, src)
+}
+func (m *WatchFeaturesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchFeaturesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchFeaturesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchFeaturesResponse proto.InternalMessageInfo
+
+func (m *WatchFeaturesResponse) GetSnapshot() *FeatureSnapshot {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+func (m *WatchFeaturesResponse) GetEvent() *FeatureEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type SetFeatureRequest struct {
+	Feature *Feature `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
+	// ExpectedVersion, if non-zero, makes the write conditional on the
+	// feature currently being at that version, e.g. the version the client
+	// read before modifying it. If it is not (including if the feature has
+	// since been deleted), the write fails with FailedPrecondition.
+	ExpectedVersion uint64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// ExpectAbsent makes the write conditional on the feature not existing,
+	// so that two clients creating the same feature can't overwrite each
+	// other. If it exists, the write fails with FailedPrecondition.
+	ExpectAbsent         bool     `protobuf:"varint,3,opt,name=expect_absent,json=expectAbsent,proto3" json:"expect_absent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetFeatureRequest) Reset()         { *m = SetFeatureRequest{} }
+func (m *SetFeatureRequest) String() string { return proto.CompactTextString(m) }
+func (*SetFeatureRequest) ProtoMessage()    {}
+func (*SetFeatureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{47}
+}
+func (m *SetFeatureRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetFeatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetFeatureRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetFeatureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetFeatureRequest.Merge(m, src)
+}
+func (m *SetFeatureRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetFeatureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetFeatureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetFeatureRequest proto.InternalMessageInfo
+
+func (m *SetFeatureRequest) GetFeature() *Feature {
+	if m != nil {
+		return m.Feature
+	}
+	return nil
+}
+
+func (m *SetFeatureRequest) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+func (m *SetFeatureRequest) GetExpectAbsent() bool {
+	if m != nil {
+		return m.ExpectAbsent
+	}
+	return false
+}
+
+type SetFeatureResponse struct {
+	Before *Feature `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	After  *Feature `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	// Pending is set if the feature is protected, in which case the change
+	// has not been made yet, but is awaiting approval, and After has no
+	// version.
+	Pending              *PendingChange `protobuf:"bytes,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SetFeatureResponse) Reset()         { *m = SetFeatureResponse{} }
+func (m *SetFeatureResponse) String() string { return proto.CompactTextString(m) }
+func (*SetFeatureResponse) ProtoMessage()    {}
+func (*SetFeatureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{48}
+}
+func (m *SetFeatureResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetFeatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetFeatureResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetFeatureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetFeatureResponse.Merge(m, src)
+}
+func (m *SetFeatureResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetFeatureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetFeatureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetFeatureResponse proto.InternalMessageInfo
+
+func (m *SetFeatureResponse) GetBefore() *Feature {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *SetFeatureResponse) GetAfter() *Feature {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *SetFeatureResponse) GetPending() *PendingChange {
+	if m != nil {
+		return m.Pending
+	}
+	return nil
+}
+
+type DeleteSegmentRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSegmentRequest) Reset()         { *m = DeleteSegmentRequest{} }
+func (m *DeleteSegmentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteSegmentRequest) ProtoMessage()    {}
+func (*DeleteSegmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{49}
+}
+func (m *DeleteSegmentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteSegmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteSegmentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeleteSegmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSegmentRequest.Merge(m, src)
+}
+func (m *DeleteSegmentRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteSegmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSegmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSegmentRequest proto.InternalMessageInfo
+
+func (m *DeleteSegmentRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteSegmentResponse struct {
+	// Segment is the deleted segment, or nil if there was no such segment.
+	Segment              *Segment `protobuf:"bytes,1,opt,name=segment,proto3" json:"segment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSegmentResponse) Reset()         { *m = DeleteSegmentResponse{} }
+func (m *DeleteSegmentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteSegmentResponse) ProtoMessage()    {}
+func (*DeleteSegmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{50}
+}
+func (m *DeleteSegmentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteSegmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteSegmentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeleteSegmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSegmentResponse.Merge(m, src)
+}
+func (m *DeleteSegmentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteSegmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSegmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSegmentResponse proto.InternalMessageInfo
+
+func (m *DeleteSegmentResponse) GetSegment() *Segment {
+	if m != nil {
+		return m.Segment
+	}
+	return nil
+}
+
+type GetSegmentRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSegmentRequest) Reset()         { *m = GetSegmentRequest{} }
+func (m *GetSegmentRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSegmentRequest) ProtoMessage()    {}
+func (*GetSegmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{51}
+}
+func (m *GetSegmentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSegmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSegmentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetSegmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSegmentRequest.Merge(m, src)
+}
+func (m *GetSegmentRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSegmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSegmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSegmentRequest proto.InternalMessageInfo
+
+func (m *GetSegmentRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GetSegmentResponse struct {
+	Segment              *Segment `protobuf:"bytes,1,opt,name=segment,proto3" json:"segment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSegmentResponse) Reset()         { *m = GetSegmentResponse{} }
+func (m *GetSegmentResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSegmentResponse) ProtoMessage()    {}
+func (*GetSegmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{52}
+}
+func (m *GetSegmentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSegmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSegmentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetSegmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSegmentResponse.Merge(m, src)
+}
+func (m *GetSegmentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSegmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSegmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSegmentResponse proto.InternalMessageInfo
+
+func (m *GetSegmentResponse) GetSegment() *Segment {
+	if m != nil {
+		return m.Segment
+	}
+	return nil
+}
+
+type GetSegmentsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSegmentsRequest) Reset()         { *m = GetSegmentsRequest{} }
+func (m *GetSegmentsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSegmentsRequest) ProtoMessage()    {}
+func (*GetSegmentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{53}
+}
+func (m *GetSegmentsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSegmentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSegmentsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetSegmentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSegmentsRequest.Merge(m, src)
+}
+func (m *GetSegmentsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSegmentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSegmentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSegmentsRequest proto.InternalMessageInfo
+
+type GetSegmentsResponse struct {
+	Segments             []*Segment `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetSegmentsResponse) Reset()         { *m = GetSegmentsResponse{} }
+func (m *GetSegmentsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSegmentsResponse) ProtoMessage()    {}
+func (*GetSegmentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{54}
+}
+func (m *GetSegmentsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSegmentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSegmentsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetSegmentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSegmentsResponse.Merge(m, src)
+}
+func (m *GetSegmentsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSegmentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSegmentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSegmentsResponse proto.InternalMessageInfo
+
+func (m *GetSegmentsResponse) GetSegments() []*Segment {
+	if m != nil {
+		return m.Segments
+	}
+	return nil
+}
+
+type SetSegmentRequest struct {
+	Segment              *Segment `protobuf:"bytes,1,opt,name=segment,proto3" json:"segment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetSegmentRequest) Reset()         { *m = SetSegmentRequest{} }
+func (m *SetSegmentRequest) String() string { return proto.CompactTextString(m) }
+func (*SetSegmentRequest) ProtoMessage()    {}
+func (*SetSegmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{55}
+}
+func (m *SetSegmentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetSegmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetSegmentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetSegmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSegmentRequest.Merge(m, src)
+}
+func (m *SetSegmentRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetSegmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSegmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSegmentRequest proto.InternalMessageInfo
+
+func (m *SetSegmentRequest) GetSegment() *Segment {
+	if m != nil {
+		return m.Segment
+	}
+	return nil
+}
+
+type SetSegmentResponse struct {
+	Before               *Segment `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	After                *Segment `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetSegmentResponse) Reset()         { *m = SetSegmentResponse{} }
+func (m *SetSegmentResponse) String() string { return proto.CompactTextString(m) }
+func (*SetSegmentResponse) ProtoMessage()    {}
+func (*SetSegmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7767543e194ebda6, []int{56}
+}
+func (m *SetSegmentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetSegmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetSegmentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetSegmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSegmentResponse.Merge(m, src)
+}
+func (m *SetSegmentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetSegmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSegmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSegmentResponse proto.InternalMessageInfo
+
+func (m *SetSegmentResponse) GetBefore() *Segment {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *SetSegmentResponse) GetAfter() *Segment {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("feature.Feature_Type", Feature_Type_name, Feature_Type_value)
+	proto.RegisterEnum("feature.EvaluationDetail_Reason", EvaluationDetail_Reason_name, EvaluationDetail_Reason_value)
+	proto.RegisterEnum("feature.FeatureEvent_Type", FeatureEvent_Type_name, FeatureEvent_Type_value)
+	proto.RegisterType((*Feature)(nil), "feature.Feature")
+	proto.RegisterType((*Feature_Variant)(nil), "feature.Feature.Variant")
+	proto.RegisterType((*Rule)(nil), "feature.Rule")
+	proto.RegisterType((*Outcome)(nil), "feature.Outcome")
+	proto.RegisterType((*Value)(nil), "feature.Value")
+	proto.RegisterType((*Segment)(nil), "feature.Segment")
+	proto.RegisterType((*EvaluationDetail)(nil), "feature.EvaluationDetail")
+	proto.RegisterType((*FeatureOperation)(nil), "feature.FeatureOperation")
+	proto.RegisterType((*FeatureChange)(nil), "feature.FeatureChange")
+	proto.RegisterType((*ApplyFeaturesRequest)(nil), "feature.ApplyFeaturesRequest")
+	proto.RegisterType((*ApplyFeaturesResponse)(nil), "feature.ApplyFeaturesResponse")
+	proto.RegisterType((*DeleteFeatureRequest)(nil), "feature.DeleteFeatureRequest")
+	proto.RegisterType((*DeleteFeatureResponse)(nil), "feature.DeleteFeatureResponse")
+	proto.RegisterType((*EvaluateFeatureRequest)(nil), "feature.EvaluateFeatureRequest")
+	proto.RegisterType((*EvaluateFeatureResponse)(nil), "feature.EvaluateFeatureResponse")
+	proto.RegisterType((*EvaluateFeaturesRequest)(nil), "feature.EvaluateFeaturesRequest")
+	proto.RegisterType((*EvaluateFeaturesResponse)(nil), "feature.EvaluateFeaturesResponse")
+	proto.RegisterType((*FeatureEvent)(nil), "feature.FeatureEvent")
+	proto.RegisterType((*FeatureSnapshot)(nil), "feature.FeatureSnapshot")
+	proto.RegisterType((*FeatureRevision)(nil), "feature.FeatureRevision")
+	proto.RegisterType((*AuditEntry)(nil), "feature.AuditEntry")
+	proto.RegisterType((*PendingChange)(nil), "feature.PendingChange")
+	proto.RegisterType((*ApproveChangeRequest)(nil), "feature.ApproveChangeRequest")
+	proto.RegisterType((*ApproveChangeResponse)(nil), "feature.ApproveChangeResponse")
+	proto.RegisterType((*ListPendingChangesRequest)(nil), "feature.ListPendingChangesRequest")
+	proto.RegisterType((*ListPendingChangesResponse)(nil), "feature.ListPendingChangesResponse")
+	proto.RegisterType((*RejectChangeRequest)(nil), "feature.RejectChangeRequest")
+	proto.RegisterType((*RejectChangeResponse)(nil), "feature.RejectChangeResponse")
+	proto.RegisterType((*ScheduledChange)(nil), "feature.ScheduledChange")
+	proto.RegisterType((*ScheduledStep)(nil), "feature.ScheduledStep")
+	proto.RegisterType((*ScheduleFeatureRequest)(nil), "feature.ScheduleFeatureRequest")
+	proto.RegisterType((*ScheduleFeatureResponse)(nil), "feature.ScheduleFeatureResponse")
+	proto.RegisterType((*ListScheduledChangesRequest)(nil), "feature.ListScheduledChangesRequest")
+	proto.RegisterType((*ListScheduledChangesResponse)(nil), "feature.ListScheduledChangesResponse")
+	proto.RegisterType((*CancelScheduledChangeRequest)(nil), "feature.CancelScheduledChangeRequest")
+	proto.RegisterType((*CancelScheduledChangeResponse)(nil), "feature.CancelScheduledChangeResponse")
+	proto.RegisterType((*GetFeatureRequest)(nil), "feature.GetFeatureRequest")
+	proto.RegisterType((*GetFeatureResponse)(nil), "feature.GetFeatureResponse")
+	proto.RegisterType((*GetFeaturesRequest)(nil), "feature.GetFeaturesRequest")
+	proto.RegisterType((*GetFeaturesResponse)(nil), "feature.GetFeaturesResponse")
+	proto.RegisterType((*ListAuditEntriesRequest)(nil), "feature.ListAuditEntriesRequest")
+	proto.RegisterType((*ListAuditEntriesResponse)(nil), "feature.ListAuditEntriesResponse")
+	proto.RegisterType((*ListFeatureHistoryRequest)(nil), "feature.ListFeatureHistoryRequest")
+	proto.RegisterType((*ListFeatureHistoryResponse)(nil), "feature.ListFeatureHistoryResponse")
+	proto.RegisterType((*RollbackFeatureRequest)(nil), "feature.RollbackFeatureRequest")
+	proto.RegisterType((*RollbackFeatureResponse)(nil), "feature.RollbackFeatureResponse")
+	proto.RegisterType((*WatchFeaturesRequest)(nil), "feature.WatchFeaturesRequest")
+	proto.RegisterType((*WatchFeaturesResponse)(nil), "feature.WatchFeaturesResponse")
+	proto.RegisterType((*SetFeatureRequest)(nil), "feature.SetFeatureRequest")
+	proto.RegisterType((*SetFeatureResponse)(nil), "feature.SetFeatureResponse")
+	proto.RegisterType((*DeleteSegmentRequest)(nil), "feature.DeleteSegmentRequest")
+	proto.RegisterType((*DeleteSegmentResponse)(nil), "feature.DeleteSegmentResponse")
+	proto.RegisterType((*GetSegmentRequest)(nil), "feature.GetSegmentRequest")
+	proto.RegisterType((*GetSegmentResponse)(nil), "feature.GetSegmentResponse")
+	proto.RegisterType((*GetSegmentsRequest)(nil), "feature.GetSegmentsRequest")
+	proto.RegisterType((*GetSegmentsResponse)(nil), "feature.GetSegmentsResponse")
+	proto.RegisterType((*SetSegmentRequest)(nil), "feature.SetSegmentRequest")
+	proto.RegisterType((*SetSegmentResponse)(nil), "feature.SetSegmentResponse")
+}
+
+func init() { proto.RegisterFile("proto/feature.proto", fileDescriptor_7767543e194ebda6) }
+
+var fileDescriptor_7767543e194ebda6 = []byte{
+	// 2554 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0x4b, 0x77, 0x1b, 0x49,
+	0x15, 0x76, 0xeb, 0xad, 0x2b, 0xc9, 0xd6, 0x54, 0xfc, 0xe8, 0xe9, 0xf8, 0xa1, 0x74, 0x1e, 0x78,
+	0x42, 0x70, 0x26, 0xce, 0xf0, 0xdc, 0x80, 0xed, 0x28, 0x71, 0x18, 0x8f, 0x15, 0x4a, 0xb6, 0x87,
+	0x99, 0x8d, 0x4e, 0x5b, 0x2a, 0x5b, 0x9d, 0xc8, 0xdd, 0x3d, 0xdd, 0x2d, 0x63, 0xc1, 0x6e, 0x56,
+	0xec, 0x80, 0xc3, 0x82, 0xd9, 0x70, 0x20, 0x3b, 0xce, 0x61, 0x01, 0x3b, 0xfe, 0x02, 0x1b, 0xce,
+	0x81, 0x7f, 0xc0, 0x09, 0xff, 0x80, 0x35, 0x0b, 0x4e, 0x3d, 0xfa, 0xdd, 0x2d, 0x2b, 0x33, 0x2c,
+	0xd8, 0xa9, 0xee, 0xfd, 0xea, 0xd6, 0xbd, 0xb7, 0xee, 0xab, 0xda, 0x86, 0x1b, 0x96, 0x6d, 0xba,
+	0xe6, 0xc3, 0x33, 0xa2, 0xb9, 0x63, 0x9b, 0x6c, 0xb1, 0x15, 0x2a, 0x8b, 0xa5, 0xb2, 0x78, 0x6e,
+	0x9e, 0x9b, 0x1c, 0x41, 0x7f, 0x71, 0xb6, 0xb2, 0x7a, 0x6e, 0x9a, 0xe7, 0x23, 0xf2, 0x90, 0xad,
+	0x4e, 0xc7, 0x67, 0x0f, 0x1d, 0xd7, 0x1e, 0xf7, 0x5d, 0xce, 0x55, 0x3f, 0x2f, 0x42, 0xf9, 0x29,
+	0xdf, 0x8f, 0x10, 0x14, 0x0c, 0xed, 0x82, 0xc8, 0x52, 0x4b, 0xda, 0xac, 0x62, 0xf6, 0x1b, 0xbd,
+	0x07, 0x05, 0x77, 0x62, 0x11, 0x39, 0xd7, 0x92, 0x36, 0xe7, 0xb7, 0x97, 0xb6, 0xbc, 0xa3, 0xc5,
+	0x9e, 0xad, 0xa3, 0x89, 0x45, 0x30, 0x83, 0x20, 0x19, 0xca, 0xc4, 0xd0, 0x4e, 0x47, 0x64, 0x20,
+	0xe7, 0x5b, 0xd2, 0x66, 0x05, 0x7b, 0x4b, 0xb4, 0x0e, 0x60, 0x11, 0xbb, 0x4f, 0x0c, 0x57, 0x3b,
+	0x27, 0x72, 0xa1, 0x25, 0x6d, 0x36, 0x70, 0x88, 0x42, 0xf9, 0xe4, 0xca, 0xb2, 0x89, 0xe3, 0xe8,
+	0xa6, 0x21, 0x17, 0xd9, 0xf1, 0x21, 0x0a, 0x6a, 0x41, 0x6d, 0x40, 0x9c, 0xbe, 0xad, 0x5b, 0x2e,
+	0x05, 0x94, 0x18, 0x20, 0x4c, 0x42, 0xb7, 0xa1, 0x71, 0x3a, 0xee, 0xbf, 0x22, 0xae, 0x6e, 0x9c,
+	0xf7, 0x5e, 0x91, 0x89, 0x5c, 0x66, 0x98, 0xba, 0x4f, 0xfc, 0x90, 0x4c, 0xa8, 0x7d, 0x8e, 0x36,
+	0x72, 0xe5, 0x0a, 0xb7, 0x8f, 0xfe, 0x46, 0x1f, 0x40, 0xe5, 0x52, 0xb3, 0x75, 0xcd, 0x70, 0x1d,
+	0xb9, 0xda, 0xca, 0x6f, 0xd6, 0xb6, 0xe5, 0x84, 0x8d, 0x27, 0x1c, 0x80, 0x7d, 0x24, 0xba, 0x03,
+	0xc5, 0x4b, 0x6d, 0x34, 0x26, 0x32, 0xb4, 0xa4, 0xcd, 0xda, 0xf6, 0xbc, 0xbf, 0xe5, 0x84, 0x52,
+	0x31, 0x67, 0xa2, 0xdb, 0x50, 0xb4, 0xc7, 0x23, 0xe2, 0xc8, 0x35, 0x26, 0xb8, 0xe1, 0xa3, 0xf0,
+	0x78, 0x44, 0x30, 0xe7, 0xa1, 0x6d, 0xa8, 0x9d, 0x69, 0xa3, 0x91, 0x3b, 0xb4, 0xcd, 0xf1, 0xf9,
+	0x50, 0xae, 0x33, 0x81, 0x4d, 0x1f, 0xda, 0x19, 0xbb, 0x7d, 0xf3, 0x82, 0xe0, 0x30, 0x88, 0x7a,
+	0xfa, 0x92, 0xd8, 0xcc, 0x59, 0x8d, 0x96, 0xb4, 0x59, 0xc0, 0xde, 0x12, 0xad, 0x42, 0x95, 0xde,
+	0x2b, 0xe9, 0xbb, 0x64, 0x20, 0xcf, 0xb3, 0x5b, 0x08, 0x08, 0xca, 0x37, 0xa1, 0x2c, 0x6c, 0x49,
+	0xbd, 0xeb, 0x65, 0x28, 0xfd, 0x84, 0xe8, 0xe7, 0x43, 0x97, 0xdd, 0x76, 0x03, 0x8b, 0x95, 0xda,
+	0x83, 0x02, 0xbd, 0x66, 0x54, 0x83, 0xf2, 0xf1, 0xe1, 0x87, 0x87, 0x9d, 0x8f, 0x0f, 0x9b, 0x73,
+	0xa8, 0x0e, 0x95, 0xbd, 0xce, 0x61, 0xf7, 0x68, 0xe7, 0xf0, 0xa8, 0x29, 0xa1, 0x45, 0x68, 0xbe,
+	0x68, 0xe3, 0xbd, 0xf6, 0xe1, 0xd1, 0xce, 0xb3, 0x76, 0x6f, 0x77, 0xa7, 0xdb, 0x7e, 0xd2, 0xcc,
+	0xa1, 0x79, 0x80, 0xf6, 0x8f, 0x5f, 0xe0, 0x76, 0xb7, 0xfb, 0xbc, 0x73, 0xd8, 0xcc, 0x53, 0x01,
+	0x27, 0x3b, 0xf8, 0x39, 0xdd, 0x52, 0x40, 0x55, 0x28, 0xe2, 0xe3, 0x83, 0x76, 0xb7, 0x59, 0x54,
+	0x31, 0x14, 0xa8, 0x4b, 0x62, 0x71, 0x20, 0x25, 0xe2, 0xe0, 0x3e, 0x94, 0x4d, 0xee, 0x0f, 0x39,
+	0x97, 0xe1, 0x27, 0x0f, 0xa0, 0xfe, 0x4a, 0x82, 0xb2, 0x20, 0x22, 0x25, 0x88, 0x4c, 0x2a, 0xb4,
+	0xb2, 0x3f, 0x17, 0xc4, 0x66, 0x2b, 0x12, 0x9b, 0xcc, 0xf0, 0xfd, 0xb9, 0x48, 0x74, 0x2a, 0x50,
+	0x16, 0x17, 0xcf, 0xe2, 0xba, 0x4a, 0x77, 0x0b, 0x42, 0x10, 0x08, 0x85, 0x29, 0x81, 0xb0, 0x5b,
+	0x82, 0xc2, 0x2b, 0xdd, 0x18, 0xa8, 0xbf, 0x91, 0xa0, 0x78, 0x22, 0x42, 0xa3, 0xee, 0xb8, 0x36,
+	0x0d, 0x56, 0xbe, 0x5d, 0x12, 0x82, 0x6b, 0x9c, 0xca, 0x41, 0x6b, 0x50, 0xd5, 0x0d, 0x57, 0x20,
+	0xa8, 0x66, 0xf9, 0xfd, 0x39, 0x5c, 0xd1, 0x0d, 0x97, 0xb3, 0x6f, 0x41, 0xed, 0x6c, 0x64, 0x6a,
+	0x1e, 0x80, 0xea, 0x26, 0x51, 0xd5, 0x19, 0x91, 0x43, 0x36, 0x00, 0x5e, 0x3a, 0xa6, 0xd1, 0x0b,
+	0x74, 0xa4, 0x87, 0x54, 0x29, 0xed, 0x24, 0xa2, 0xd9, 0xef, 0x24, 0x28, 0x77, 0xc9, 0xf9, 0x05,
+	0xc9, 0x08, 0x8d, 0x26, 0xe4, 0x69, 0x56, 0xe5, 0x18, 0x89, 0xfe, 0x44, 0x0a, 0x54, 0x74, 0xa3,
+	0x3f, 0x1a, 0x0f, 0x58, 0xba, 0xe7, 0x37, 0xab, 0xd8, 0x5f, 0x53, 0x1e, 0xb9, 0x12, 0xbc, 0x02,
+	0xe7, 0x79, 0x6b, 0xb4, 0xe8, 0x25, 0x45, 0x91, 0x31, 0xf8, 0xe2, 0xfa, 0x0c, 0x57, 0xff, 0x92,
+	0x87, 0x66, 0x9b, 0xda, 0xa1, 0xd1, 0xe5, 0x13, 0xe2, 0x6a, 0xfa, 0x28, 0x55, 0xd5, 0x50, 0x19,
+	0xca, 0x45, 0xcb, 0x90, 0x1c, 0xbb, 0xc8, 0xb7, 0xbc, 0x46, 0xf4, 0x1d, 0x28, 0xd9, 0x44, 0x73,
+	0x44, 0x89, 0x9a, 0xdf, 0x6e, 0xf9, 0xb0, 0xb8, 0x62, 0x5b, 0x98, 0xe1, 0xb0, 0xc0, 0xd3, 0xcc,
+	0xe2, 0x95, 0x88, 0x59, 0x56, 0xc4, 0x62, 0x85, 0xd6, 0x00, 0xa8, 0xfd, 0x3d, 0xdd, 0x18, 0x90,
+	0x2b, 0x56, 0xb3, 0x8a, 0xb8, 0x4a, 0x29, 0xcf, 0x29, 0x81, 0xfa, 0x8a, 0xd8, 0xb6, 0x69, 0x8b,
+	0x8a, 0xc5, 0x17, 0xe1, 0xec, 0xaf, 0x46, 0xb2, 0x5f, 0xfd, 0xb5, 0x04, 0x25, 0x7e, 0xf2, 0xb4,
+	0x5c, 0x9d, 0x07, 0x08, 0x72, 0xf5, 0xba, 0x2c, 0x9d, 0x07, 0xa0, 0x59, 0xda, 0xfb, 0x68, 0xe7,
+	0x68, 0x6f, 0xbf, 0x59, 0x44, 0x0b, 0x50, 0x7b, 0xba, 0x73, 0x70, 0x70, 0xb4, 0x8f, 0x3b, 0xc7,
+	0xcf, 0xf6, 0x9b, 0x25, 0x9a, 0xc6, 0x6d, 0x8c, 0x3b, 0xb8, 0x59, 0x46, 0x4b, 0xf0, 0xce, 0xd3,
+	0xf6, 0xce, 0xd1, 0x31, 0x6e, 0xf7, 0x0e, 0x3b, 0x47, 0xbd, 0xa7, 0x9d, 0xe3, 0xc3, 0x27, 0xcd,
+	0x8a, 0xfa, 0x07, 0x09, 0x9a, 0xa2, 0x94, 0x76, 0x2c, 0x62, 0x33, 0x37, 0xa1, 0x3b, 0x90, 0x77,
+	0x88, 0x2b, 0x4b, 0xb1, 0x34, 0x16, 0xb8, 0xfd, 0x39, 0x4c, 0xd9, 0x48, 0x86, 0xd2, 0x80, 0x8c,
+	0x88, 0xcb, 0xc3, 0x9f, 0xc6, 0xae, 0x58, 0xa3, 0xf7, 0xa0, 0x49, 0xae, 0x2c, 0x56, 0xd6, 0x7a,
+	0x9e, 0x37, 0xf2, 0xcc, 0x1b, 0x0b, 0x1e, 0xfd, 0x84, 0x93, 0x69, 0x6f, 0xe0, 0xa4, 0x9e, 0x76,
+	0xea, 0x10, 0xc3, 0x65, 0x97, 0x5c, 0xc1, 0x75, 0x4e, 0xdc, 0x61, 0xb4, 0xdd, 0x02, 0xe4, 0x4c,
+	0x4b, 0x1d, 0x43, 0x43, 0x68, 0xb0, 0x37, 0xd4, 0x8c, 0xf3, 0xf4, 0x96, 0xb8, 0x09, 0xa5, 0x53,
+	0x72, 0x66, 0xda, 0xc9, 0x22, 0x24, 0xf6, 0x62, 0xc1, 0x47, 0xf7, 0xa0, 0xa8, 0x9d, 0xb9, 0xc4,
+	0x96, 0xf3, 0x19, 0x40, 0xce, 0x56, 0x5f, 0xc2, 0xe2, 0x8e, 0x65, 0x8d, 0x26, 0x82, 0xec, 0x60,
+	0xf2, 0xd9, 0x98, 0x38, 0x2e, 0xfa, 0x2e, 0x80, 0xe9, 0x79, 0xcc, 0x91, 0x25, 0xd6, 0x45, 0xde,
+	0x8d, 0x0b, 0xf1, 0x7d, 0x8a, 0x43, 0x60, 0xb4, 0x02, 0xe5, 0x81, 0x3d, 0xe9, 0xd9, 0x63, 0x43,
+	0x64, 0x41, 0x69, 0x60, 0x4f, 0xf0, 0xd8, 0x50, 0x7f, 0x06, 0x4b, 0xb1, 0xb3, 0x1c, 0xcb, 0x34,
+	0x1c, 0x82, 0xde, 0x87, 0x72, 0x9f, 0x19, 0xed, 0x9d, 0xb4, 0x1c, 0x3f, 0x89, 0xfb, 0x04, 0x7b,
+	0x30, 0xba, 0xc3, 0x22, 0xc6, 0x40, 0x37, 0xce, 0x85, 0x27, 0x82, 0x1d, 0x2f, 0x38, 0xdd, 0xdb,
+	0x21, 0x60, 0xea, 0x31, 0x2c, 0x3e, 0x61, 0xf7, 0xe7, 0x39, 0x40, 0x18, 0x9a, 0x3e, 0x79, 0x24,
+	0x6f, 0x38, 0x97, 0x7a, 0xc3, 0xea, 0x18, 0x96, 0x62, 0x62, 0x85, 0x4d, 0xf7, 0xc1, 0x1b, 0x8e,
+	0xb2, 0x22, 0x0d, 0x7b, 0x80, 0x2f, 0x61, 0x8d, 0x03, 0xcb, 0x22, 0xf1, 0x67, 0xb1, 0xe7, 0xdb,
+	0x00, 0x96, 0x66, 0x6b, 0x17, 0xc4, 0x25, 0xb6, 0x23, 0x8e, 0x58, 0xd9, 0xe2, 0xc3, 0xd9, 0x96,
+	0x37, 0x9c, 0x6d, 0x75, 0xd9, 0x70, 0x86, 0x43, 0xd0, 0xef, 0xd5, 0x7f, 0xfe, 0x7a, 0x63, 0xee,
+	0x97, 0xaf, 0x37, 0xe6, 0x7e, 0xff, 0x7a, 0x63, 0x4e, 0x3d, 0x80, 0x95, 0xc4, 0xa1, 0xc2, 0xda,
+	0x47, 0x34, 0x5b, 0x68, 0xf9, 0x11, 0xc6, 0xbe, 0x9b, 0x59, 0x9f, 0xb0, 0x00, 0xaa, 0x97, 0x09,
+	0x69, 0x7e, 0xf0, 0x2d, 0x42, 0x91, 0xea, 0xcd, 0xa3, 0xa1, 0x8a, 0xf9, 0xe2, 0x7f, 0x65, 0x45,
+	0x07, 0xe4, 0xe4, 0xb9, 0xc2, 0x8c, 0xc7, 0x50, 0xe6, 0xda, 0x25, 0x43, 0x3e, 0x61, 0x87, 0x87,
+	0x54, 0xff, 0x2c, 0x41, 0x5d, 0x48, 0x6a, 0x5f, 0xd2, 0x2e, 0xb6, 0x25, 0x06, 0x57, 0x89, 0x95,
+	0x6a, 0x25, 0x7e, 0xef, 0x0c, 0x14, 0x9e, 0x5e, 0x15, 0xa8, 0xd8, 0xe4, 0x52, 0x0f, 0x85, 0x99,
+	0xbf, 0x0e, 0x87, 0x51, 0xfe, 0x9a, 0x30, 0x52, 0x37, 0xd3, 0x86, 0xa5, 0x32, 0xe4, 0xbb, 0x6d,
+	0x5a, 0x7b, 0x01, 0x4a, 0x4f, 0xda, 0x07, 0xed, 0xa3, 0x76, 0x33, 0xa7, 0x7e, 0x06, 0x0b, 0x62,
+	0x77, 0xd7, 0xd0, 0x2c, 0x67, 0x68, 0xba, 0x11, 0x25, 0xa4, 0x98, 0x12, 0x0f, 0xa0, 0x22, 0xce,
+	0xa0, 0x7e, 0xcf, 0xa7, 0x6a, 0xe1, 0x23, 0x58, 0xeb, 0xb0, 0xcc, 0xfe, 0x50, 0x74, 0x3a, 0xbe,
+	0x50, 0xff, 0x26, 0xf9, 0x67, 0x62, 0x4f, 0x6e, 0x46, 0x0f, 0x8d, 0xa6, 0x9c, 0xb7, 0x44, 0x77,
+	0x60, 0xde, 0xd5, 0x2f, 0x48, 0x6f, 0x6c, 0xe8, 0x57, 0x3d, 0x43, 0x33, 0x4c, 0x76, 0x40, 0x1e,
+	0xd7, 0x29, 0xf5, 0xd8, 0xd0, 0xaf, 0x0e, 0x35, 0xc3, 0xa4, 0xa7, 0x6b, 0x7d, 0xd7, 0xb4, 0xf9,
+	0xc8, 0x81, 0xf9, 0x22, 0x54, 0x38, 0x8b, 0xb3, 0x16, 0xce, 0xd2, 0xf4, 0xc2, 0xf9, 0x9f, 0x1c,
+	0xc0, 0xce, 0x78, 0xa0, 0xbb, 0x6d, 0xc3, 0xb5, 0x27, 0x29, 0xca, 0x49, 0x29, 0xca, 0x2d, 0x43,
+	0x49, 0xeb, 0xbb, 0x9e, 0x6d, 0x55, 0x2c, 0x56, 0xd4, 0xe8, 0xf0, 0x2d, 0x57, 0x83, 0xd2, 0x10,
+	0x72, 0x47, 0x21, 0xea, 0x0e, 0xdf, 0xd0, 0x62, 0xd8, 0x50, 0x04, 0x05, 0x8b, 0x08, 0xed, 0xab,
+	0x98, 0xfd, 0xa6, 0xa7, 0x5e, 0x10, 0x77, 0x68, 0x0e, 0xc4, 0xd3, 0x44, 0xac, 0x28, 0x5d, 0x0c,
+	0x15, 0xbc, 0xc9, 0x8b, 0x55, 0xc8, 0x59, 0xd5, 0x59, 0x9d, 0x05, 0x53, 0x9d, 0x85, 0x6e, 0xd2,
+	0xb7, 0x01, 0xb9, 0xec, 0x0d, 0x35, 0x67, 0x28, 0xd7, 0xd8, 0x61, 0x15, 0x4a, 0xd8, 0xd7, 0x9c,
+	0x21, 0x55, 0x99, 0xd1, 0xeb, 0x5c, 0x65, 0xfa, 0x1b, 0x6d, 0x40, 0x4d, 0xb3, 0x2c, 0xdb, 0xbc,
+	0x24, 0x83, 0xde, 0xe9, 0x84, 0x3d, 0x35, 0xaa, 0x18, 0x3c, 0xd2, 0xee, 0x44, 0xfd, 0xb7, 0x04,
+	0x8d, 0x48, 0x6d, 0x44, 0xf3, 0x90, 0xd3, 0x07, 0x22, 0x94, 0x72, 0xfa, 0x20, 0xdc, 0x54, 0x72,
+	0xb3, 0x35, 0x95, 0x5b, 0x50, 0xb7, 0x79, 0x05, 0xe2, 0xa7, 0xf2, 0xab, 0xa8, 0xf9, 0xb4, 0xdd,
+	0x49, 0xc8, 0x65, 0x85, 0x88, 0xcb, 0x1e, 0xc1, 0x52, 0xdf, 0x26, 0x9a, 0x4b, 0x7a, 0xb1, 0x28,
+	0x28, 0xb2, 0x28, 0x40, 0x9c, 0x79, 0x14, 0x8e, 0x85, 0x47, 0xb0, 0x44, 0xae, 0x2c, 0xdd, 0x4e,
+	0x6c, 0x29, 0xf1, 0x2d, 0x9c, 0x19, 0xde, 0xa2, 0xde, 0x63, 0xcd, 0x9a, 0xba, 0x40, 0xa8, 0x2e,
+	0xea, 0x65, 0xcc, 0x74, 0xf5, 0x39, 0x2c, 0xc5, 0x70, 0x5f, 0xb6, 0xd1, 0xaa, 0x37, 0xe1, 0xdd,
+	0x03, 0xdd, 0x71, 0x23, 0xae, 0xf6, 0xea, 0xb4, 0x7a, 0x08, 0x4a, 0x1a, 0x33, 0x38, 0xcc, 0xeb,
+	0x6a, 0xf1, 0xc3, 0x32, 0xba, 0xda, 0x5d, 0xb8, 0x81, 0xc9, 0x4b, 0xd2, 0x77, 0xa7, 0x9b, 0xb7,
+	0x0f, 0x8b, 0x51, 0x58, 0xda, 0x81, 0x33, 0xb5, 0xd1, 0x7f, 0x48, 0xb0, 0xd0, 0xed, 0x0f, 0xc9,
+	0x60, 0x3c, 0x22, 0x83, 0x8c, 0x38, 0xf2, 0x8a, 0x54, 0x2e, 0x54, 0xa4, 0x1e, 0x40, 0xd1, 0x71,
+	0x89, 0xe5, 0xb0, 0xe7, 0x47, 0xf8, 0x1c, 0x5f, 0x58, 0xd7, 0x25, 0x16, 0xe6, 0xa0, 0x44, 0x5c,
+	0x15, 0xa6, 0xc5, 0x55, 0x71, 0xb6, 0xb8, 0x2a, 0x65, 0xc5, 0x95, 0xfa, 0x47, 0x09, 0x1a, 0x11,
+	0x35, 0x66, 0xac, 0x4d, 0x0f, 0x82, 0x1a, 0x94, 0xcb, 0x1c, 0x8d, 0x3d, 0x48, 0xf8, 0x5d, 0x9b,
+	0x9f, 0xfe, 0xae, 0x2d, 0x24, 0xdf, 0xb5, 0xbb, 0x15, 0x28, 0xf1, 0x00, 0x53, 0x3f, 0x85, 0x65,
+	0x4f, 0xd9, 0x19, 0x06, 0x19, 0xdf, 0xef, 0xb9, 0x19, 0xfc, 0xae, 0x76, 0x60, 0x25, 0x21, 0x5b,
+	0x84, 0xca, 0x07, 0x50, 0x71, 0x04, 0x4b, 0xc4, 0x8a, 0x9c, 0x94, 0x25, 0xa2, 0xc5, 0x47, 0xaa,
+	0x8f, 0xe0, 0x26, 0x8d, 0xf7, 0x18, 0xc0, 0x99, 0xa2, 0xb1, 0x7a, 0x02, 0xab, 0xe9, 0x5b, 0x84,
+	0x22, 0xdf, 0x82, 0xaa, 0x27, 0xde, 0xcb, 0xc9, 0x6c, 0x4d, 0x02, 0xa8, 0xba, 0x05, 0xab, 0x7b,
+	0x9a, 0xd1, 0x27, 0xa3, 0x38, 0x26, 0x23, 0x67, 0x8e, 0x61, 0x2d, 0x03, 0xff, 0x95, 0x3c, 0xf2,
+	0x35, 0x78, 0xe7, 0x19, 0x71, 0xaf, 0xbf, 0x39, 0xf5, 0x07, 0x80, 0xc2, 0xc0, 0xb7, 0x1f, 0x92,
+	0xd5, 0xc7, 0x61, 0x09, 0xbe, 0xcf, 0xd7, 0x00, 0xa8, 0x7c, 0xa7, 0x67, 0x1a, 0xa3, 0x09, 0xff,
+	0xc4, 0x82, 0xab, 0x8c, 0xd2, 0x31, 0x46, 0x13, 0xf5, 0x13, 0xb8, 0x11, 0xd9, 0x24, 0xce, 0x0d,
+	0x0f, 0x34, 0xd2, 0x2c, 0x03, 0x0d, 0x1f, 0x47, 0x73, 0xa1, 0x71, 0x54, 0xfd, 0x93, 0x04, 0x2b,
+	0xf4, 0x6a, 0xfd, 0x21, 0x40, 0x0f, 0xb4, 0x92, 0xa3, 0x76, 0x85, 0xfa, 0xb9, 0xdf, 0xb5, 0x73,
+	0xe1, 0xae, 0x7d, 0x0f, 0x16, 0x1c, 0xdd, 0xe8, 0x27, 0x67, 0x9b, 0x06, 0x23, 0xfb, 0x39, 0x7a,
+	0x0f, 0x16, 0xc6, 0x86, 0xab, 0x8f, 0x42, 0xb8, 0x02, 0xc7, 0x31, 0x72, 0x78, 0x08, 0x1a, 0xe9,
+	0x17, 0xba, 0xcb, 0xaa, 0x49, 0x03, 0xf3, 0x85, 0xfa, 0x1c, 0xe4, 0xa4, 0xc2, 0xc2, 0x23, 0xdf,
+	0xa0, 0xf9, 0xcc, 0x48, 0xc2, 0x21, 0x37, 0x7c, 0x87, 0x04, 0x53, 0x0e, 0xf6, 0x30, 0xea, 0x43,
+	0xde, 0x16, 0xbc, 0xc2, 0xa0, 0x3b, 0xae, 0x69, 0x4f, 0xa6, 0xdd, 0xff, 0x11, 0x28, 0x69, 0x1b,
+	0x82, 0x2c, 0xf0, 0x86, 0xcd, 0x64, 0x16, 0xc4, 0xa6, 0x46, 0x1c, 0x40, 0xd5, 0xa7, 0xb0, 0x8c,
+	0xcd, 0xd1, 0xe8, 0x54, 0xeb, 0xbf, 0x9a, 0xa1, 0x7a, 0x64, 0x8e, 0x96, 0xea, 0x6f, 0x25, 0x58,
+	0x49, 0x08, 0x12, 0xba, 0x05, 0xd3, 0x90, 0x34, 0xeb, 0x34, 0x94, 0x9b, 0x3e, 0x0d, 0x85, 0xfa,
+	0x54, 0x7e, 0xb6, 0x3e, 0xf5, 0x23, 0x58, 0xfc, 0x58, 0x73, 0xfb, 0xc3, 0x78, 0xf4, 0xdf, 0x86,
+	0xc6, 0x99, 0x6d, 0x5e, 0xf4, 0x62, 0x93, 0x7b, 0x9d, 0x12, 0xfd, 0x29, 0xdb, 0x9f, 0xc7, 0x73,
+	0xe1, 0x79, 0xfc, 0xa7, 0xb0, 0x14, 0x13, 0x19, 0x2a, 0x04, 0xe2, 0x51, 0x90, 0x28, 0x04, 0xb1,
+	0x47, 0x03, 0xf6, 0x91, 0xe8, 0xeb, 0x50, 0x24, 0xf4, 0x5d, 0x23, 0x6c, 0x5f, 0x4a, 0x7d, 0xf4,
+	0x60, 0x8e, 0x51, 0x7f, 0x21, 0xc1, 0x3b, 0xdd, 0x44, 0xd9, 0x78, 0x9b, 0x17, 0xf3, 0xec, 0x2f,
+	0xf4, 0xe4, 0x37, 0x98, 0x7c, 0xf2, 0x1b, 0x8c, 0xfa, 0x85, 0x04, 0xa8, 0x4b, 0xdc, 0xff, 0xc7,
+	0xbb, 0xbf, 0xef, 0x7d, 0xb8, 0x10, 0x1f, 0x49, 0xa7, 0x65, 0xd9, 0x1e, 0x2c, 0xc5, 0xb0, 0x41,
+	0xa1, 0x75, 0x38, 0x29, 0x61, 0x89, 0x07, 0xf5, 0x00, 0xa2, 0xa6, 0xcf, 0x70, 0x1a, 0xaf, 0xe9,
+	0x5f, 0xe5, 0xa8, 0xc5, 0xb0, 0x04, 0x7f, 0xac, 0xdc, 0x83, 0x1b, 0x11, 0x6a, 0x50, 0xb4, 0xc5,
+	0xbe, 0x64, 0xd1, 0xf6, 0x24, 0xfb, 0x08, 0xf5, 0xfb, 0x2c, 0xc4, 0x62, 0x56, 0xbc, 0x8d, 0x6e,
+	0x67, 0x2c, 0x22, 0xe2, 0xd6, 0x65, 0x47, 0x84, 0x87, 0xbc, 0x36, 0x22, 0x3c, 0x20, 0x67, 0x6f,
+	0x7f, 0x3e, 0x0f, 0x15, 0x2f, 0x09, 0xd1, 0x0b, 0x68, 0x44, 0x3e, 0x91, 0xa1, 0xb5, 0xa0, 0x0c,
+	0xa7, 0x7c, 0xa6, 0x53, 0xd6, 0xb3, 0xd8, 0x5c, 0x5d, 0x75, 0x8e, 0x4a, 0x8c, 0x7c, 0xa0, 0x0a,
+	0x49, 0x4c, 0xfb, 0x1e, 0xa6, 0xac, 0x67, 0xb1, 0x7d, 0x89, 0x27, 0xb0, 0x10, 0xfb, 0x80, 0x82,
+	0x36, 0xe2, 0x9f, 0x49, 0xe2, 0x52, 0x5b, 0xd9, 0x00, 0x5f, 0xee, 0x27, 0xd0, 0x8c, 0x31, 0x1d,
+	0x94, 0xb9, 0xcf, 0xf7, 0xc0, 0xad, 0x29, 0x08, 0x5f, 0xf4, 0x33, 0x80, 0x60, 0x0c, 0x40, 0xc1,
+	0x17, 0x99, 0xc4, 0xec, 0xa2, 0xdc, 0x4c, 0xe5, 0xf9, 0x82, 0x7e, 0x08, 0xb5, 0x80, 0xee, 0xa0,
+	0x34, 0xb4, 0xaf, 0xd9, 0x6a, 0x3a, 0x33, 0x6c, 0x6f, 0xbc, 0x1d, 0x87, 0xec, 0xcd, 0x18, 0x2d,
+	0x94, 0x5b, 0x53, 0x10, 0xbe, 0xe8, 0x1e, 0xa0, 0x64, 0xb7, 0x45, 0x6a, 0x64, 0x6b, 0x6a, 0xef,
+	0x56, 0x6e, 0x4f, 0xc5, 0x84, 0x63, 0x20, 0xd6, 0x2f, 0x43, 0x31, 0x90, 0xde, 0x92, 0x95, 0x56,
+	0x36, 0x20, 0x7c, 0x51, 0xdd, 0xb4, 0x8b, 0xea, 0x4e, 0xb9, 0xa8, 0x6e, 0xda, 0x45, 0x61, 0x68,
+	0x44, 0xda, 0x5b, 0x28, 0xec, 0xd3, 0x3a, 0xa9, 0xb2, 0x9e, 0xc5, 0xf6, 0x24, 0xbe, 0x2f, 0x89,
+	0xe4, 0x0c, 0x9e, 0xd5, 0xd1, 0xe4, 0x4c, 0x3c, 0xcb, 0x95, 0xf5, 0x2c, 0x76, 0xfc, 0x9e, 0xa2,
+	0x0f, 0xe8, 0xd8, 0x3d, 0xa5, 0x3e, 0xbd, 0x95, 0xdb, 0x53, 0x31, 0xfe, 0x01, 0x1f, 0x41, 0x3d,
+	0xfc, 0x54, 0x46, 0x41, 0x4c, 0xa6, 0x3c, 0xb4, 0x95, 0xb5, 0x0c, 0xae, 0x2f, 0x6e, 0x08, 0x4b,
+	0xa9, 0xaf, 0x08, 0x74, 0xd7, 0xdf, 0x39, 0xed, 0x55, 0xa2, 0xdc, 0xbb, 0x0e, 0xe6, 0x9f, 0x44,
+	0x60, 0x31, 0xed, 0xdd, 0x84, 0xee, 0x44, 0xec, 0xce, 0x78, 0x89, 0x29, 0x77, 0xaf, 0x41, 0x85,
+	0xe3, 0x38, 0xf6, 0x44, 0x0c, 0xc5, 0x71, 0xfa, 0xc3, 0x54, 0x69, 0x65, 0x03, 0x92, 0x55, 0xd7,
+	0xfb, 0xcb, 0x66, 0xbc, 0xea, 0x46, 0x1b, 0x93, 0xb2, 0x9e, 0xc5, 0x8e, 0x95, 0x30, 0x4f, 0x5c,
+	0xa4, 0x84, 0xc5, 0x64, 0xdd, 0x4c, 0xe5, 0xc5, 0x4a, 0x98, 0xa0, 0xc7, 0x4a, 0x58, 0xac, 0x13,
+	0x2b, 0xab, 0xe9, 0xcc, 0x58, 0xba, 0x26, 0x95, 0xea, 0x4e, 0x51, 0xaa, 0x9b, 0xa2, 0xd4, 0xee,
+	0xad, 0xbf, 0xbe, 0x59, 0x97, 0xfe, 0xfe, 0x66, 0x5d, 0xfa, 0xe7, 0x9b, 0x75, 0xe9, 0x8b, 0x7f,
+	0xad, 0xcf, 0x7d, 0xba, 0xb0, 0xf5, 0x30, 0xf2, 0x1f, 0x27, 0xa7, 0x25, 0xb6, 0x7c, 0xfc, 0xdf,
+	0x01, 0x00, 0x6f, 0x73, 0xd3, 0x20, 0x89, 0x22, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// FeaturesClient is the client API for Features service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FeaturesClient interface {
+	ApplyFeatures(ctx context.Context, in *ApplyFeaturesRequest, opts ...grpc.CallOption) (*ApplyFeaturesResponse, error)
+	DeleteFeature(ctx context.Context, in *DeleteFeatureRequest, opts ...grpc.CallOption) (*DeleteFeatureResponse, error)
+	EvaluateFeature(ctx context.Context, in *EvaluateFeatureRequest, opts ...grpc.CallOption) (*EvaluateFeatureResponse, error)
+	EvaluateFeatures(ctx context.Context, in *EvaluateFeaturesRequest, opts ...grpc.CallOption) (*EvaluateFeaturesResponse, error)
+	GetFeature(ctx context.Context, in *GetFeatureRequest, opts ...grpc.CallOption) (*GetFeatureResponse, error)
+	GetFeatures(ctx context.Context, in *GetFeaturesRequest, opts ...grpc.CallOption) (*GetFeaturesResponse, error)
+	ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest, opts ...grpc.CallOption) (*ListAuditEntriesResponse, error)
+	ListFeatureHistory(ctx context.Context, in *ListFeatureHistoryRequest, opts ...grpc.CallOption) (*ListFeatureHistoryResponse, error)
+	RollbackFeature(ctx context.Context, in *RollbackFeatureRequest, opts ...grpc.CallOption) (*RollbackFeatureResponse, error)
+	SetFeature(ctx context.Context, in *SetFeatureRequest, opts ...grpc.CallOption) (*SetFeatureResponse, error)
+	WatchFeatures(ctx context.Context, in *WatchFeaturesRequest, opts ...grpc.CallOption) (Features_WatchFeaturesClient, error)
+	ApproveChange(ctx context.Context, in *ApproveChangeRequest, opts ...grpc.CallOption) (*ApproveChangeResponse, error)
+	ListPendingChanges(ctx context.Context, in *ListPendingChangesRequest, opts ...grpc.CallOption) (*ListPendingChangesResponse, error)
+	RejectChange(ctx context.Context, in *RejectChangeRequest, opts ...grpc.CallOption) (*RejectChangeResponse, error)
+	CancelScheduledChange(ctx context.Context, in *CancelScheduledChangeRequest, opts ...grpc.CallOption) (*CancelScheduledChangeResponse, error)
+	ListScheduledChanges(ctx context.Context, in *ListScheduledChangesRequest, opts ...grpc.CallOption) (*ListScheduledChangesResponse, error)
+	ScheduleFeature(ctx context.Context, in *ScheduleFeatureRequest, opts ...grpc.CallOption) (*ScheduleFeatureResponse, error)
+	DeleteSegment(ctx context.Context, in *DeleteSegmentRequest, opts ...grpc.CallOption) (*DeleteSegmentResponse, error)
+	GetSegment(ctx context.Context, in *GetSegmentRequest, opts ...grpc.CallOption) (*GetSegmentResponse, error)
+	GetSegments(ctx context.Context, in *GetSegmentsRequest, opts ...grpc.CallOption) (*GetSegmentsResponse, error)
+	SetSegment(ctx context.Context, in *SetSegmentRequest, opts ...grpc.CallOption) (*SetSegmentResponse, error)
+}
+
+type featuresClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFeaturesClient(cc *grpc.ClientConn) FeaturesClient {
+	return &featuresClient{cc}
+}
+
+func (c *featuresClient) ApplyFeatures(ctx context.Context, in *ApplyFeaturesRequest, opts ...grpc.CallOption) (*ApplyFeaturesResponse, error) {
+	out := new(ApplyFeaturesResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/ApplyFeatures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) DeleteFeature(ctx context.Context, in *DeleteFeatureRequest, opts ...grpc.CallOption) (*DeleteFeatureResponse, error) {
+	out := new(DeleteFeatureResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/DeleteFeature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) EvaluateFeature(ctx context.Context, in *EvaluateFeatureRequest, opts ...grpc.CallOption) (*EvaluateFeatureResponse, error) {
+	out := new(EvaluateFeatureResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/EvaluateFeature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) EvaluateFeatures(ctx context.Context, in *EvaluateFeaturesRequest, opts ...grpc.CallOption) (*EvaluateFeaturesResponse, error) {
+	out := new(EvaluateFeaturesResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/EvaluateFeatures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) GetFeature(ctx context.Context, in *GetFeatureRequest, opts ...grpc.CallOption) (*GetFeatureResponse, error) {
+	out := new(GetFeatureResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/GetFeature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) GetFeatures(ctx context.Context, in *GetFeaturesRequest, opts ...grpc.CallOption) (*GetFeaturesResponse, error) {
+	out := new(GetFeaturesResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/GetFeatures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest, opts ...grpc.CallOption) (*ListAuditEntriesResponse, error) {
+	out := new(ListAuditEntriesResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/ListAuditEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) ListFeatureHistory(ctx context.Context, in *ListFeatureHistoryRequest, opts ...grpc.CallOption) (*ListFeatureHistoryResponse, error) {
+	out := new(ListFeatureHistoryResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/ListFeatureHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) RollbackFeature(ctx context.Context, in *RollbackFeatureRequest, opts ...grpc.CallOption) (*RollbackFeatureResponse, error) {
+	out := new(RollbackFeatureResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/RollbackFeature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) SetFeature(ctx context.Context, in *SetFeatureRequest, opts ...grpc.CallOption) (*SetFeatureResponse, error) {
+	out := new(SetFeatureResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/SetFeature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) WatchFeatures(ctx context.Context, in *WatchFeaturesRequest, opts ...grpc.CallOption) (Features_WatchFeaturesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Features_serviceDesc.Streams[0], "/feature.Features/WatchFeatures", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &featuresWatchFeaturesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Features_WatchFeaturesClient interface {
+	Recv() (*WatchFeaturesResponse, error)
+	grpc.ClientStream
+}
+
+type featuresWatchFeaturesClient struct {
+	grpc.ClientStream
+}
+
+func (x *featuresWatchFeaturesClient) Recv() (*WatchFeaturesResponse, error) {
+	m := new(WatchFeaturesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *featuresClient) ApproveChange(ctx context.Context, in *ApproveChangeRequest, opts ...grpc.CallOption) (*ApproveChangeResponse, error) {
+	out := new(ApproveChangeResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/ApproveChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) ListPendingChanges(ctx context.Context, in *ListPendingChangesRequest, opts ...grpc.CallOption) (*ListPendingChangesResponse, error) {
+	out := new(ListPendingChangesResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/ListPendingChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) RejectChange(ctx context.Context, in *RejectChangeRequest, opts ...grpc.CallOption) (*RejectChangeResponse, error) {
+	out := new(RejectChangeResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/RejectChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) CancelScheduledChange(ctx context.Context, in *CancelScheduledChangeRequest, opts ...grpc.CallOption) (*CancelScheduledChangeResponse, error) {
+	out := new(CancelScheduledChangeResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/CancelScheduledChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) ListScheduledChanges(ctx context.Context, in *ListScheduledChangesRequest, opts ...grpc.CallOption) (*ListScheduledChangesResponse, error) {
+	out := new(ListScheduledChangesResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/ListScheduledChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) ScheduleFeature(ctx context.Context, in *ScheduleFeatureRequest, opts ...grpc.CallOption) (*ScheduleFeatureResponse, error) {
+	out := new(ScheduleFeatureResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/ScheduleFeature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) DeleteSegment(ctx context.Context, in *DeleteSegmentRequest, opts ...grpc.CallOption) (*DeleteSegmentResponse, error) {
+	out := new(DeleteSegmentResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/DeleteSegment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) GetSegment(ctx context.Context, in *GetSegmentRequest, opts ...grpc.CallOption) (*GetSegmentResponse, error) {
+	out := new(GetSegmentResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/GetSegment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) GetSegments(ctx context.Context, in *GetSegmentsRequest, opts ...grpc.CallOption) (*GetSegmentsResponse, error) {
+	out := new(GetSegmentsResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/GetSegments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featuresClient) SetSegment(ctx context.Context, in *SetSegmentRequest, opts ...grpc.CallOption) (*SetSegmentResponse, error) {
+	out := new(SetSegmentResponse)
+	err := c.cc.Invoke(ctx, "/feature.Features/SetSegment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FeaturesServer is the server API for Features service.
+type FeaturesServer interface {
+	ApplyFeatures(context.Context, *ApplyFeaturesRequest) (*ApplyFeaturesResponse, error)
+	DeleteFeature(context.Context, *DeleteFeatureRequest) (*DeleteFeatureResponse, error)
+	EvaluateFeature(context.Context, *EvaluateFeatureRequest) (*EvaluateFeatureResponse, error)
+	EvaluateFeatures(context.Context, *EvaluateFeaturesRequest) (*EvaluateFeaturesResponse, error)
+	GetFeature(context.Context, *GetFeatureRequest) (*GetFeatureResponse, error)
+	GetFeatures(context.Context, *GetFeaturesRequest) (*GetFeaturesResponse, error)
+	ListAuditEntries(context.Context, *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error)
+	ListFeatureHistory(context.Context, *ListFeatureHistoryRequest) (*ListFeatureHistoryResponse, error)
+	RollbackFeature(context.Context, *RollbackFeatureRequest) (*RollbackFeatureResponse, error)
+	SetFeature(context.Context, *SetFeatureRequest) (*SetFeatureResponse, error)
+	WatchFeatures(*WatchFeaturesRequest, Features_WatchFeaturesServer) error
+	ApproveChange(context.Context, *ApproveChangeRequest) (*ApproveChangeResponse, error)
+	ListPendingChanges(context.Context, *ListPendingChangesRequest) (*ListPendingChangesResponse, error)
+	RejectChange(context.Context, *RejectChangeRequest) (*RejectChangeResponse, error)
+	CancelScheduledChange(context.Context, *CancelScheduledChangeRequest) (*CancelScheduledChangeResponse, error)
+	ListScheduledChanges(context.Context, *ListScheduledChangesRequest) (*ListScheduledChangesResponse, error)
+	ScheduleFeature(context.Context, *ScheduleFeatureRequest) (*ScheduleFeatureResponse, error)
+	DeleteSegment(context.Context, *DeleteSegmentRequest) (*DeleteSegmentResponse, error)
+	GetSegment(context.Context, *GetSegmentRequest) (*GetSegmentResponse, error)
+	GetSegments(context.Context, *GetSegmentsRequest) (*GetSegmentsResponse, error)
+	SetSegment(context.Context, *SetSegmentRequest) (*SetSegmentResponse, error)
+}
+
+// UnimplementedFeaturesServer can be embedded to have forward compatible implementations.
+type UnimplementedFeaturesServer struct {
+}
+
+func (*UnimplementedFeaturesServer) ApplyFeatures(ctx context.Context, req *ApplyFeaturesRequest) (*ApplyFeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyFeatures not implemented")
+}
+func (*UnimplementedFeaturesServer) DeleteFeature(ctx context.Context, req *DeleteFeatureRequest) (*DeleteFeatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFeature not implemented")
+}
+func (*UnimplementedFeaturesServer) EvaluateFeature(ctx context.Context, req *EvaluateFeatureRequest) (*EvaluateFeatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateFeature not implemented")
+}
+func (*UnimplementedFeaturesServer) EvaluateFeatures(ctx context.Context, req *EvaluateFeaturesRequest) (*EvaluateFeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateFeatures not implemented")
+}
+func (*UnimplementedFeaturesServer) GetFeature(ctx context.Context, req *GetFeatureRequest) (*GetFeatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeature not implemented")
+}
+func (*UnimplementedFeaturesServer) GetFeatures(ctx context.Context, req *GetFeaturesRequest) (*GetFeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeatures not implemented")
+}
+func (*UnimplementedFeaturesServer) ListAuditEntries(ctx context.Context, req *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEntries not implemented")
+}
+func (*UnimplementedFeaturesServer) ListFeatureHistory(ctx context.Context, req *ListFeatureHistoryRequest) (*ListFeatureHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeatureHistory not implemented")
+}
+func (*UnimplementedFeaturesServer) RollbackFeature(ctx context.Context, req *RollbackFeatureRequest) (*RollbackFeatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackFeature not implemented")
+}
+func (*UnimplementedFeaturesServer) SetFeature(ctx context.Context, req *SetFeatureRequest) (*SetFeatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeature not implemented")
+}
+func (*UnimplementedFeaturesServer) WatchFeatures(req *WatchFeaturesRequest, srv Features_WatchFeaturesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFeatures not implemented")
+}
+func (*UnimplementedFeaturesServer) ApproveChange(ctx context.Context, req *ApproveChangeRequest) (*ApproveChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveChange not implemented")
+}
+func (*UnimplementedFeaturesServer) ListPendingChanges(ctx context.Context, req *ListPendingChangesRequest) (*ListPendingChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingChanges not implemented")
+}
+func (*UnimplementedFeaturesServer) RejectChange(ctx context.Context, req *RejectChangeRequest) (*RejectChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectChange not implemented")
+}
+func (*UnimplementedFeaturesServer) CancelScheduledChange(ctx context.Context, req *CancelScheduledChangeRequest) (*CancelScheduledChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledChange not implemented")
+}
+func (*UnimplementedFeaturesServer) ListScheduledChanges(ctx context.Context, req *ListScheduledChangesRequest) (*ListScheduledChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledChanges not implemented")
+}
+func (*UnimplementedFeaturesServer) ScheduleFeature(ctx context.Context, req *ScheduleFeatureRequest) (*ScheduleFeatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleFeature not implemented")
+}
+func (*UnimplementedFeaturesServer) DeleteSegment(ctx context.Context, req *DeleteSegmentRequest) (*DeleteSegmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSegment not implemented")
+}
+func (*UnimplementedFeaturesServer) GetSegment(ctx context.Context, req *GetSegmentRequest) (*GetSegmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSegment not implemented")
+}
+func (*UnimplementedFeaturesServer) GetSegments(ctx context.Context, req *GetSegmentsRequest) (*GetSegmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSegments not implemented")
+}
+func (*UnimplementedFeaturesServer) SetSegment(ctx context.Context, req *SetSegmentRequest) (*SetSegmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSegment not implemented")
+}
+
+func RegisterFeaturesServer(s *grpc.Server, srv FeaturesServer) {
+	s.RegisterService(&_Features_serviceDesc, srv)
+}
+
+func _Features_ApplyFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyFeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).ApplyFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/ApplyFeatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).ApplyFeatures(ctx, req.(*ApplyFeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_DeleteFeature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFeatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).DeleteFeature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/DeleteFeature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).DeleteFeature(ctx, req.(*DeleteFeatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_EvaluateFeature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateFeatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).EvaluateFeature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/EvaluateFeature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).EvaluateFeature(ctx, req.(*EvaluateFeatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_EvaluateFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateFeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).EvaluateFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/EvaluateFeatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).EvaluateFeatures(ctx, req.(*EvaluateFeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_GetFeature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).GetFeature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/GetFeature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).GetFeature(ctx, req.(*GetFeatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_GetFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).GetFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/GetFeatures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).GetFeatures(ctx, req.(*GetFeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_ListAuditEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).ListAuditEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/ListAuditEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).ListAuditEntries(ctx, req.(*ListAuditEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_ListFeatureHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeatureHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).ListFeatureHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/ListFeatureHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).ListFeatureHistory(ctx, req.(*ListFeatureHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_RollbackFeature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackFeatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).RollbackFeature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/RollbackFeature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).RollbackFeature(ctx, req.(*RollbackFeatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_SetFeature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).SetFeature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/SetFeature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).SetFeature(ctx, req.(*SetFeatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_WatchFeatures_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFeaturesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeaturesServer).WatchFeatures(m, &featuresWatchFeaturesServer{stream})
+}
+
+type Features_WatchFeaturesServer interface {
+	Send(*WatchFeaturesResponse) error
+	grpc.ServerStream
+}
+
+type featuresWatchFeaturesServer struct {
+	grpc.ServerStream
+}
+
+func (x *featuresWatchFeaturesServer) Send(m *WatchFeaturesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Features_ApproveChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).ApproveChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/ApproveChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).ApproveChange(ctx, req.(*ApproveChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_ListPendingChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).ListPendingChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/ListPendingChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).ListPendingChanges(ctx, req.(*ListPendingChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_RejectChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).RejectChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/RejectChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).RejectChange(ctx, req.(*RejectChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_CancelScheduledChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).CancelScheduledChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/CancelScheduledChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).CancelScheduledChange(ctx, req.(*CancelScheduledChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_ListScheduledChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).ListScheduledChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/ListScheduledChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).ListScheduledChanges(ctx, req.(*ListScheduledChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_ScheduleFeature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleFeatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).ScheduleFeature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/ScheduleFeature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).ScheduleFeature(ctx, req.(*ScheduleFeatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_DeleteSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).DeleteSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/DeleteSegment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).DeleteSegment(ctx, req.(*DeleteSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_GetSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).GetSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/GetSegment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).GetSegment(ctx, req.(*GetSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_GetSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSegmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).GetSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/GetSegments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).GetSegments(ctx, req.(*GetSegmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Features_SetSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeaturesServer).SetSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feature.Features/SetSegment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeaturesServer).SetSegment(ctx, req.(*SetSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Features_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "feature.Features",
+	HandlerType: (*FeaturesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ApplyFeatures",
+			Handler:    _Features_ApplyFeatures_Handler,
+		},
+		{
+			MethodName: "DeleteFeature",
+			Handler:    _Features_DeleteFeature_Handler,
+		},
+		{
+			MethodName: "EvaluateFeature",
+			Handler:    _Features_EvaluateFeature_Handler,
+		},
+		{
+			MethodName: "EvaluateFeatures",
+			Handler:    _Features_EvaluateFeatures_Handler,
+		},
+		{
+			MethodName: "GetFeature",
+			Handler:    _Features_GetFeature_Handler,
+		},
+		{
+			MethodName: "GetFeatures",
+			Handler:    _Features_GetFeatures_Handler,
+		},
+		{
+			MethodName: "ListAuditEntries",
+			Handler:    _Features_ListAuditEntries_Handler,
+		},
+		{
+			MethodName: "ListFeatureHistory",
+			Handler:    _Features_ListFeatureHistory_Handler,
+		},
+		{
+			MethodName: "RollbackFeature",
+			Handler:    _Features_RollbackFeature_Handler,
+		},
+		{
+			MethodName: "SetFeature",
+			Handler:    _Features_SetFeature_Handler,
+		},
+		{
+			MethodName: "ApproveChange",
+			Handler:    _Features_ApproveChange_Handler,
+		},
+		{
+			MethodName: "ListPendingChanges",
+			Handler:    _Features_ListPendingChanges_Handler,
+		},
+		{
+			MethodName: "RejectChange",
+			Handler:    _Features_RejectChange_Handler,
+		},
+		{
+			MethodName: "CancelScheduledChange",
+			Handler:    _Features_CancelScheduledChange_Handler,
+		},
+		{
+			MethodName: "ListScheduledChanges",
+			Handler:    _Features_ListScheduledChanges_Handler,
+		},
+		{
+			MethodName: "ScheduleFeature",
+			Handler:    _Features_ScheduleFeature_Handler,
+		},
+		{
+			MethodName: "DeleteSegment",
+			Handler:    _Features_DeleteSegment_Handler,
+		},
+		{
+			MethodName: "GetSegment",
+			Handler:    _Features_GetSegment_Handler,
+		},
+		{
+			MethodName: "GetSegments",
+			Handler:    _Features_GetSegments_Handler,
+		},
+		{
+			MethodName: "SetSegment",
+			Handler:    _Features_SetSegment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchFeatures",
+			Handler:       _Features_WatchFeatures_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/feature.proto",
+}
+
+func (m *Feature) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Feature) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Feature) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Protected {
+		i--
+		if m.Protected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.Version != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.Fallthrough != nil {
+		{
+			size, err := m.Fallthrough.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.Rules) > 0 {
+		for iNdEx := len(m.Rules) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Rules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if m.Value != nil {
+		{
+			size, err := m.Value.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Variants) > 0 {
+		for iNdEx := len(m.Variants) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Variants[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.Salt) > 0 {
+		i -= len(m.Salt)
+		copy(dAtA[i:], m.Salt)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Salt)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.BucketingKey) > 0 {
+		i -= len(m.BucketingKey)
+		copy(dAtA[i:], m.BucketingKey)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.BucketingKey)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Expression) > 0 {
+		i -= len(m.Expression)
+		copy(dAtA[i:], m.Expression)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Expression)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Percentage != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Percentage))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Enabled {
+		i--
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Type != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Feature_Variant) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Feature_Variant) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Feature_Variant) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Weight != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Weight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Rule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Rule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Outcome != nil {
+		{
+			size, err := m.Outcome.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Expression) > 0 {
+		i -= len(m.Expression)
+		copy(dAtA[i:], m.Expression)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Expression)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Outcome) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Outcome) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Outcome) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Value != nil {
+		{
+			size, err := m.Value.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Kind != nil {
+		{
+			size := m.Kind.Size()
+			i -= size
+			if _, err := m.Kind.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Outcome_Enabled) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Outcome_Enabled) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i--
+	if m.Enabled {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+func (m *Outcome_Percentage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Outcome_Percentage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintFeature(dAtA, i, uint64(m.Percentage))
+	i--
+	dAtA[i] = 0x10
+	return len(dAtA) - i, nil
+}
+func (m *Outcome_Variant) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Outcome_Variant) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.Variant)
+	copy(dAtA[i:], m.Variant)
+	i = encodeVarintFeature(dAtA, i, uint64(len(m.Variant)))
+	i--
+	dAtA[i] = 0x1a
+	return len(dAtA) - i, nil
+}
+func (m *Value) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Value) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Value) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Kind != nil {
+		{
+			size := m.Kind.Size()
+			i -= size
+			if _, err := m.Kind.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Value_StringValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Value_StringValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.StringValue)
+	copy(dAtA[i:], m.StringValue)
+	i = encodeVarintFeature(dAtA, i, uint64(len(m.StringValue)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+func (m *Value_IntValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Value_IntValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintFeature(dAtA, i, uint64(m.IntValue))
+	i--
+	dAtA[i] = 0x10
+	return len(dAtA) - i, nil
+}
+func (m *Value_FloatValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Value_FloatValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= 8
+	encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.FloatValue))))
+	i--
+	dAtA[i] = 0x19
+	return len(dAtA) - i, nil
+}
+func (m *Value_JsonValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Value_JsonValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.JsonValue)
+	copy(dAtA[i:], m.JsonValue)
+	i = encodeVarintFeature(dAtA, i, uint64(len(m.JsonValue)))
+	i--
+	dAtA[i] = 0x22
+	return len(dAtA) - i, nil
+}
+func (m *Segment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Segment) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Segment) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Rules) > 0 {
+		for iNdEx := len(m.Rules) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Rules[iNdEx])
+			copy(dAtA[i:], m.Rules[iNdEx])
+			i = encodeVarintFeature(dAtA, i, uint64(len(m.Rules[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Excluded) > 0 {
+		for iNdEx := len(m.Excluded) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Excluded[iNdEx])
+			copy(dAtA[i:], m.Excluded[iNdEx])
+			i = encodeVarintFeature(dAtA, i, uint64(len(m.Excluded[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Included) > 0 {
+		for iNdEx := len(m.Included) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Included[iNdEx])
+			copy(dAtA[i:], m.Included[iNdEx])
+			i = encodeVarintFeature(dAtA, i, uint64(len(m.Included[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EvaluationDetail) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EvaluationDetail) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EvaluationDetail) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.RuleIndex != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.RuleIndex))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Bucket != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Bucket))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Reason != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Reason))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Value != nil {
+		{
+			size, err := m.Value.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Variant) > 0 {
+		i -= len(m.Variant)
+		copy(dAtA[i:], m.Variant)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Variant)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Enabled {
+		i--
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeatureOperation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeatureOperation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeatureOperation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ExpectAbsent {
+		i--
+		if m.ExpectAbsent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ExpectedVersion != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.ExpectedVersion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Op != nil {
+		{
+			size := m.Op.Size()
+			i -= size
+			if _, err := m.Op.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeatureOperation_Set) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeatureOperation_Set) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Set != nil {
+		{
+			size, err := m.Set.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *FeatureOperation_Delete) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeatureOperation_Delete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.Delete)
+	copy(dAtA[i:], m.Delete)
+	i = encodeVarintFeature(dAtA, i, uint64(len(m.Delete)))
+	i--
+	dAtA[i] = 0x12
+	return len(dAtA) - i, nil
+}
+func (m *FeatureChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeatureChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeatureChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.After != nil {
+		{
+			size, err := m.After.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Before != nil {
+		{
+			size, err := m.Before.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApplyFeaturesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplyFeaturesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApplyFeaturesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.DryRun {
+		i--
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Operations) > 0 {
+		for iNdEx := len(m.Operations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Operations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApplyFeaturesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplyFeaturesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApplyFeaturesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pending != nil {
+		{
+			size, err := m.Pending.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Changes) > 0 {
+		for iNdEx := len(m.Changes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Changes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteFeatureRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteFeatureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteFeatureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ExpectedVersion != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.ExpectedVersion))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteFeatureResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteFeatureResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteFeatureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pending != nil {
+		{
+			size, err := m.Pending.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Feature != nil {
+		{
+			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EvaluateFeatureResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EvaluateFeatureResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EvaluateFeatureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Detail != nil {
+		{
+			size, err := m.Detail.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EvaluateFeaturesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EvaluateFeaturesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EvaluateFeaturesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Details) > 0 {
+		for iNdEx := len(m.Details) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Details[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeatureEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeatureEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeatureEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Feature != nil {
+		{
+			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Revision != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Type != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeatureSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeatureSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeatureSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Epoch) > 0 {
+		i -= len(m.Epoch)
+		copy(dAtA[i:], m.Epoch)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Epoch)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Features[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Revision != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeatureRevision) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeatureRevision) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeatureRevision) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.After != nil {
+		{
+			size, err := m.After.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Before != nil {
+		{
+			size, err := m.Before.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Actor) > 0 {
+		i -= len(m.Actor)
+		copy(dAtA[i:], m.Actor)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Actor)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.TimeUnixNano != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.TimeUnixNano))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Version != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuditEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuditEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuditEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ApprovedBy) > 0 {
+		i -= len(m.ApprovedBy)
+		copy(dAtA[i:], m.ApprovedBy)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.ApprovedBy)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.PrevHash) > 0 {
+		i -= len(m.PrevHash)
+		copy(dAtA[i:], m.PrevHash)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.PrevHash)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.After != nil {
+		{
+			size, err := m.After.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Before != nil {
+		{
+			size, err := m.Before.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Peer) > 0 {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Actor) > 0 {
+		i -= len(m.Actor)
+		copy(dAtA[i:], m.Actor)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Actor)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Version != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Feature) > 0 {
+		i -= len(m.Feature)
+		copy(dAtA[i:], m.Feature)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Feature)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Action) > 0 {
+		i -= len(m.Action)
+		copy(dAtA[i:], m.Action)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Action)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TimeUnixNano != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.TimeUnixNano))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PendingChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PendingChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PendingChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ExpireTimeUnixNano != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.ExpireTimeUnixNano))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.CreateTimeUnixNano != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.CreateTimeUnixNano))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.RequestedBy) > 0 {
+		i -= len(m.RequestedBy)
+		copy(dAtA[i:], m.RequestedBy)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.RequestedBy)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Changes) > 0 {
+		for iNdEx := len(m.Changes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Changes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApproveChangeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApproveChangeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApproveChangeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApproveChangeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApproveChangeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApproveChangeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Changes) > 0 {
+		for iNdEx := len(m.Changes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Changes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListPendingChangesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListPendingChangesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListPendingChangesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListPendingChangesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListPendingChangesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListPendingChangesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Pending) > 0 {
+		for iNdEx := len(m.Pending) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Pending[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RejectChangeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RejectChangeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RejectChangeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RejectChangeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RejectChangeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RejectChangeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pending != nil {
+		{
+			size, err := m.Pending.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScheduledChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScheduledChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduledChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.CreateTimeUnixNano != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.CreateTimeUnixNano))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.RequestedBy) > 0 {
+		i -= len(m.RequestedBy)
+		copy(dAtA[i:], m.RequestedBy)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.RequestedBy)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Steps) > 0 {
+		for iNdEx := len(m.Steps) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Steps[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScheduledStep) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScheduledStep) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduledStep) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Change != nil {
+		{
+			size := m.Change.Size()
+			i -= size
+			if _, err := m.Change.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if m.TimeUnixNano != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.TimeUnixNano))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScheduledStep_Feature) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduledStep_Feature) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Feature != nil {
+		{
+			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ScheduledStep_Enabled) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduledStep_Enabled) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i--
+	if m.Enabled {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i--
+	dAtA[i] = 0x18
+	return len(dAtA) - i, nil
+}
+func (m *ScheduledStep_Percentage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduledStep_Percentage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintFeature(dAtA, i, uint64(m.Percentage))
+	i--
+	dAtA[i] = 0x20
+	return len(dAtA) - i, nil
+}
+func (m *ScheduleFeatureRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScheduleFeatureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduleFeatureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Steps) > 0 {
+		for iNdEx := len(m.Steps) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Steps[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScheduleFeatureResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScheduleFeatureResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduleFeatureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Schedule != nil {
+		{
+			size, err := m.Schedule.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListScheduledChangesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListScheduledChangesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListScheduledChangesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListScheduledChangesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListScheduledChangesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListScheduledChangesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Schedules) > 0 {
+		for iNdEx := len(m.Schedules) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Schedules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CancelScheduledChangeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CancelScheduledChangeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CancelScheduledChangeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CancelScheduledChangeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CancelScheduledChangeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CancelScheduledChangeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Schedule != nil {
+		{
+			size, err := m.Schedule.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetFeatureRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetFeatureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetFeatureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetFeatureResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetFeatureResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetFeatureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Feature != nil {
+		{
+			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetFeaturesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetFeaturesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetFeaturesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.NamesOnly {
+		i--
+		if m.NamesOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetFeaturesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetFeaturesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetFeaturesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Names) > 0 {
+		for iNdEx := len(m.Names) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Names[iNdEx])
+			copy(dAtA[i:], m.Names[iNdEx])
+			i = encodeVarintFeature(dAtA, i, uint64(len(m.Names[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Features[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListAuditEntriesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAuditEntriesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAuditEntriesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Limit != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.UntilUnixNano != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.UntilUnixNano))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.SinceUnixNano != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.SinceUnixNano))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Actor) > 0 {
+		i -= len(m.Actor)
+		copy(dAtA[i:], m.Actor)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Actor)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Feature) > 0 {
+		i -= len(m.Feature)
+		copy(dAtA[i:], m.Feature)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Feature)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListAuditEntriesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAuditEntriesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAuditEntriesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Entries) > 0 {
+		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Entries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListFeatureHistoryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListFeatureHistoryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListFeatureHistoryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListFeatureHistoryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListFeatureHistoryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListFeatureHistoryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Revisions) > 0 {
+		for iNdEx := len(m.Revisions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Revisions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RollbackFeatureRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackFeatureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RollbackFeatureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RollbackFeatureResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackFeatureResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RollbackFeatureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pending != nil {
+		{
+			size, err := m.Pending.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.After != nil {
+		{
+			size, err := m.After.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Before != nil {
+		{
+			size, err := m.Before.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchFeaturesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchFeaturesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchFeaturesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Epoch) > 0 {
+		i -= len(m.Epoch)
+		copy(dAtA[i:], m.Epoch)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Epoch)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.FromRevision != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.FromRevision))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchFeaturesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchFeaturesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchFeaturesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Event != nil {
+		{
+			size, err := m.Event.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Snapshot != nil {
+		{
+			size, err := m.Snapshot.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetFeatureRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetFeatureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetFeatureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ExpectAbsent {
+		i--
+		if m.ExpectAbsent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ExpectedVersion != 0 {
+		i = encodeVarintFeature(dAtA, i, uint64(m.ExpectedVersion))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Feature != nil {
+		{
+			size, err := m.Feature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetFeatureResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetFeatureResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetFeatureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pending != nil {
+		{
+			size, err := m.Pending.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.After != nil {
+		{
+			size, err := m.After.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Before != nil {
+		{
+			size, err := m.Before.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteSegmentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteSegmentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteSegmentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteSegmentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteSegmentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteSegmentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Segment != nil {
+		{
+			size, err := m.Segment.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetSegmentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSegmentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetSegmentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFeature(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetSegmentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSegmentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetSegmentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Segment != nil {
+		{
+			size, err := m.Segment.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetSegmentsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSegmentsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetSegmentsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetSegmentsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSegmentsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetSegmentsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Segments) > 0 {
+		for iNdEx := len(m.Segments) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Segments[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFeature(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetSegmentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetSegmentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetSegmentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Segment != nil {
+		{
+			size, err := m.Segment.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetSegmentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetSegmentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetSegmentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.After != nil {
+		{
+			size, err := m.After.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Before != nil {
+		{
+			size, err := m.Before.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFeature(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintFeature(dAtA []byte, offset int, v uint64) int {
+	offset -= sovFeature(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Feature) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovFeature(uint64(m.Type))
+	}
+	if m.Enabled {
+		n += 2
+	}
+	if m.Percentage != 0 {
+		n += 1 + sovFeature(uint64(m.Percentage))
+	}
+	l = len(m.Expression)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.BucketingKey)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Salt)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if len(m.Variants) > 0 {
+		for _, e := range m.Variants {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.Value != nil {
+		l = m.Value.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if len(m.Rules) > 0 {
+		for _, e := range m.Rules {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.Fallthrough != nil {
+		l = m.Fallthrough.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovFeature(uint64(m.Version))
+	}
+	if m.Protected {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Feature_Variant) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Weight != 0 {
+		n += 1 + sovFeature(uint64(m.Weight))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Rule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Expression)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Outcome != nil {
+		l = m.Outcome.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Outcome) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Kind != nil {
+		n += m.Kind.Size()
+	}
+	if m.Value != nil {
+		l = m.Value.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Outcome_Enabled) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 2
+	return n
+}
+func (m *Outcome_Percentage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovFeature(uint64(m.Percentage))
+	return n
+}
+func (m *Outcome_Variant) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Variant)
+	n += 1 + l + sovFeature(uint64(l))
+	return n
+}
+func (m *Value) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Kind != nil {
+		n += m.Kind.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Value_StringValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.StringValue)
+	n += 1 + l + sovFeature(uint64(l))
+	return n
+}
+func (m *Value_IntValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovFeature(uint64(m.IntValue))
+	return n
+}
+func (m *Value_FloatValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 9
+	return n
+}
+func (m *Value_JsonValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JsonValue)
+	n += 1 + l + sovFeature(uint64(l))
+	return n
+}
+func (m *Segment) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if len(m.Included) > 0 {
+		for _, s := range m.Included {
+			l = len(s)
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if len(m.Excluded) > 0 {
+		for _, s := range m.Excluded {
+			l = len(s)
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if len(m.Rules) > 0 {
+		for _, s := range m.Rules {
+			l = len(s)
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EvaluationDetail) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Enabled {
+		n += 2
+	}
+	l = len(m.Variant)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Value != nil {
+		l = m.Value.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Reason != 0 {
+		n += 1 + sovFeature(uint64(m.Reason))
+	}
+	if m.Bucket != 0 {
+		n += 1 + sovFeature(uint64(m.Bucket))
+	}
+	if m.RuleIndex != 0 {
+		n += 1 + sovFeature(uint64(m.RuleIndex))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovFeature(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FeatureOperation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Op != nil {
+		n += m.Op.Size()
+	}
+	if m.ExpectedVersion != 0 {
+		n += 1 + sovFeature(uint64(m.ExpectedVersion))
+	}
+	if m.ExpectAbsent {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FeatureOperation_Set) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Set != nil {
+		l = m.Set.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	return n
+}
+func (m *FeatureOperation_Delete) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Delete)
+	n += 1 + l + sovFeature(uint64(l))
+	return n
+}
+func (m *FeatureChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Before != nil {
+		l = m.Before.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.After != nil {
+		l = m.After.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ApplyFeaturesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Operations) > 0 {
+		for _, e := range m.Operations {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.DryRun {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ApplyFeaturesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.Pending != nil {
+		l = m.Pending.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteFeatureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.ExpectedVersion != 0 {
+		n += 1 + sovFeature(uint64(m.ExpectedVersion))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteFeatureResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Feature != nil {
+		l = m.Feature.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Pending != nil {
+		l = m.Pending.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EvaluateFeatureResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Detail != nil {
+		l = m.Detail.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EvaluateFeaturesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Details) > 0 {
+		for _, e := range m.Details {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FeatureEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovFeature(uint64(m.Type))
+	}
+	if m.Revision != 0 {
+		n += 1 + sovFeature(uint64(m.Revision))
+	}
+	if m.Feature != nil {
+		l = m.Feature.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FeatureSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Revision != 0 {
+		n += 1 + sovFeature(uint64(m.Revision))
+	}
+	if len(m.Features) > 0 {
+		for _, e := range m.Features {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	l = len(m.Epoch)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FeatureRevision) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovFeature(uint64(m.Version))
+	}
+	if m.TimeUnixNano != 0 {
+		n += 1 + sovFeature(uint64(m.TimeUnixNano))
+	}
+	l = len(m.Actor)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Before != nil {
+		l = m.Before.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.After != nil {
+		l = m.After.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AuditEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TimeUnixNano != 0 {
+		n += 1 + sovFeature(uint64(m.TimeUnixNano))
+	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Feature)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovFeature(uint64(m.Version))
+	}
+	l = len(m.Actor)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Peer)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Before != nil {
+		l = m.Before.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.After != nil {
+		l = m.After.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.PrevHash)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.ApprovedBy)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PendingChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	l = len(m.RequestedBy)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.CreateTimeUnixNano != 0 {
+		n += 1 + sovFeature(uint64(m.CreateTimeUnixNano))
+	}
+	if m.ExpireTimeUnixNano != 0 {
+		n += 1 + sovFeature(uint64(m.ExpireTimeUnixNano))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ApproveChangeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ApproveChangeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListPendingChangesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListPendingChangesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Pending) > 0 {
+		for _, e := range m.Pending {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RejectChangeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RejectChangeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pending != nil {
+		l = m.Pending.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ScheduledChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if len(m.Steps) > 0 {
+		for _, e := range m.Steps {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	l = len(m.RequestedBy)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.CreateTimeUnixNano != 0 {
+		n += 1 + sovFeature(uint64(m.CreateTimeUnixNano))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ScheduledStep) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TimeUnixNano != 0 {
+		n += 1 + sovFeature(uint64(m.TimeUnixNano))
+	}
+	if m.Change != nil {
+		n += m.Change.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ScheduledStep_Feature) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Feature != nil {
+		l = m.Feature.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	return n
+}
+func (m *ScheduledStep_Enabled) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 2
+	return n
+}
+func (m *ScheduledStep_Percentage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovFeature(uint64(m.Percentage))
+	return n
+}
+func (m *ScheduleFeatureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if len(m.Steps) > 0 {
+		for _, e := range m.Steps {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ScheduleFeatureResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Schedule != nil {
+		l = m.Schedule.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListScheduledChangesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListScheduledChangesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Schedules) > 0 {
+		for _, e := range m.Schedules {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CancelScheduledChangeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CancelScheduledChangeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Schedule != nil {
+		l = m.Schedule.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetFeatureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetFeatureResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Feature != nil {
+		l = m.Feature.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetFeaturesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NamesOnly {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetFeaturesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Features) > 0 {
+		for _, e := range m.Features {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if len(m.Names) > 0 {
+		for _, s := range m.Names {
+			l = len(s)
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListAuditEntriesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Feature)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	l = len(m.Actor)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.SinceUnixNano != 0 {
+		n += 1 + sovFeature(uint64(m.SinceUnixNano))
+	}
+	if m.UntilUnixNano != 0 {
+		n += 1 + sovFeature(uint64(m.UntilUnixNano))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovFeature(uint64(m.Limit))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListAuditEntriesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListFeatureHistoryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListFeatureHistoryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Revisions) > 0 {
+		for _, e := range m.Revisions {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RollbackFeatureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovFeature(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RollbackFeatureResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Before != nil {
+		l = m.Before.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.After != nil {
+		l = m.After.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Pending != nil {
+		l = m.Pending.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WatchFeaturesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromRevision != 0 {
+		n += 1 + sovFeature(uint64(m.FromRevision))
+	}
+	l = len(m.Epoch)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WatchFeaturesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		l = m.Snapshot.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Event != nil {
+		l = m.Event.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetFeatureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Feature != nil {
+		l = m.Feature.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.ExpectedVersion != 0 {
+		n += 1 + sovFeature(uint64(m.ExpectedVersion))
+	}
+	if m.ExpectAbsent {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetFeatureResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Before != nil {
+		l = m.Before.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.After != nil {
+		l = m.After.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.Pending != nil {
+		l = m.Pending.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteSegmentRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteSegmentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Segment != nil {
+		l = m.Segment.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetSegmentRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetSegmentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Segment != nil {
+		l = m.Segment.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetSegmentsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetSegmentsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Segments) > 0 {
+		for _, e := range m.Segments {
+			l = e.Size()
+			n += 1 + l + sovFeature(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetSegmentRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Segment != nil {
+		l = m.Segment.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetSegmentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Before != nil {
+		l = m.Before.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.After != nil {
+		l = m.After.Size()
+		n += 1 + l + sovFeature(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovFeature(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozFeature(x uint64) (n int) {
+	return sovFeature(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Feature) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Feature: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Feature: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= Feature_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Percentage", wireType)
+			}
+			m.Percentage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Percentage |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Expression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BucketingKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BucketingKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Salt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Salt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Variants", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Variants = append(m.Variants, &Feature_Variant{})
+			if err := m.Variants[len(m.Variants)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Value == nil {
+				m.Value = &Value{}
+			}
+			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rules = append(m.Rules, &Rule{})
+			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fallthrough", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Fallthrough == nil {
+				m.Fallthrough = &Outcome{}
+			}
+			if err := m.Fallthrough.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protected", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Protected = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Feature_Variant) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Variant: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Variant: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+			}
+			m.Weight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Weight |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Rule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Rule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Rule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Expression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Outcome", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Outcome == nil {
+				m.Outcome = &Outcome{}
+			}
+			if err := m.Outcome.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Outcome) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Outcome: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Outcome: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Kind = &Outcome_Enabled{b}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Percentage", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Kind = &Outcome_Percentage{v}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Variant", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kind = &Outcome_Variant{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Value == nil {
+				m.Value = &Value{}
+			}
+			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Value) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Value: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Value: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StringValue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kind = &Value_StringValue{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntValue", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Kind = &Value_IntValue{v}
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FloatValue", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Kind = &Value_FloatValue{float64(math.Float64frombits(v))}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JsonValue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kind = &Value_JsonValue{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Segment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Segment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Segment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Included", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Included = append(m.Included, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Excluded", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Excluded = append(m.Excluded, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rules = append(m.Rules, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EvaluationDetail) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EvaluationDetail: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EvaluationDetail: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Variant", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Variant = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Value == nil {
+				m.Value = &Value{}
+			}
+			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			m.Reason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Reason |= EvaluationDetail_Reason(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bucket", wireType)
+			}
+			m.Bucket = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bucket |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RuleIndex", wireType)
+			}
+			m.RuleIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RuleIndex |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeatureOperation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeatureOperation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeatureOperation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Set", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Feature{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Op = &FeatureOperation_Set{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Op = &FeatureOperation_Delete{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedVersion", wireType)
+			}
+			m.ExpectedVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectAbsent", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExpectAbsent = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeatureChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeatureChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeatureChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Before == nil {
+				m.Before = &Feature{}
+			}
+			if err := m.Before.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.After == nil {
+				m.After = &Feature{}
+			}
+			if err := m.After.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApplyFeaturesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplyFeaturesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplyFeaturesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operations = append(m.Operations, &FeatureOperation{})
+			if err := m.Operations[len(m.Operations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApplyFeaturesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplyFeaturesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplyFeaturesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, &FeatureChange{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pending == nil {
+				m.Pending = &PendingChange{}
+			}
+			if err := m.Pending.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteFeatureRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteFeatureRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteFeatureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedVersion", wireType)
+			}
+			m.ExpectedVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteFeatureResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteFeatureResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteFeatureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Feature == nil {
+				m.Feature = &Feature{}
+			}
+			if err := m.Feature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pending == nil {
+				m.Pending = &PendingChange{}
+			}
+			if err := m.Pending.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EvaluateFeatureResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EvaluateFeatureResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EvaluateFeatureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Detail", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Detail == nil {
+				m.Detail = &EvaluationDetail{}
+			}
+			if err := m.Detail.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EvaluateFeaturesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EvaluateFeaturesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EvaluateFeaturesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Details", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Details = append(m.Details, &EvaluationDetail{})
+			if err := m.Details[len(m.Details)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeatureEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeatureEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeatureEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= FeatureEvent_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Feature == nil {
+				m.Feature = &Feature{}
+			}
+			if err := m.Feature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeatureSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeatureSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeatureSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, &Feature{})
+			if err := m.Features[len(m.Features)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Epoch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeatureRevision) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeatureRevision: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeatureRevision: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeUnixNano", wireType)
+			}
+			m.TimeUnixNano = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeUnixNano |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Actor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Actor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Before == nil {
+				m.Before = &Feature{}
+			}
+			if err := m.Before.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.After == nil {
+				m.After = &Feature{}
+			}
+			if err := m.After.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuditEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuditEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuditEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeUnixNano", wireType)
+			}
+			m.TimeUnixNano = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeUnixNano |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Action = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Feature = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Actor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Actor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Before == nil {
+				m.Before = &Feature{}
+			}
+			if err := m.Before.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.After == nil {
+				m.After = &Feature{}
+			}
+			if err := m.After.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrevHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApprovedBy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ApprovedBy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PendingChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PendingChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PendingChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, &FeatureChange{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestedBy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestedBy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateTimeUnixNano", wireType)
+			}
+			m.CreateTimeUnixNano = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreateTimeUnixNano |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTimeUnixNano", wireType)
+			}
+			m.ExpireTimeUnixNano = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireTimeUnixNano |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApproveChangeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApproveChangeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApproveChangeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApproveChangeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApproveChangeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApproveChangeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, &FeatureChange{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListPendingChangesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListPendingChangesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListPendingChangesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListPendingChangesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListPendingChangesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListPendingChangesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pending = append(m.Pending, &PendingChange{})
+			if err := m.Pending[len(m.Pending)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RejectChangeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RejectChangeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RejectChangeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RejectChangeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RejectChangeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RejectChangeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pending == nil {
+				m.Pending = &PendingChange{}
+			}
+			if err := m.Pending.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScheduledChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScheduledChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScheduledChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Steps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Steps = append(m.Steps, &ScheduledStep{})
+			if err := m.Steps[len(m.Steps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestedBy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestedBy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateTimeUnixNano", wireType)
+			}
+			m.CreateTimeUnixNano = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreateTimeUnixNano |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScheduledStep) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScheduledStep: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScheduledStep: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeUnixNano", wireType)
+			}
+			m.TimeUnixNano = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeUnixNano |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Feature{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Change = &ScheduledStep_Feature{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Change = &ScheduledStep_Enabled{b}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Percentage", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Change = &ScheduledStep_Percentage{v}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScheduleFeatureRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScheduleFeatureRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScheduleFeatureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Steps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Steps = append(m.Steps, &ScheduledStep{})
+			if err := m.Steps[len(m.Steps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScheduleFeatureResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScheduleFeatureResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScheduleFeatureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schedule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Schedule == nil {
+				m.Schedule = &ScheduledChange{}
+			}
+			if err := m.Schedule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListScheduledChangesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListScheduledChangesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListScheduledChangesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListScheduledChangesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListScheduledChangesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListScheduledChangesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schedules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Schedules = append(m.Schedules, &ScheduledChange{})
+			if err := m.Schedules[len(m.Schedules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelScheduledChangeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelScheduledChangeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelScheduledChangeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelScheduledChangeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelScheduledChangeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelScheduledChangeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schedule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Schedule == nil {
+				m.Schedule = &ScheduledChange{}
+			}
+			if err := m.Schedule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetFeatureRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetFeatureRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetFeatureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetFeatureResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetFeatureResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetFeatureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Feature == nil {
+				m.Feature = &Feature{}
+			}
+			if err := m.Feature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetFeaturesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetFeaturesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetFeaturesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamesOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NamesOnly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetFeaturesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetFeaturesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetFeaturesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, &Feature{})
+			if err := m.Features[len(m.Features)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Names", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Names = append(m.Names, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListAuditEntriesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAuditEntriesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAuditEntriesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Feature = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Actor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Actor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SinceUnixNano", wireType)
+			}
+			m.SinceUnixNano = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SinceUnixNano |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UntilUnixNano", wireType)
+			}
+			m.UntilUnixNano = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UntilUnixNano |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListAuditEntriesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAuditEntriesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAuditEntriesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, &AuditEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListFeatureHistoryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListFeatureHistoryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListFeatureHistoryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListFeatureHistoryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListFeatureHistoryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListFeatureHistoryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revisions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Revisions = append(m.Revisions, &FeatureRevision{})
+			if err := m.Revisions[len(m.Revisions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RollbackFeatureRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RollbackFeatureRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RollbackFeatureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RollbackFeatureResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RollbackFeatureResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RollbackFeatureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Before == nil {
+				m.Before = &Feature{}
+			}
+			if err := m.Before.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.After == nil {
+				m.After = &Feature{}
+			}
+			if err := m.After.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pending == nil {
+				m.Pending = &PendingChange{}
+			}
+			if err := m.Pending.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchFeaturesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchFeaturesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchFeaturesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromRevision", wireType)
+			}
+			m.FromRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromRevision |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Epoch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchFeaturesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchFeaturesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchFeaturesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Snapshot == nil {
+				m.Snapshot = &FeatureSnapshot{}
+			}
+			if err := m.Snapshot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeature
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Event == nil {
+				m.Event = &FeatureEvent{}
+			}
+			if err := m.Event.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovFeature(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozFeature(x uint64) (n int) {
-	return sovFeature(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Feature) Unmarshal(dAtA []byte) error {
+func (m *SetFeatureRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1388,17 +15576,17 @@ func (m *Feature) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Feature: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetFeatureRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Feature: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetFeatureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFeature
@@ -1408,29 +15596,33 @@ func (m *Feature) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFeature
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthFeature
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if m.Feature == nil {
+				m.Feature = &Feature{}
+			}
+			if err := m.Feature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedVersion", wireType)
 			}
-			m.Type = 0
+			m.ExpectedVersion = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFeature
@@ -1440,14 +15632,14 @@ func (m *Feature) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= Feature_Type(b&0x7F) << shift
+				m.ExpectedVersion |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectAbsent", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -1464,12 +15656,63 @@ func (m *Feature) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Enabled = bool(v != 0)
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Percentage", wireType)
+			m.ExpectAbsent = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeature(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.Percentage = 0
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetFeatureResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeature
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetFeatureResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetFeatureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFeature
@@ -1479,16 +15722,33 @@ func (m *Feature) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Percentage |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			if msglen < 0 {
+				return ErrInvalidLengthFeature
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeature
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Before == nil {
+				m.Before = &Feature{}
+			}
+			if err := m.Before.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Expression", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFeature
@@ -1498,29 +15758,33 @@ func (m *Feature) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFeature
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthFeature
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Expression = string(dAtA[iNdEx:postIndex])
+			if m.After == nil {
+				m.After = &Feature{}
+			}
+			if err := m.After.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFeature
@@ -1530,23 +15794,27 @@ func (m *Feature) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFeature
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthFeature
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Description = string(dAtA[iNdEx:postIndex])
+			if m.Pending == nil {
+				m.Pending = &PendingChange{}
+			}
+			if err := m.Pending.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1570,7 +15838,7 @@ func (m *Feature) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteFeatureRequest) Unmarshal(dAtA []byte) error {
+func (m *DeleteSegmentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1593,10 +15861,10 @@ func (m *DeleteFeatureRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteFeatureRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteSegmentRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteFeatureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteSegmentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1653,7 +15921,7 @@ func (m *DeleteFeatureRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteFeatureResponse) Unmarshal(dAtA []byte) error {
+func (m *DeleteSegmentResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1676,15 +15944,15 @@ func (m *DeleteFeatureResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteFeatureResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteSegmentResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteFeatureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteSegmentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Segment", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1711,10 +15979,10 @@ func (m *DeleteFeatureResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Feature == nil {
-				m.Feature = &Feature{}
+			if m.Segment == nil {
+				m.Segment = &Segment{}
 			}
-			if err := m.Feature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Segment.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1740,7 +16008,7 @@ func (m *DeleteFeatureResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetFeatureRequest) Unmarshal(dAtA []byte) error {
+func (m *GetSegmentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1763,10 +16031,10 @@ func (m *GetFeatureRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetFeatureRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetSegmentRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetFeatureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetSegmentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1823,7 +16091,7 @@ func (m *GetFeatureRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetFeatureResponse) Unmarshal(dAtA []byte) error {
+func (m *GetSegmentResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1846,15 +16114,15 @@ func (m *GetFeatureResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetFeatureResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetSegmentResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetFeatureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetSegmentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Segment", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1881,10 +16149,10 @@ func (m *GetFeatureResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Feature == nil {
-				m.Feature = &Feature{}
+			if m.Segment == nil {
+				m.Segment = &Segment{}
 			}
-			if err := m.Feature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Segment.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1910,7 +16178,7 @@ func (m *GetFeatureResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetFeaturesRequest) Unmarshal(dAtA []byte) error {
+func (m *GetSegmentsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1933,32 +16201,12 @@ func (m *GetFeaturesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetFeaturesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetSegmentsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetFeaturesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetSegmentsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NamesOnly", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFeature
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.NamesOnly = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFeature(dAtA[iNdEx:])
@@ -1981,7 +16229,7 @@ func (m *GetFeaturesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetFeaturesResponse) Unmarshal(dAtA []byte) error {
+func (m *GetSegmentsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2004,15 +16252,15 @@ func (m *GetFeaturesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetFeaturesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetSegmentsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetFeaturesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetSegmentsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Segments", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2039,43 +16287,11 @@ func (m *GetFeaturesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Features = append(m.Features, &Feature{})
-			if err := m.Features[len(m.Features)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Segments = append(m.Segments, &Segment{})
+			if err := m.Segments[len(m.Segments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Names", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFeature
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFeature
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthFeature
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Names = append(m.Names, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFeature(dAtA[iNdEx:])
@@ -2098,7 +16314,7 @@ func (m *GetFeaturesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetFeatureRequest) Unmarshal(dAtA []byte) error {
+func (m *SetSegmentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2121,15 +16337,15 @@ func (m *SetFeatureRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetFeatureRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetSegmentRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetFeatureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetSegmentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Feature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Segment", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2156,10 +16372,10 @@ func (m *SetFeatureRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Feature == nil {
-				m.Feature = &Feature{}
+			if m.Segment == nil {
+				m.Segment = &Segment{}
 			}
-			if err := m.Feature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Segment.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2185,7 +16401,7 @@ func (m *SetFeatureRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetFeatureResponse) Unmarshal(dAtA []byte) error {
+func (m *SetSegmentResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2208,10 +16424,10 @@ func (m *SetFeatureResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetFeatureResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetSegmentResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetFeatureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetSegmentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2244,7 +16460,7 @@ func (m *SetFeatureResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Before == nil {
-				m.Before = &Feature{}
+				m.Before = &Segment{}
 			}
 			if err := m.Before.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -2280,7 +16496,7 @@ func (m *SetFeatureResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.After == nil {
-				m.After = &Feature{}
+				m.After = &Segment{}
 			}
 			if err := m.After.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -0,0 +1,9 @@
+package feature
+
+import "google.golang.org/grpc"
+
+// RegisterFeaturesService is like the generated RegisterFeaturesServer, but
+// accepts any grpc.ServiceRegistrar rather than only a *grpc.Server.
+func RegisterFeaturesService(s grpc.ServiceRegistrar, srv FeaturesServer) {
+	s.RegisterService(&_Features_serviceDesc, srv)
+}